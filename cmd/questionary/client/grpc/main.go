@@ -2,17 +2,37 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"os"
 
 	"github.com/ismaeljpv/qa-api/pkg/questionary/transport/grpc/protobuff"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func main() {
+	var addr = flag.String("addr", ":50051", "GRPC server address")
+	var caFile = flag.String("tls-ca", "", "PEM bundle of the CAs of the server, the connection uses TLS when it is set")
+	var certFile = flag.String("tls-cert", "", "PEM client certificate for mutual TLS")
+	var keyFile = flag.String("tls-key", "", "PEM key of the client certificate")
+	var serverName = flag.String("tls-server-name", "", "name of the server certificate when it is not the host of the address")
+	flag.Parse()
+
+	creds, err := transportCredentials(*caFile, *certFile, *keyFile, *serverName)
+	if err != nil {
+		log.Fatalf("TLS error %s", err)
+	}
+
 	var conn *grpc.ClientConn
-	conn, err := grpc.Dial(":50051", grpc.WithInsecure())
+	conn, err = grpc.Dial(*addr, grpc.WithTransportCredentials(creds))
 	if err != nil {
 		log.Fatalf("Connection error %s", err)
 	}
@@ -20,14 +40,20 @@ func main() {
 
 	var ctx context.Context
 	ctx = context.Background()
+	if token := os.Getenv("QA_API_TOKEN"); token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+	if apiKey := os.Getenv("QA_API_KEY"); apiKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", apiKey)
+	}
 	client := protobuff.NewQuestionaryServiceClient(conn)
 
-	questions, err := client.FindAll(ctx, &protobuff.EmptyMessage{})
+	questions, err := client.FindAll(ctx, &protobuff.FindAllRequest{PageSize: 10, Sort: "newest"})
 	if err != nil {
 		fmt.Println(err.Error())
 	}
 
-	questionsByUser, err := client.FindByUser(ctx, wrapperspb.String("1"))
+	questionsByUser, err := client.FindByUser(ctx, &protobuff.UserPageRequest{UserID: "1", PageSize: 10})
 	if err != nil {
 		fmt.Println(err.Error())
 	}
@@ -51,6 +77,14 @@ func main() {
 		fmt.Println(err.Error())
 	}
 
+	answers, err := client.FindAnswers(ctx, wrapperspb.String(createdQuestion.GetID()))
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+	if len(updatedInfo.GetAnswers()) > 0 {
+		newAnswer = updatedInfo.GetAnswers()[len(updatedInfo.GetAnswers())-1]
+	}
+
 	question, err := client.FindByID(ctx, wrapperspb.String(updatedInfo.GetQuestion().GetID()))
 	if err != nil {
 		fmt.Println(err.Error())
@@ -61,7 +95,19 @@ func main() {
 		fmt.Printf("\nerror => %v , value returned = %v\n\n", err.Error(), notFound)
 	}
 
+	answerUpdate := &protobuff.Answer{
+		ID:         newAnswer.GetID(),
+		Answer:     "gRPC is really awesome!",
+		UserID:     newAnswer.GetUserID(),
+		QuestionID: newAnswer.GetQuestionID(),
+	}
+	updatedAnswer, err := client.UpdateAnswer(ctx, answerUpdate)
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+
 	updatedInfo.Question.Statement = "do you think gRPC great?"
+	updatedInfo.Answer = updatedAnswer
 	update := &protobuff.QuestionUpdate{
 		QuestionInfo: updatedInfo,
 		QuestionID:   updatedInfo.GetQuestion().GetID(),
@@ -72,7 +118,12 @@ func main() {
 		fmt.Println(err.Error())
 	}
 
-	deleted, err := client.Delete(ctx, wrapperspb.String(createdQuestion.GetID()))
+	deletedAnswer, err := client.DeleteAnswer(ctx, &protobuff.AnswerID{QuestionID: createdQuestion.GetID(), AnswerID: updatedAnswer.GetID()})
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+
+	deleted, err := client.Delete(ctx, &protobuff.DeleteRequest{QuestionID: createdQuestion.GetID(), UserID: createdQuestion.GetUserID()})
 	if err != nil {
 		fmt.Println(err.Error())
 	}
@@ -81,7 +132,35 @@ func main() {
 	fmt.Printf("Questionary - method findByUser(), result len = %v \n\n", len(questionsByUser.GetQuestions()))
 	fmt.Printf("Questionary - method findByID(), statement = %v\n\n", question.GetQuestion().GetStatement())
 	fmt.Printf("Questionary - method Create(), NEW question ID = %v, statement = %v\n\n", createdQuestion.GetID(), createdQuestion.GetStatement())
-	fmt.Printf("Questionary - method AddAnswer(), NEW answer ID = %v, answer = %v\n\n", updatedInfo.GetAnswer().GetID(), updatedInfo.GetAnswer().GetAnswer())
+	fmt.Printf("Questionary - method AddAnswer(), NEW answer ID = %v, answer = %v\n\n", newAnswer.GetID(), newAnswer.GetAnswer())
+	fmt.Printf("Questionary - method FindAnswers(), result len = %v\n\n", len(answers.GetAnswers()))
+	fmt.Printf("Questionary - method UpdateAnswer(), updated ID = %v, answer = %v\n\n", updatedAnswer.GetID(), updatedAnswer.GetAnswer())
 	fmt.Printf("Questionary - method Update(), updated ID = %v, info = %v\n\n", updatedQuestion.GetQuestion().GetID(), updatedInfo.String())
+	fmt.Printf("Questionary - method DeleteAnswer(), message = %v\n\n", deletedAnswer.GetMessage())
 	fmt.Printf("Questionary - method Delete(), message = %v\n\n", deleted.GetMessage())
 }
+
+//Returns the TLS credentials of the CA bundle and the client certificate, or insecure credentials when there is no CA bundle
+func transportCredentials(caFile, certFile, keyFile, serverName string) (credentials.TransportCredentials, error) {
+	if caFile == "" {
+		return insecure.NewCredentials(), nil
+	}
+	data, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates in %s", caFile)
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: rootCAs, ServerName: serverName}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(cfg), nil
+}
@@ -2,64 +2,156 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/auth"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/config"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/health"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/logging"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/ratelimit"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/repository"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/repository/mongoDB"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/requestid"
+	adminserver "github.com/ismaeljpv/qa-api/pkg/questionary/server/admin"
 	grpcserver "github.com/ismaeljpv/qa-api/pkg/questionary/server/grpc"
 	httpserver "github.com/ismaeljpv/qa-api/pkg/questionary/server/http"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/service"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/tlsconfig"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/tracing"
 	grpctransport "github.com/ismaeljpv/qa-api/pkg/questionary/transport/grpc"
 	pb "github.com/ismaeljpv/qa-api/pkg/questionary/transport/grpc/protobuff"
 	httptransport "github.com/ismaeljpv/qa-api/pkg/questionary/transport/http"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
-const mongoDBURI = "MONGODB_URI"
+//Timeout of each health check and interval between the updates of the gRPC health status
+const (
+	healthCheckTimeout  = 2 * time.Second
+	healthCheckInterval = 10 * time.Second
+)
 
 func main() {
-	var httpAddr = flag.String("http", ":8080", "HTTP listen address")
-	var logger log.Logger
-	var grpcAddr = ":50051"
-	logger = log.NewLogfmtLogger(os.Stderr)
+	cfg := loadConfig()
+	logger, logLvl, logErr := newLogger(cfg.Log)
+	if logErr != nil {
+		panic(logErr)
+	}
 	logger = log.NewSyncLogger(logger)
+	logger = logging.NewLevelFilter(logger, logLvl)
 	logger = log.With(logger,
 		"service", "questionary",
 		"time:", log.DefaultTimestampUTC,
 		"caller", log.DefaultCaller,
 	)
-	errs := make(chan error)
-	connURI, confErr := config.GetConfig(mongoDBURI)
-	if confErr != nil {
-		panic(confErr)
-	}
+	//The channel has room for the error of each server and the signal, so no sender blocks during the shutdown
+	errs := make(chan error, 4)
 
-	level.Info(logger).Log("msg", fmt.Sprintf("Connection URI prepared -> %v", connURI))
+	level.Info(logger).Log("msg", fmt.Sprintf("Database prepared -> %v, questions collection %v", cfg.MongoDB.Database, cfg.MongoDB.QuestionCollection))
 	level.Info(logger).Log("msg", "service started")
 	defer level.Info(logger).Log("msg", "service ended")
 
-	flag.Parse()
 	ctx := context.Background()
 
-	repo, repoErr := mongoDB.NewRepository(ctx, logger, connURI)
+	traceCfg := newTraceConfig(cfg.Trace)
+	shutdownTracing, traceErr := tracing.Setup(ctx, traceCfg)
+	if traceErr != nil {
+		panic(traceErr)
+	}
+	if traceCfg.Exporter != tracing.ExporterNone {
+		level.Info(logger).Log("msg", fmt.Sprintf("Traces are exported with the %v exporter", traceCfg.Exporter))
+	}
+
+	repo, repoErr := mongoDB.NewRepository(ctx, logger, mongoDB.Config{
+		URI:                cfg.MongoDB.URI,
+		Database:           cfg.MongoDB.Database,
+		QuestionCollection: cfg.MongoDB.QuestionCollection,
+	})
 	if repoErr != nil {
 		panic(repoErr)
 	}
+	repo = repository.NewTracingMiddleware(tracing.Tracer())(repo)
+	repo = instrumentRepository(repo)
+	repo = repository.NewRoleCache(cfg.Auth.RoleCacheTTL)(repo)
+
+	checker := health.NewChecker(healthCheckTimeout)
+	checker.Add("mongodb", repo.Ping)
+
+	verifier, authErr := newVerifier(cfg.Auth)
+	if authErr != nil {
+		panic(authErr)
+	}
+
+	var serv service.Service
+	serv = service.NewService(repo, logger)
+	serv = service.NewAuthorizationMiddleware(logger)(serv)
+	serv = service.NewTracingMiddleware(tracing.Tracer())(serv)
+	serv = instrumentService(serv)
+	serv = service.NewLoggingMiddleware(logger)(serv)
+	verifier = verifier.WithAPIKeys(serv)
 
-	serv := service.NewService(repo, logger)
-	httpEndpoints := httptransport.MakeEndpoints(serv)
-	grpcEndpoints := grpctransport.MakeEndpoints(serv)
+	adminId := cfg.Auth.AdminUserID
+	if adminId != "" {
+		if _, err := serv.GrantRole(ctx, adminId, domain.RoleAdmin); err != nil {
+			panic(err)
+		}
+		level.Info(logger).Log("msg", fmt.Sprintf("User %v has the admin role", adminId))
+	}
+
+	retention, interval := cfg.Purge.Retention, cfg.Purge.Interval
+	level.Info(logger).Log("msg", fmt.Sprintf("Deleted questions are purged after %v, every %v", retention, interval))
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	var jobs sync.WaitGroup
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		service.RunPurgeJob(jobsCtx, serv, logger, retention, interval)
+	}()
+
+	drain := cfg.Server.ShutdownTimeout
+
+	limits, limitsErr := ratelimit.ParseLimits(cfg.RateLimits)
+	if limitsErr != nil {
+		panic(limitsErr)
+	}
+	level.Info(logger).Log("msg", fmt.Sprintf("Rate limits of the endpoints -> %v", cfg.RateLimits))
+	limiters := ratelimit.NewLimiters(limits)
+	httpEndpoints := httptransport.MakeEndpoints(serv, repo, limiters)
+	grpcEndpoints := grpctransport.MakeEndpoints(serv, repo, limiters)
+
+	var reloader *tlsconfig.Reloader
+	if tlsCfg := cfg.TLS.Config(); tlsCfg.Enabled() {
+		var tlsErr error
+		if reloader, tlsErr = tlsconfig.NewReloader(tlsCfg); tlsErr != nil {
+			panic(tlsErr)
+		}
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			reloadOnSIGHUP(jobsCtx, reloader, logger)
+		}()
+		level.Info(logger).Log("msg", fmt.Sprintf("TLS enabled on the HTTP and GRPC Servers, client certificates -> %v", tlsCfg.ClientAuthMode()))
+	}
 
 	grpcServer := grpcserver.NewGRPCServer(grpcEndpoints, logger)
-	grpcListener, err := net.Listen("tcp", grpcAddr)
+	grpcListener, err := net.Listen("tcp", cfg.Server.GRPCAddr)
 	if err != nil {
 		logger.Log("during", "Listen", "err", err)
 		os.Exit(1)
@@ -71,19 +163,234 @@ func main() {
 		errs <- fmt.Errorf("%s", <-c)
 	}()
 
+	grpcOptions := []grpc.ServerOption{grpc.ChainUnaryInterceptor(
+		requestid.UnaryServerInterceptor(),
+		tracing.UnaryServerInterceptor(),
+		auth.UnaryServerInterceptor(verifier, grpcserver.PublicMethods),
+		grpctransport.UnaryErrorInterceptor(),
+	)}
+	if reloader != nil {
+		grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(reloader.ServerConfig("h2"))))
+	}
+	baseServer := grpc.NewServer(grpcOptions...)
+	pb.RegisterQuestionaryServiceServer(baseServer, grpcServer)
+	if cfg.Server.GRPCReflection {
+		reflection.Register(baseServer)
+		level.Info(logger).Log("msg", "GRPC Server reflection enabled")
+	}
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(baseServer, healthServer)
+	jobs.Add(1)
 	go func() {
-		level.Info(logger).Log("msg", fmt.Sprintf("GRPC Server started listening on port %v", grpcAddr))
-		baseServer := grpc.NewServer()
-		pb.RegisterQuestionaryServiceServer(baseServer, grpcServer)
+		defer jobs.Done()
+		health.WatchGRPC(jobsCtx, checker, healthServer, healthCheckInterval, grpcserver.ServiceName)
+	}()
+	go func() {
+		level.Info(logger).Log("msg", fmt.Sprintf("GRPC Server started listening on port %v", cfg.Server.GRPCAddr))
 		errs <- baseServer.Serve(grpcListener)
 	}()
 
+	adminAddress := cfg.Server.AdminAddr
+	adminServer := &http.Server{Addr: adminAddress, Handler: adminserver.NewAdminServer(logLvl, cfg.Server.AdminToken)}
+	go func() {
+		level.Info(logger).Log("msg", fmt.Sprintf("Admin Server listening on port %v", adminAddress))
+		errs <- adminServer.ListenAndServe()
+	}()
+
+	httpServer := &http.Server{Addr: cfg.Server.HTTPAddr, Handler: httpserver.NewHTTPServer(ctx, httpEndpoints, verifier, checker)}
+	if reloader != nil {
+		httpServer.TLSConfig = reloader.ServerConfig("h2", "http/1.1")
+	}
 	go func() {
-		handler := httpserver.NewHTTPServer(ctx, httpEndpoints)
-		level.Info(logger).Log("msg", fmt.Sprintf("HTTP Server listening on port %v", *httpAddr))
-		errs <- http.ListenAndServe(*httpAddr, handler)
+		level.Info(logger).Log("msg", fmt.Sprintf("HTTP Server listening on port %v", cfg.Server.HTTPAddr))
+		if reloader != nil {
+			errs <- httpServer.ListenAndServeTLS("", "")
+			return
+		}
+		errs <- httpServer.ListenAndServe()
 	}()
 
 	level.Error(logger).Log("exit", <-errs)
-	close(errs)
+
+	//The servers stop accepting requests and wait for the in-flight ones, then the background jobs are stopped,
+	//and the repository and the tracing are closed once nothing can use them.
+	level.Info(logger).Log("msg", fmt.Sprintf("Shutting down, the in-flight requests have %v to finish", drain))
+	checker.Drain()
+	healthServer.Shutdown()
+	shutdownCtx, cancel := context.WithTimeout(ctx, drain)
+	defer cancel()
+
+	var servers sync.WaitGroup
+	servers.Add(2)
+	go func() {
+		defer servers.Done()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			level.Error(logger).Log("msg", fmt.Sprintf("Error shutting down the HTTP Server => %v", err.Error()))
+		}
+	}()
+	go func() {
+		defer servers.Done()
+		gracefulStop(shutdownCtx, baseServer)
+	}()
+	servers.Wait()
+
+	stopJobs()
+	if !waitUntilDone(shutdownCtx, &jobs) {
+		level.Error(logger).Log("msg", "The background jobs did not finish before the shutdown timeout")
+	}
+	if err := repo.Close(shutdownCtx); err != nil {
+		level.Error(logger).Log("msg", fmt.Sprintf("Error closing the repository => %v", err.Error()))
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		level.Error(logger).Log("msg", fmt.Sprintf("Error flushing the traces => %v", err.Error()))
+	}
+	if err := adminServer.Shutdown(shutdownCtx); err != nil {
+		level.Error(logger).Log("msg", fmt.Sprintf("Error shutting down the Admin Server => %v", err.Error()))
+	}
+}
+
+//Stops the gRPC server after its in-flight calls finish, the calls still running when the context is done are cancelled
+func gracefulStop(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
+
+//Reloads the certificates of the HTTP and gRPC listeners on each SIGHUP until the context is done,
+//the listeners keep the current certificates when the new ones can not be loaded
+func reloadOnSIGHUP(ctx context.Context, reloader *tlsconfig.Reloader, logger log.Logger) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			if err := reloader.Reload(); err != nil {
+				level.Error(logger).Log("msg", fmt.Sprintf("Error reloading the TLS certificates => %v", err.Error()))
+				continue
+			}
+			level.Info(logger).Log("msg", "TLS certificates reloaded")
+		}
+	}
+}
+
+//Waits for the group until the context is done, returns false when the context was done first
+func waitUntilDone(ctx context.Context, group *sync.WaitGroup) bool {
+	done := make(chan struct{})
+	go func() {
+		group.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+//Loads the configuration from the defaults, the config file, the environment and the flags,
+//the server exits when the configuration is invalid or after printing it with --print-config.
+func loadConfig() config.Config {
+	if err := config.LoadDotEnv(".env"); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	cfg, opts, err := config.Load(os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if opts.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if opts.PrintConfig {
+		os.Exit(0)
+	}
+	return cfg
+}
+
+//Builds the logger with the format and level of the configuration
+func newLogger(cfg config.LogConfig) (log.Logger, *logging.Level, error) {
+	lvl, err := logging.NewLevel(cfg.Level)
+	if err != nil {
+		return nil, nil, err
+	}
+	logger, err := logging.NewLogger(cfg.Format, os.Stderr)
+	return logger, lvl, err
+}
+
+//Builds the JWT verifier with the keys of the configuration
+func newVerifier(cfg config.AuthConfig) (*auth.Verifier, error) {
+	return auth.NewVerifier(auth.Config{
+		HS256Secret:        cfg.HS256Secret,
+		RS256PublicKeyFile: cfg.RS256PublicKeyFile,
+		JWKSFile:           cfg.JWKSFile,
+	})
+}
+
+//Builds the tracing configuration with the exporter of the configuration
+func newTraceConfig(cfg config.TraceConfig) tracing.Config {
+	return tracing.Config{
+		ServiceName:  "questionary",
+		Exporter:     cfg.Exporter,
+		OTLPEndpoint: cfg.OTLPEndpoint,
+		File:         cfg.File,
+	}
+}
+
+//Wraps the service with the Prometheus metrics of the requests
+func instrumentService(serv service.Service) service.Service {
+	requestCount := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "questionary",
+		Subsystem: "service",
+		Name:      "request_count",
+		Help:      "Number of requests received.",
+	}, []string{"method", "outcome"})
+	errorCount := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "questionary",
+		Subsystem: "service",
+		Name:      "error_count",
+		Help:      "Number of requests that failed, by the kind of the error.",
+	}, []string{"method", "kind"})
+	requestLatency := kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Namespace: "questionary",
+		Subsystem: "service",
+		Name:      "request_latency_seconds",
+		Help:      "Duration of the requests in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"method", "outcome"})
+	return service.NewInstrumentingMiddleware(requestCount, errorCount, requestLatency)(serv)
+}
+
+//Wraps the repository with the Prometheus metrics of the database calls
+func instrumentRepository(repo repository.Repository) repository.Repository {
+	latency := kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Namespace: "questionary",
+		Subsystem: "repository",
+		Name:      "query_latency_seconds",
+		Help:      "Duration of the database calls in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"method", "outcome"})
+	return repository.NewInstrumentingMiddleware(latency)(repo)
 }
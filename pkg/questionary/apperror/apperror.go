@@ -0,0 +1,95 @@
+package apperror
+
+import (
+	"errors"
+)
+
+//This is the error model of the service and the repositories
+//The errors have a kind that says what went wrong without any transport in mind,
+//each transport maps the kinds to its own status codes and shows the detail to the client.
+
+//The kind of an error, the zero value is Internal so an error without kind is never shown as a client error
+type Kind int
+
+const (
+	Internal Kind = iota
+	NotFound
+	Conflict
+	Invalid
+	Unauthenticated
+	Forbidden
+	RateLimited
+	Unavailable
+)
+
+//All the kinds, the tables of the transports must have a status code for each one of them
+var Kinds = []Kind{Internal, NotFound, Conflict, Invalid, Unauthenticated, Forbidden, RateLimited, Unavailable}
+
+var names = map[Kind]string{
+	Internal:        "internal",
+	NotFound:        "not_found",
+	Conflict:        "conflict",
+	Invalid:         "invalid",
+	Unauthenticated: "unauthenticated",
+	Forbidden:       "forbidden",
+	RateLimited:     "rate_limited",
+	Unavailable:     "unavailable",
+}
+
+func (k Kind) String() string {
+	if name, ok := names[k]; ok {
+		return name
+	}
+	return names[Internal]
+}
+
+//Method that checks if the error was caused by the request of the client and not by the server
+func (k Kind) IsClientError() bool {
+	switch k {
+	case NotFound, Conflict, Invalid, Unauthenticated, Forbidden, RateLimited:
+		return true
+	}
+	return false
+}
+
+//An Error has the kind, the detail that can be shown to the client and the cause that is only logged
+type Error struct {
+	Kind   Kind
+	Detail string
+	Cause  error
+}
+
+func New(err error, kind Kind, detail string) *Error {
+	return &Error{
+		Kind:   kind,
+		Detail: detail,
+		Cause:  err,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+//Method that returns the kind of the error, the errors that are not of this package are internal
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+	return Internal
+}
+
+//Method that returns the detail of the error that can be shown to the client,
+//the errors that are not of this package do not have one
+func DetailOf(err error) (string, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Detail, true
+	}
+	return "", false
+}
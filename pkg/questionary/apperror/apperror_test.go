@@ -0,0 +1,43 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindOf(t *testing.T) {
+	cause := errors.New("no documents in result")
+	err := New(cause, NotFound, "Question not found")
+	assert.Equal(t, NotFound, KindOf(err))
+	assert.Equal(t, "Question not found", err.Error())
+	assert.True(t, errors.Is(err, cause))
+
+	//The kind is found on the wrapped errors too
+	wrapped := fmt.Errorf("Failed to find the question => %w", err)
+	assert.Equal(t, NotFound, KindOf(wrapped))
+	detail, ok := DetailOf(wrapped)
+	assert.True(t, ok)
+	assert.Equal(t, "Question not found", detail)
+
+	//The errors without kind are internal and have no detail for the client
+	assert.Equal(t, Internal, KindOf(cause))
+	_, ok = DetailOf(cause)
+	assert.False(t, ok)
+}
+
+func TestKind(t *testing.T) {
+	names := map[string]bool{}
+	for _, kind := range Kinds {
+		names[kind.String()] = true
+	}
+	assert.Len(t, names, len(Kinds))
+	assert.Equal(t, "rate_limited", RateLimited.String())
+	assert.Equal(t, "internal", Kind(100).String())
+
+	assert.True(t, Invalid.IsClientError())
+	assert.False(t, Internal.IsClientError())
+	assert.False(t, Unavailable.IsClientError())
+}
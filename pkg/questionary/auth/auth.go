@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+)
+
+//This is the authentication of the API
+//The requests carry a JWT bearer token signed with HS256 or RS256, the subject of a valid token
+//is the ID of the user that makes the request and it is stored in the context for the lower layers.
+//The service clients can send an API key instead of a token, the user of the key is the subject of its requests.
+
+//Keys used to verify the tokens, at least one of them is required.
+//The RS256 keys can be a single PEM public key file, a local JWKS file or both.
+type Config struct {
+	HS256Secret        string
+	RS256PublicKeyFile string
+	JWKSFile           string
+}
+
+//The API keys are stored by the service, it returns the API key of a valid key
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (domain.APIKey, error)
+}
+
+//The verifier validates the signature, the algorithm and the expiration of the tokens
+//and the API keys when an APIKeyAuthenticator is set
+type Verifier struct {
+	hmacSecret []byte
+	rsaKeys    map[string]*rsa.PublicKey
+	apiKeys    APIKeyAuthenticator
+}
+
+type contextKey int
+
+const (
+	subjectKey contextKey = iota
+	rolesKey
+	scopesKey
+	apiKeyIDKey
+	clientIdentityKey
+)
+
+var (
+	ErrMissingToken  = errors.New("Authentication Required")
+	ErrInvalidToken  = errors.New("Invalid Token")
+	ErrInvalidAPIKey = errors.New("Invalid API Key")
+)
+
+func NewVerifier(cfg Config) (*Verifier, error) {
+	verifier := &Verifier{rsaKeys: make(map[string]*rsa.PublicKey)}
+	if cfg.HS256Secret != "" {
+		verifier.hmacSecret = []byte(cfg.HS256Secret)
+	}
+
+	if cfg.RS256PublicKeyFile != "" {
+		data, err := ioutil.ReadFile(cfg.RS256PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read the RS256 public key => %v", err)
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse the RS256 public key => %v", err)
+		}
+		verifier.rsaKeys[""] = key
+	}
+
+	if cfg.JWKSFile != "" {
+		keys, err := readJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		for kid, key := range keys {
+			verifier.rsaKeys[kid] = key
+		}
+	}
+
+	if verifier.hmacSecret == nil && len(verifier.rsaKeys) == 0 {
+		return nil, errors.New("No JWT verification key configured.")
+	}
+	return verifier, nil
+}
+
+//Method that validates a token and returns its subject
+func (v *Verifier) Verify(token string) (string, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(token, &claims, v.key, jwt.WithValidMethods([]string{"HS256", "RS256"}))
+	if err != nil || claims.Subject == "" || claims.ExpiresAt == nil {
+		return "", ErrInvalidToken
+	}
+	return claims.Subject, nil
+}
+
+//Method that returns a copy of the verifier that also accepts the API keys of the authenticator
+func (v *Verifier) WithAPIKeys(apiKeys APIKeyAuthenticator) *Verifier {
+	verifier := *v
+	verifier.apiKeys = apiKeys
+	return &verifier
+}
+
+//Method that authenticates a request with its API key, or with its bearer token when it has no API key,
+//and returns the context with the subject of the request and the scopes of the API key
+func (v *Verifier) Authenticate(ctx context.Context, token string, apiKey string) (context.Context, error) {
+	if apiKey != "" {
+		if v.apiKeys == nil {
+			return ctx, ErrInvalidAPIKey
+		}
+		key, err := v.apiKeys.AuthenticateAPIKey(ctx, apiKey)
+		if err != nil {
+			return ctx, ErrInvalidAPIKey
+		}
+		ctx = context.WithValue(ctx, apiKeyIDKey, key.ID)
+		return WithScopes(WithSubject(ctx, key.UserID), key.Scopes), nil
+	}
+
+	subject, err := v.Verify(token)
+	if err != nil {
+		return ctx, err
+	}
+	return WithSubject(ctx, subject), nil
+}
+
+//The RS256 tokens are verified with the key of its "kid" header,
+//a token without "kid" uses the PEM public key or the only key of the JWKS file
+func (v *Verifier) key(token *jwt.Token) (interface{}, error) {
+	if token.Method.Alg() == "HS256" {
+		if v.hmacSecret == nil {
+			return nil, ErrInvalidToken
+		}
+		return v.hmacSecret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if key, ok := v.rsaKeys[kid]; ok {
+		return key, nil
+	}
+	if kid == "" && len(v.rsaKeys) == 1 {
+		for _, key := range v.rsaKeys {
+			return key, nil
+		}
+	}
+	return nil, ErrInvalidToken
+}
+
+//Method that returns a copy of the context with the subject of the request
+func WithSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, subjectKey, subject)
+}
+
+//Method that returns the subject of the request stored in the context
+func Subject(ctx context.Context) (string, bool) {
+	subject, ok := ctx.Value(subjectKey).(string)
+	return subject, ok && subject != ""
+}
+
+//Method that returns a copy of the context with the roles granted to the subject of the request
+func WithRoles(ctx context.Context, roles []string) context.Context {
+	return context.WithValue(ctx, rolesKey, roles)
+}
+
+//Method that returns the roles granted to the subject of the request stored in the context
+func Roles(ctx context.Context) []string {
+	roles, _ := ctx.Value(rolesKey).([]string)
+	return roles
+}
+
+//Method that checks if the subject of the request has at least one of the roles
+func HasRole(ctx context.Context, roles ...string) bool {
+	for _, granted := range Roles(ctx) {
+		for _, role := range roles {
+			if granted == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//Method that returns a copy of the context with the scopes of the API key of the request
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey, scopes)
+}
+
+//Method that checks if the request can use the scope,
+//the requests that were not authenticated with an API key are not limited by scopes
+func HasScope(ctx context.Context, scope string) bool {
+	scopes, ok := ctx.Value(scopesKey).([]string)
+	if !ok {
+		return true
+	}
+	for _, granted := range scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+//Method that returns the ID of the API key of the request, if it was authenticated with one
+func APIKeyID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(apiKeyIDKey).(string)
+	return id, ok && id != ""
+}
+
+//Method that returns a copy of the context authenticated as the identity of the verified client certificate of the request,
+//the identity is the subject of the request like the subject of a token
+func WithClientIdentity(ctx context.Context, identity string) context.Context {
+	ctx = context.WithValue(ctx, clientIdentityKey, identity)
+	return WithSubject(ctx, identity)
+}
+
+//Method that returns the identity of the client certificate of the request, if it was authenticated with one
+func ClientIdentity(ctx context.Context) (string, bool) {
+	identity, ok := ctx.Value(clientIdentityKey).(string)
+	return identity, ok && identity != ""
+}
@@ -0,0 +1,317 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const secret = "test-secret"
+
+func signHS256(t *testing.T, claims jwt.RegisteredClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	assert.Nil(t, err)
+	return token
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.RegisteredClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	assert.Nil(t, err)
+	return signed
+}
+
+func validClaims(subject string) jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Subject:   subject,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+func TestNewVerifierWithoutKeys(t *testing.T) {
+	_, err := NewVerifier(Config{})
+	assert.NotNil(t, err)
+}
+
+func TestVerifyHS256(t *testing.T) {
+	verifier, err := NewVerifier(Config{HS256Secret: secret})
+	assert.Nil(t, err)
+
+	subject, err := verifier.Verify(signHS256(t, validClaims("1")))
+	assert.Nil(t, err)
+	assert.Equal(t, "1", subject)
+}
+
+func TestVerifyRejectsInvalidTokens(t *testing.T) {
+	verifier, err := NewVerifier(Config{HS256Secret: secret})
+	assert.Nil(t, err)
+
+	expired := validClaims("1")
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	withoutExpiration := jwt.RegisteredClaims{Subject: "1"}
+	wrongSecret, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims("1")).SignedString([]byte("other"))
+	wrongAlg, _ := jwt.NewWithClaims(jwt.SigningMethodHS512, validClaims("1")).SignedString([]byte(secret))
+	unsigned, _ := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims("1")).SignedString(jwt.UnsafeAllowNoneSignatureType)
+
+	tokens := map[string]string{
+		"expired":            signHS256(t, expired),
+		"without subject":    signHS256(t, validClaims("")),
+		"without expiration": signHS256(t, withoutExpiration),
+		"wrong secret":       wrongSecret,
+		"wrong algorithm":    wrongAlg,
+		"unsigned":           unsigned,
+		"malformed":          "not.a.token",
+	}
+	for name, token := range tokens {
+		_, err := verifier.Verify(token)
+		assert.Equal(t, ErrInvalidToken, err, name)
+	}
+}
+
+func TestVerifyRS256WithPEMKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.Nil(t, err)
+	path := filepath.Join(t.TempDir(), "public.pem")
+	assert.Nil(t, ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+	verifier, err := NewVerifier(Config{RS256PublicKeyFile: path})
+	assert.Nil(t, err)
+
+	subject, err := verifier.Verify(signRS256(t, key, "", validClaims("2")))
+	assert.Nil(t, err)
+	assert.Equal(t, "2", subject)
+
+	_, err = verifier.Verify(signHS256(t, validClaims("2")))
+	assert.Equal(t, ErrInvalidToken, err)
+}
+
+func TestVerifyRS256WithJWKS(t *testing.T) {
+	first, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	second, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+
+	jwk := func(kid string, key *rsa.PublicKey) map[string]string {
+		return map[string]string{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	}
+	data, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{jwk("first", &first.PublicKey), jwk("second", &second.PublicKey)},
+	})
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	assert.Nil(t, ioutil.WriteFile(path, data, 0600))
+
+	verifier, err := NewVerifier(Config{JWKSFile: path})
+	assert.Nil(t, err)
+
+	subject, err := verifier.Verify(signRS256(t, second, "second", validClaims("3")))
+	assert.Nil(t, err)
+	assert.Equal(t, "3", subject)
+
+	_, err = verifier.Verify(signRS256(t, second, "first", validClaims("3")))
+	assert.Equal(t, ErrInvalidToken, err)
+
+	_, err = verifier.Verify(signRS256(t, second, "", validClaims("3")))
+	assert.Equal(t, ErrInvalidToken, err)
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	verifier, _ := NewVerifier(Config{HS256Secret: secret})
+	handler := HTTPMiddleware(verifier, func(r *http.Request) bool {
+		return r.Method == http.MethodGet
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject, _ := Subject(r.Context())
+		w.Write([]byte(subject))
+	}))
+
+	serve := func(method, authorization string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, "/question", nil)
+		if authorization != "" {
+			r.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(http.MethodPost, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+
+	w = serve(http.MethodGet, "Bearer not.a.token")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = serve(http.MethodPost, "Bearer "+signHS256(t, validClaims("4")))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "4", w.Body.String())
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	verifier, _ := NewVerifier(Config{HS256Secret: secret})
+	interceptor := UnaryServerInterceptor(verifier, []string{"/QuestionaryService/FindAll"})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		subject, _ := Subject(ctx)
+		return subject, nil
+	}
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/QuestionaryService/FindAll"}, handler)
+	assert.Nil(t, err)
+
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/QuestionaryService/Create"}, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+signHS256(t, validClaims("5"))))
+	subject, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/QuestionaryService/Create"}, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, "5", subject)
+}
+
+type fakeAPIKeys map[string]domain.APIKey
+
+func (f fakeAPIKeys) AuthenticateAPIKey(ctx context.Context, key string) (domain.APIKey, error) {
+	apiKey, ok := f[key]
+	if !ok {
+		return domain.APIKey{}, errors.New("Invalid API Key")
+	}
+	return apiKey, nil
+}
+
+func TestHTTPMiddlewareWithAPIKey(t *testing.T) {
+	verifier, _ := NewVerifier(Config{HS256Secret: secret})
+	verifier = verifier.WithAPIKeys(fakeAPIKeys{
+		"qak_read": {UserID: "job", Scopes: []string{domain.ScopeRead}},
+	})
+	handler := HTTPMiddleware(verifier, func(r *http.Request) bool {
+		return r.Method == http.MethodGet
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject, _ := Subject(r.Context())
+		if !HasScope(r.Context(), domain.ScopeWrite) {
+			subject += ":read"
+		}
+		w.Write([]byte(subject))
+	}))
+
+	serve := func(apiKey string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/question", nil)
+		r.Header.Set("X-API-Key", apiKey)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve("qak_read")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "job:read", w.Body.String())
+
+	w = serve("qak_unknown")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestUnaryServerInterceptorWithAPIKey(t *testing.T) {
+	verifier, _ := NewVerifier(Config{HS256Secret: secret})
+	interceptor := UnaryServerInterceptor(verifier, nil)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "qak_read"))
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/QuestionaryService/Create"}, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	interceptor = UnaryServerInterceptor(verifier.WithAPIKeys(fakeAPIKeys{"qak_read": {UserID: "job"}}), nil)
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/QuestionaryService/Create"}, handler)
+	assert.Nil(t, err)
+}
+
+func verifiedState(commonName string) *tls.ConnectionState {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+	return &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+}
+
+func TestHTTPMiddlewareWithClientCertificate(t *testing.T) {
+	verifier, _ := NewVerifier(Config{HS256Secret: secret})
+	handler := HTTPMiddleware(verifier, func(r *http.Request) bool {
+		return false
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject, _ := Subject(r.Context())
+		identity, _ := ClientIdentity(r.Context())
+		w.Write([]byte(subject + ":" + identity))
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/question", nil)
+	r.TLS = verifiedState("billing-service")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "billing-service:billing-service", w.Body.String())
+
+	//A certificate that was not verified does not authenticate the request
+	r = httptest.NewRequest(http.MethodPost, "/question", nil)
+	r.TLS = &tls.ConnectionState{}
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	//The token wins over the certificate
+	r = httptest.NewRequest(http.MethodPost, "/question", nil)
+	r.TLS = verifiedState("billing-service")
+	r.Header.Set("Authorization", "Bearer "+signHS256(t, validClaims("4")))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, "4:", w.Body.String())
+}
+
+func TestUnaryServerInterceptorWithClientCertificate(t *testing.T) {
+	verifier, _ := NewVerifier(Config{HS256Secret: secret})
+	interceptor := UnaryServerInterceptor(verifier, nil)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		subject, _ := Subject(ctx)
+		return subject, nil
+	}
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: *verifiedState("billing-service")}})
+	subject, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/QuestionaryService/Create"}, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, "billing-service", subject)
+
+	ctx = peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}})
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/QuestionaryService/Create"}, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
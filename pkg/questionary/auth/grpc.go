@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/ismaeljpv/qa-api/pkg/questionary/tlsconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+//This is the gRPC unary interceptor that authenticates the calls with the "authorization: Bearer <token>" metadata
+//or the "x-api-key" metadata of the service clients, the calls without them that have a verified client certificate
+//are authenticated as the identity of the certificate.
+//The public methods are identified by its full name and can be called without credentials.
+func UnaryServerInterceptor(verifier *Verifier, publicMethods []string) grpc.UnaryServerInterceptor {
+	public := make(map[string]bool)
+	for _, method := range publicMethods {
+		public[method] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var token, apiKey string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("authorization"); len(values) > 0 {
+				token = bearerToken(values[0])
+			}
+			if values := md.Get("x-api-key"); len(values) > 0 {
+				apiKey = values[0]
+			}
+		}
+
+		if token == "" && apiKey == "" {
+			if identity, ok := peerIdentity(ctx); ok {
+				return handler(WithClientIdentity(ctx, identity), req)
+			}
+			if public[info.FullMethod] {
+				return handler(ctx, req)
+			}
+			return nil, status.Error(codes.Unauthenticated, ErrMissingToken.Error())
+		}
+
+		authenticated, err := verifier.Authenticate(ctx, token, apiKey)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return handler(authenticated, req)
+	}
+}
+
+//Method that returns the identity of the verified client certificate of the TLS connection of the call
+func peerIdentity(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return "", false
+	}
+	return tlsconfig.Identity(&info.State)
+}
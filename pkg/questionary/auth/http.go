@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ismaeljpv/qa-api/pkg/questionary/requestid"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/tlsconfig"
+	httpError "github.com/ismaeljpv/qa-api/pkg/questionary/transport/http/error"
+)
+
+//This is the HTTP middleware that authenticates the requests with the "Authorization: Bearer <token>" header
+//or the "X-API-Key" header of the service clients, the requests without them that have a verified client certificate
+//are authenticated as the identity of the certificate.
+//The public requests can be made without credentials, but the credentials sent on them must be valid.
+func HTTPMiddleware(verifier *Verifier, public func(r *http.Request) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := bearerToken(r.Header.Get("Authorization"))
+			apiKey := r.Header.Get("X-API-Key")
+			if token == "" && apiKey == "" {
+				if identity, ok := tlsconfig.Identity(r.TLS); ok {
+					next.ServeHTTP(w, r.WithContext(WithClientIdentity(r.Context(), identity)))
+					return
+				}
+				if public(r) {
+					next.ServeHTTP(w, r)
+					return
+				}
+				writeError(w, r, ErrMissingToken)
+				return
+			}
+
+			ctx, err := verifier.Authenticate(r.Context(), token, apiKey)
+			if err != nil {
+				writeError(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func bearerToken(header string) string {
+	if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+	return ""
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	clientErr := httpError.NewClientError(err, http.StatusUnauthorized, err.Error())
+	clientErr = httpError.WithRequestID(clientErr, requestid.FromContext(r.Context()))
+	body, _ := clientErr.ResponseBody()
+	status, headers := clientErr.ResponseHeaders()
+	for k, v := range headers {
+		w.Header().Set(k, v)
+	}
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	w.WriteHeader(status)
+	w.Write(body)
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+)
+
+//A JSON Web Key Set as defined by RFC 7517, only the RSA signing keys are used
+type jwks struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+//Method that reads the RSA public keys of a local JWKS file indexed by its key ID
+func readJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read the JWKS file => %v", err)
+	}
+
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("Failed to parse the JWKS file => %v", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, key := range set.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(key.N)
+		e, errE := base64.RawURLEncoding.DecodeString(key.E)
+		if errN != nil || errE != nil {
+			return nil, fmt.Errorf("Invalid RSA key %q in the JWKS file", key.Kid)
+		}
+		keys[key.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/ismaeljpv/qa-api/pkg/questionary/logging"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/ratelimit"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/tlsconfig"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/tracing"
+)
+
+//This is the configuration of the server
+//Each setting has a default that can be overridden by the config file, then by the environment and then by the flags.
+//The tags of the fields are the key on the config file, the environment variable and the flag of the setting,
+//the secrets are redacted when the configuration is printed.
+type Config struct {
+	Server     ServerConfig  `yaml:"server" json:"server"`
+	TLS        TLSConfig     `yaml:"tls" json:"tls"`
+	MongoDB    MongoDBConfig `yaml:"mongodb" json:"mongodb"`
+	Auth       AuthConfig    `yaml:"auth" json:"auth"`
+	Purge      PurgeConfig   `yaml:"purge" json:"purge"`
+	RateLimits string        `yaml:"rateLimits" json:"rateLimits" env:"RATE_LIMITS" flag:"rate-limits"`
+	Log        LogConfig     `yaml:"log" json:"log"`
+	Trace      TraceConfig   `yaml:"trace" json:"trace"`
+}
+
+//The admin server only listens on localhost unless its address is changed,
+//the routes that change the server need the admin token when it is reached from another host
+type ServerConfig struct {
+	HTTPAddr        string        `yaml:"httpAddr" json:"httpAddr" env:"HTTP_ADDR" flag:"http"`
+	GRPCAddr        string        `yaml:"grpcAddr" json:"grpcAddr" env:"GRPC_ADDR" flag:"grpc"`
+	AdminAddr       string        `yaml:"adminAddr" json:"adminAddr" env:"ADMIN_ADDR" flag:"admin"`
+	AdminToken      string        `yaml:"adminToken" json:"adminToken" env:"ADMIN_TOKEN" flag:"admin-token" secret:"true"`
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" json:"shutdownTimeout" env:"SHUTDOWN_TIMEOUT" flag:"shutdown-timeout"`
+	GRPCReflection  bool          `yaml:"grpcReflection" json:"grpcReflection" env:"GRPC_REFLECTION" flag:"grpc-reflection"`
+}
+
+//The TLS of the HTTP and gRPC listeners, the listeners are plaintext when there is no certificate
+type TLSConfig struct {
+	CertFile     string `yaml:"certFile" json:"certFile" env:"TLS_CERT_FILE" flag:"tls-cert"`
+	KeyFile      string `yaml:"keyFile" json:"keyFile" env:"TLS_KEY_FILE" flag:"tls-key"`
+	ClientCAFile string `yaml:"clientCAFile" json:"clientCAFile" env:"TLS_CLIENT_CA_FILE" flag:"tls-client-ca"`
+	ClientAuth   string `yaml:"clientAuth" json:"clientAuth" env:"TLS_CLIENT_AUTH" flag:"tls-client-auth"`
+}
+
+//Method that returns the configuration of the TLS package
+func (c TLSConfig) Config() tlsconfig.Config {
+	return tlsconfig.Config{
+		CertFile:     c.CertFile,
+		KeyFile:      c.KeyFile,
+		ClientCAFile: c.ClientCAFile,
+		ClientAuth:   c.ClientAuth,
+	}
+}
+
+type MongoDBConfig struct {
+	URI                string `yaml:"uri" json:"uri" env:"MONGODB_URI" flag:"mongodb-uri" secret:"true"`
+	Database           string `yaml:"database" json:"database" env:"DB_NAME" flag:"mongodb-database"`
+	QuestionCollection string `yaml:"questionCollection" json:"questionCollection" env:"QUESTION_COLLECTION" flag:"mongodb-question-collection"`
+}
+
+type AuthConfig struct {
+	HS256Secret        string        `yaml:"hs256Secret" json:"hs256Secret" env:"JWT_HS256_SECRET" flag:"jwt-hs256-secret" secret:"true"`
+	RS256PublicKeyFile string        `yaml:"rs256PublicKeyFile" json:"rs256PublicKeyFile" env:"JWT_RS256_PUBLIC_KEY" flag:"jwt-rs256-public-key"`
+	JWKSFile           string        `yaml:"jwksFile" json:"jwksFile" env:"JWT_JWKS_FILE" flag:"jwt-jwks-file"`
+	AdminUserID        string        `yaml:"adminUserId" json:"adminUserId" env:"ADMIN_USER_ID" flag:"admin-user-id"`
+	RoleCacheTTL       time.Duration `yaml:"roleCacheTTL" json:"roleCacheTTL" env:"ROLE_CACHE_TTL" flag:"role-cache-ttl"`
+}
+
+type PurgeConfig struct {
+	Retention time.Duration `yaml:"retention" json:"retention" env:"PURGE_RETENTION" flag:"purge-retention"`
+	Interval  time.Duration `yaml:"interval" json:"interval" env:"PURGE_INTERVAL" flag:"purge-interval"`
+}
+
+type LogConfig struct {
+	Format string `yaml:"format" json:"format" env:"LOG_FORMAT" flag:"log-format"`
+	Level  string `yaml:"level" json:"level" env:"LOG_LEVEL" flag:"log-level"`
+}
+
+type TraceConfig struct {
+	Exporter     string `yaml:"exporter" json:"exporter" env:"TRACE_EXPORTER" flag:"trace-exporter"`
+	OTLPEndpoint string `yaml:"otlpEndpoint" json:"otlpEndpoint" env:"TRACE_OTLP_ENDPOINT" flag:"trace-otlp-endpoint"`
+	File         string `yaml:"file" json:"file" env:"TRACE_FILE" flag:"trace-file"`
+}
+
+//Method that returns the configuration used when nothing else is set
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			HTTPAddr:        ":8080",
+			GRPCAddr:        ":50051",
+			AdminAddr:       "localhost:8081",
+			ShutdownTimeout: 30 * time.Second,
+		},
+		MongoDB: MongoDBConfig{
+			URI:                "mongodb://localhost:27017",
+			Database:           "questionary",
+			QuestionCollection: "questionInfo",
+		},
+		Auth: AuthConfig{
+			RoleCacheTTL: 10 * time.Second,
+		},
+		Purge: PurgeConfig{
+			Retention: 30 * 24 * time.Hour,
+			Interval:  time.Hour,
+		},
+		Log: LogConfig{
+			Format: logging.FormatLogfmt,
+			Level:  "info",
+		},
+		Trace: TraceConfig{
+			OTLPEndpoint: "localhost:4318",
+			File:         "traces.json",
+		},
+	}
+}
+
+//Method that checks the whole configuration and returns all the invalid settings in a single error,
+//so the server does not start with a configuration that fails later.
+func (c Config) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(c.Server.HTTPAddr != "", "server.httpAddr is required")
+	check(c.Server.GRPCAddr != "", "server.grpcAddr is required")
+	check(c.Server.AdminAddr != "", "server.adminAddr is required")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdownTimeout must be positive")
+
+	if err := c.TLS.Config().Validate(); err != nil {
+		check(false, "tls: %v", err)
+	}
+
+	check(c.MongoDB.URI != "", "mongodb.uri is required")
+	check(c.MongoDB.Database != "", "mongodb.database is required")
+	check(c.MongoDB.QuestionCollection != "", "mongodb.questionCollection is required")
+
+	check(c.Auth.HS256Secret != "" || c.Auth.RS256PublicKeyFile != "" || c.Auth.JWKSFile != "",
+		"auth needs at least one of hs256Secret, rs256PublicKeyFile or jwksFile")
+	check(c.Auth.RoleCacheTTL >= 0, "auth.roleCacheTTL can not be negative")
+
+	check(c.Purge.Retention > 0, "purge.retention must be positive")
+	check(c.Purge.Interval > 0, "purge.interval must be positive")
+
+	if _, err := ratelimit.ParseLimits(c.RateLimits); err != nil {
+		check(false, "rateLimits: %v", err)
+	}
+	if _, err := logging.NewLogger(c.Log.Format, ioutil.Discard); err != nil {
+		check(false, "log.format: %v", err)
+	}
+	if _, err := logging.NewLevel(c.Log.Level); err != nil {
+		check(false, "log.level: %v", err)
+	}
+
+	switch c.Trace.Exporter {
+	case tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout:
+	case tracing.ExporterFile:
+		check(c.Trace.File != "", "trace.file is required by the file exporter")
+	default:
+		check(false, "trace.exporter %q must be otlp, stdout or file", c.Trace.Exporter)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("Invalid configuration:\n  %v", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
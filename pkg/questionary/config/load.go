@@ -0,0 +1,303 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+//Environment variable with the path of the config file when the --config flag is not set
+const FileEnv = "CONFIG_FILE"
+
+//Suffix of the environment variables that hold the path of a file with the value of the setting,
+//like the secrets that Docker mounts on /run/secrets.
+const FileSuffix = "_FILE"
+
+//Value shown instead of the secrets when the configuration is printed
+const redacted = "******"
+
+//The options of the command line that are not settings of the server
+type Options struct {
+	File        string
+	PrintConfig bool
+}
+
+//Method that loads the .env file into the environment, the variables that are already set are kept
+//and a missing file is not an error since the environment can be set in other ways.
+func LoadDotEnv(path string) error {
+	err := godotenv.Load(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("Failed to read the env file %v => %v", path, err)
+	}
+	return nil
+}
+
+//Method that builds the configuration from the defaults, the config file, the environment and the flags,
+//each source overrides the settings of the previous ones. The empty environment variables are ignored.
+func Load(args []string, lookupEnv func(string) (string, bool)) (Config, Options, error) {
+	cfg := Default()
+	var opts Options
+	fields := settings(&cfg)
+
+	fs := flag.NewFlagSet("questionary", flag.ContinueOnError)
+	fs.StringVar(&opts.File, "config", "", fmt.Sprintf("path of the YAML or JSON config file (env %v)", FileEnv))
+	fs.BoolVar(&opts.PrintConfig, "print-config", false, "print the configuration with the secrets redacted and exit")
+	for _, s := range fields {
+		fs.Var(&flagValue{value: format(s.value), isBool: s.value.Kind() == reflect.Bool}, s.flag, fmt.Sprintf("%v (env %v)", s.path, s.env))
+	}
+	if err := fs.Parse(args); err != nil {
+		return cfg, opts, err
+	}
+
+	if opts.File == "" {
+		opts.File, _ = lookupEnv(FileEnv)
+	}
+	if opts.File != "" {
+		if err := loadFile(fields, opts.File); err != nil {
+			return cfg, opts, err
+		}
+	}
+
+	for _, s := range fields {
+		if err := loadEnv(s, lookupEnv); err != nil {
+			return cfg, opts, err
+		}
+	}
+
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		for _, s := range fields {
+			if s.flag == f.Name && flagErr == nil {
+				flagErr = s.set(f.Value.String(), "flag --"+f.Name)
+			}
+		}
+	})
+	return cfg, opts, flagErr
+}
+
+//Method that writes the configuration as YAML with the secrets redacted
+func (c Config) Print(w io.Writer) error {
+	data, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+//Method that returns a copy of the configuration without the values of the secrets
+func (c Config) Redacted() Config {
+	for _, s := range settings(&c) {
+		if s.secret && !s.value.IsZero() {
+			s.value.SetString(redacted)
+		}
+	}
+	return c
+}
+
+//A setting is a leaf field of the configuration with its key on each source
+type setting struct {
+	path   string
+	env    string
+	flag   string
+	secret bool
+	value  reflect.Value
+}
+
+//Method that returns the settings of the configuration, the values point to the fields of the configuration
+func settings(cfg *Config) []setting {
+	return collect(reflect.ValueOf(cfg).Elem(), "", nil)
+}
+
+func collect(v reflect.Value, prefix string, found []setting) []setting {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		path := prefix + field.Tag.Get("yaml")
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			found = collect(v.Field(i), path+".", found)
+			continue
+		}
+		found = append(found, setting{
+			path:   path,
+			env:    field.Tag.Get("env"),
+			flag:   field.Tag.Get("flag"),
+			secret: field.Tag.Get("secret") == "true",
+			value:  v.Field(i),
+		})
+	}
+	return found
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+//Method that parses the value of the source and sets it on the field of the setting
+func (s setting) set(raw, source string) error {
+	var err error
+	switch {
+	case s.value.Type() == durationType:
+		var d time.Duration
+		if d, err = time.ParseDuration(raw); err == nil {
+			s.value.SetInt(int64(d))
+		}
+	case s.value.Kind() == reflect.String:
+		s.value.SetString(raw)
+	case s.value.Kind() == reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(raw); err == nil {
+			s.value.SetBool(b)
+		}
+	case s.value.Kind() == reflect.Int:
+		var n int64
+		if n, err = strconv.ParseInt(raw, 10, 64); err == nil {
+			s.value.SetInt(n)
+		}
+	case s.value.Kind() == reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(raw, 64); err == nil {
+			s.value.SetFloat(f)
+		}
+	default:
+		err = fmt.Errorf("unsupported type %v", s.value.Type())
+	}
+	if err != nil {
+		if s.secret {
+			raw = redacted
+		}
+		return fmt.Errorf("Invalid value %q for %v on %v => %v", raw, s.path, source, err)
+	}
+	return nil
+}
+
+//Method that returns the value of the setting as it is written on the sources
+func format(v reflect.Value) string {
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+//Method that sets the settings of the YAML or JSON file, the keys of the file are the paths of the settings
+//and the unknown keys are rejected so a typo does not go unnoticed.
+func loadFile(fields []setting, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Failed to read the config file => %v", err)
+	}
+
+	values := map[string]interface{}{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&values)
+	default:
+		return fmt.Errorf("Unsupported config file %v, it must be .yaml, .yml or .json.", path)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to parse the config file %v => %v", path, err)
+	}
+
+	flat := map[string]interface{}{}
+	flatten(values, "", flat)
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s, ok := find(fields, key)
+		if !ok {
+			return fmt.Errorf("Unknown setting %q on the config file %v.", key, path)
+		}
+		value := flat[key]
+		if value == nil {
+			continue
+		}
+		switch value.(type) {
+		case []interface{}:
+			return fmt.Errorf("Invalid value for %v on the config file %v => a list is not allowed", key, path)
+		}
+		if err := s.set(fmt.Sprint(value), "the config file"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func flatten(values map[string]interface{}, prefix string, flat map[string]interface{}) {
+	for key, value := range values {
+		if nested, ok := value.(map[string]interface{}); ok {
+			flatten(nested, prefix+key+".", flat)
+			continue
+		}
+		flat[prefix+key] = value
+	}
+}
+
+func find(fields []setting, path string) (setting, bool) {
+	for _, s := range fields {
+		if s.path == path {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+//Method that sets the setting from its environment variable or from the file of its _FILE variable,
+//setting both is an error since it is not clear which one should win.
+func loadEnv(s setting, lookupEnv func(string) (string, bool)) error {
+	value, _ := lookupEnv(s.env)
+	file, _ := lookupEnv(s.env + FileSuffix)
+	switch {
+	case value != "" && file != "":
+		return fmt.Errorf("Both %v and %v%v are set, only one of them is allowed.", s.env, s.env, FileSuffix)
+	case file != "":
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("Failed to read %v%v => %v", s.env, FileSuffix, err)
+		}
+		return s.set(strings.TrimRight(string(data), "\r\n"), "env "+s.env+FileSuffix)
+	case value != "":
+		return s.set(value, "env "+s.env)
+	}
+	return nil
+}
+
+//The flags of the settings keep the raw value, it is parsed after the other sources are loaded
+type flagValue struct {
+	value  string
+	isBool bool
+}
+
+func (f *flagValue) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.value
+}
+
+func (f *flagValue) Set(value string) error {
+	f.value = value
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	return f.isBool
+}
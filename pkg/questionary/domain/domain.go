@@ -1,12 +1,16 @@
 package domain
 
+import "strings"
+
 //Domains that represents the structures of the database schemas
 
 type Question struct {
-	ID        string `json:"id,omitempty"`
-	Statement string `json:"statement" validate:"required"`
-	UserID    string `json:"userId" validate:"required"`
-	CreatedOn int64  `json:"createdOn,omitempty"`
+	ID        string   `json:"id,omitempty"`
+	Statement string   `json:"statement" validate:"required"`
+	UserID    string   `json:"userId" validate:"required"`
+	CreatedOn int64    `json:"createdOn,omitempty"`
+	Score     int64    `json:"score"`
+	Tags      []string `json:"tags,omitempty" validate:"max=5,dive,tag"`
 }
 
 type Answer struct {
@@ -15,9 +19,258 @@ type Answer struct {
 	QuestionID string `json:"questionId,omitempty" validate:"required"`
 	UserID     string `json:"userId,omitempty" validate:"required"`
 	CreatedOn  int64  `json:"createdOn,omitempty"`
+	Score      int64  `json:"score"`
 }
 
+//The Answer field is the legacy single answer of a question, it is only used to read old records
+//and to select the answer to edit on an update. The answers of a question are stored in the Answers list.
+//A question is resolved once its author accepts one of the answers.
+//The RevisionCount is the number of edits recorded over the question and its answers.
+//A deleted question keeps the date and the user of the deletion until it is restored or purged.
 type QuestionInfo struct {
-	Question Question `json:"question" validate:"required"`
-	Answer   Answer   `json:"answer" validate:"required"`
+	Question         Question `json:"question" validate:"required"`
+	Answer           Answer   `json:"answer" validate:"required"`
+	Answers          []Answer `json:"answers"`
+	AcceptedAnswerID string   `json:"acceptedAnswerId,omitempty"`
+	RevisionCount    int64    `json:"revisionCount"`
+	DeletedOn        int64    `json:"deletedOn,omitempty"`
+	DeletedBy        string   `json:"deletedBy,omitempty"`
+}
+
+//Types of content that can receive votes and comments
+const (
+	TargetQuestion = "question"
+	TargetAnswer   = "answer"
+)
+
+//A vote of a user over a question or an answer, each user has only one vote per target.
+//The value of a vote is 1 for an up vote and -1 for a down vote.
+type Vote struct {
+	TargetType string `json:"targetType"`
+	TargetID   string `json:"targetId"`
+	UserID     string `json:"userId"`
+	Value      int    `json:"value"`
+	CreatedOn  int64  `json:"createdOn,omitempty"`
+}
+
+//The score of a question or an answer after a vote is registered or retracted
+type Score struct {
+	TargetType string `json:"targetType"`
+	TargetID   string `json:"targetId"`
+	Score      int64  `json:"score"`
+}
+
+//A comment that asks for clarification over a question or an answer without answering the question,
+//the QuestionID is the question that holds the commented content.
+type Comment struct {
+	ID         string `json:"id,omitempty"`
+	ParentType string `json:"parentType,omitempty"`
+	ParentID   string `json:"parentId,omitempty"`
+	QuestionID string `json:"questionId,omitempty" validate:"required"`
+	Comment    string `json:"comment" validate:"required"`
+	UserID     string `json:"userId" validate:"required"`
+	CreatedOn  int64  `json:"createdOn,omitempty"`
+}
+
+//A revision records an edit over the statement of a question or the text of one of its answers.
+//The Text is the text that the target had before the edit and the UserID is the user that made the edit,
+//the revisions of a question and its answers share the same sequence of numbers starting at 1.
+type Revision struct {
+	QuestionID string `json:"questionId"`
+	Number     int64  `json:"number"`
+	TargetType string `json:"targetType"`
+	TargetID   string `json:"targetId"`
+	Text       string `json:"text"`
+	UserID     string `json:"userId"`
+	CreatedOn  int64  `json:"createdOn"`
+}
+
+//The number of questions that use a tag
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+//Roles that can be granted to a user, the moderators can edit or delete any content
+//and the admins can grant and revoke the roles of the users
+const (
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+)
+
+//The roles granted to a user
+type UserRoles struct {
+	UserID string   `json:"userId"`
+	Roles  []string `json:"roles"`
+}
+
+//Method that checks if the role is one of the roles that can be granted
+func ValidRole(role string) bool {
+	return role == RoleAdmin || role == RoleModerator
+}
+
+//Scopes of an API key, the read scope only allows the methods that do not modify the content
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+//An API key lets a service client call the API as the user of the key without an interactive login.
+//Only the hash of the key is stored, the Key is returned once when the API key is created.
+//The ExpiresOn and RevokedOn are 0 for the keys that do not expire and have not been revoked.
+type APIKey struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name" validate:"required"`
+	UserID     string   `json:"userId" validate:"required"`
+	Scopes     []string `json:"scopes" validate:"required,min=1,dive,oneof=read write"`
+	Key        string   `json:"key,omitempty" bson:"-"`
+	Hash       string   `json:"-"`
+	CreatedOn  int64    `json:"createdOn"`
+	ExpiresOn  int64    `json:"expiresOn,omitempty"`
+	LastUsedOn int64    `json:"lastUsedOn,omitempty"`
+	RevokedOn  int64    `json:"revokedOn,omitempty"`
+}
+
+//Default and maximum number of questions returned in a page
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+//Orders in which the questions can be listed, the oldest questions are listed first by default
+const (
+	SortOldest = "oldest"
+	SortNewest = "newest"
+	SortScore  = "score"
+)
+
+//Pagination parameters of a listing, the cursor is the opaque token returned as NextCursor by the previous page
+//and it can only be used to continue a listing with the same sort order.
+type Page struct {
+	Limit  int64  `json:"limit,omitempty"`
+	Cursor string `json:"cursor,omitempty"`
+	Sort   string `json:"sort,omitempty"`
+}
+
+//A page of questions, the NextCursor is empty when there are no more questions to list
+type QuestionPage struct {
+	Questions  []QuestionInfo `json:"questions"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+}
+
+//Maximum number of questions returned by a search
+const MaxSearchResults = 50
+
+//A question that matches a search, the Relevance is the text score of the question for the searched terms
+type SearchResult struct {
+	QuestionInfo QuestionInfo `json:"questionInfo"`
+	Relevance    float64      `json:"relevance"`
+}
+
+//Filters that can be applied when listing the questions, the empty fields are not applied.
+//Answered selects the questions with (true) or without (false) answers, AnswererID the questions answered by a user
+//and CreatedFrom and CreatedTo the questions created inside a range of unix times, both limits included.
+type QuestionFilter struct {
+	Unresolved  bool   `json:"unresolved,omitempty"`
+	Answered    *bool  `json:"answered,omitempty"`
+	AuthorID    string `json:"authorId,omitempty"`
+	AnswererID  string `json:"answererId,omitempty"`
+	CreatedFrom int64  `json:"createdFrom,omitempty"`
+	CreatedTo   int64  `json:"createdTo,omitempty"`
+}
+
+//Method that tells if an update has an answer to edit, the updates without one only change the question
+func (qi *QuestionInfo) HasAnswerUpdate() bool {
+	return qi.Answer.ID != "" || qi.Answer.Answer != ""
+}
+
+//Method that moves the legacy single answer of a question into the Answers list
+func (qi *QuestionInfo) NormalizeAnswers() {
+	if qi.Answer.ID != "" {
+		found := false
+		for _, answer := range qi.Answers {
+			if answer.ID == qi.Answer.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			qi.Answers = append([]Answer{qi.Answer}, qi.Answers...)
+		}
+	}
+	qi.Answer = Answer{}
+	if qi.Answers == nil {
+		qi.Answers = []Answer{}
+	}
+}
+
+//Method that trims and lowercases the tags of a question and removes the empty and repeated ones
+func (q *Question) NormalizeTags() {
+	if q.Tags == nil {
+		return
+	}
+	tags := make([]string, 0, len(q.Tags))
+	seen := make(map[string]bool)
+	for _, tag := range q.Tags {
+		tag = NormalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	q.Tags = tags
+}
+
+//Tags are compared in lowercase and without surrounding spaces
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+//Method that returns the current text of the question statement or of one of its answers
+func (qi *QuestionInfo) Text(targetType string, targetId string) (string, bool) {
+	if targetType == TargetQuestion {
+		return qi.Question.Statement, qi.Question.ID == targetId
+	}
+	for _, answer := range qi.Answers {
+		if answer.ID == targetId {
+			return answer.Answer, true
+		}
+	}
+	return "", false
+}
+
+//Method that replaces the text of the question statement or of one of its answers
+func (qi *QuestionInfo) SetText(targetType string, targetId string, text string) {
+	if targetType == TargetQuestion {
+		qi.Question.Statement = text
+		return
+	}
+	for i, answer := range qi.Answers {
+		if answer.ID == targetId {
+			qi.Answers[i].Answer = text
+		}
+	}
+}
+
+//Method that keeps the limit of a page between 1 and the maximum page limit and sets the default sort order
+func (p *Page) Normalize() {
+	if p.Limit <= 0 {
+		p.Limit = DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		p.Limit = MaxPageLimit
+	}
+	if p.Sort == "" {
+		p.Sort = SortOldest
+	}
+}
+
+//Method that checks if the sort order of a page is one of the supported orders
+func (p *Page) ValidSort() bool {
+	switch p.Sort {
+	case "", SortOldest, SortNewest, SortScore:
+		return true
+	}
+	return false
 }
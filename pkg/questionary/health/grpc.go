@@ -0,0 +1,33 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+//Method that updates the status of the gRPC health service with the readiness of the server every interval until the context is done,
+//the status is set for the whole server ("") and for each one of the services.
+func WatchGRPC(ctx context.Context, checker *Checker, server *health.Server, interval time.Duration, services ...string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		if checker.Check(ctx).Status != StatusUp {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		server.SetServingStatus("", status)
+		for _, service := range services {
+			server.SetServingStatus(service, status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
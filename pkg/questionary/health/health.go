@@ -0,0 +1,103 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//This is the health checking of the API
+//The server is alive while it can answer requests, and it is ready while all its dependencies are available,
+//the status of each dependency is reported so a degraded server can be diagnosed.
+
+//Status of the server and of its dependencies
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+//A Check returns an error when its dependency is not available
+type Check func(ctx context.Context) error
+
+//The status of a dependency, with the error of its check when it is down
+type CheckStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+//The readiness of the server and the status of each one of its dependencies
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckStatus `json:"checks,omitempty"`
+}
+
+//A Checker runs the checks of the dependencies of the server, each check has the timeout to finish
+type Checker struct {
+	timeout  time.Duration
+	mu       sync.RWMutex
+	names    []string
+	checks   map[string]Check
+	draining int32
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+		checks:  make(map[string]Check),
+	}
+}
+
+//Method that adds the check of a dependency
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.checks[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.checks[name] = check
+}
+
+//Method that marks the server as not ready so no new requests are sent to it while it shuts down
+func (c *Checker) Drain() {
+	atomic.StoreInt32(&c.draining, 1)
+}
+
+//Method that runs all the checks at the same time, the server is ready when all of them pass and it is not draining
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.RLock()
+	names := append([]string{}, c.names...)
+	checks := make([]Check, len(names))
+	for i, name := range names {
+		checks[i] = c.checks[name]
+	}
+	c.mu.RUnlock()
+
+	statuses := make([]CheckStatus, len(names))
+	var wg sync.WaitGroup
+	for i := range checks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+			statuses[i] = CheckStatus{Status: StatusUp}
+			if err := checks[i](checkCtx); err != nil {
+				statuses[i] = CheckStatus{Status: StatusDown, Error: err.Error()}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]CheckStatus, len(names))}
+	if atomic.LoadInt32(&c.draining) == 1 {
+		report.Status = StatusDown
+	}
+	for i, name := range names {
+		report.Checks[name] = statuses[i]
+		if statuses[i].Status == StatusDown {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
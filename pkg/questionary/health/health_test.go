@@ -0,0 +1,90 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestChecker(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Add("mongodb", func(ctx context.Context) error { return nil })
+
+	report := checker.Check(context.Background())
+	assert.Equal(t, Report{Status: StatusUp, Checks: map[string]CheckStatus{"mongodb": {Status: StatusUp}}}, report)
+
+	checker.Add("cache", func(ctx context.Context) error { return errors.New("connection refused") })
+	report = checker.Check(context.Background())
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, CheckStatus{Status: StatusDown, Error: "connection refused"}, report.Checks["cache"])
+	assert.Equal(t, CheckStatus{Status: StatusUp}, report.Checks["mongodb"])
+}
+
+func TestCheckerTimeout(t *testing.T) {
+	checker := NewChecker(10 * time.Millisecond)
+	checker.Add("mongodb", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report := checker.Check(context.Background())
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["mongodb"].Error)
+}
+
+func TestCheckerDrain(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Add("mongodb", func(ctx context.Context) error { return nil })
+	checker.Drain()
+
+	report := checker.Check(context.Background())
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, StatusUp, report.Checks["mongodb"].Status)
+}
+
+func TestHandlers(t *testing.T) {
+	var available error
+	checker := NewChecker(time.Second)
+	checker.Add("mongodb", func(ctx context.Context) error { return available })
+
+	w := httptest.NewRecorder()
+	LivenessHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "{\"status\":\"up\"}\n", w.Body.String())
+
+	w = httptest.NewRecorder()
+	ReadinessHandler(checker).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	available = errors.New("server selection timeout")
+	w = httptest.NewRecorder()
+	ReadinessHandler(checker).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var report Report
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, "server selection timeout", report.Checks["mongodb"].Error)
+}
+
+func TestWatchGRPC(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Add("mongodb", func(ctx context.Context) error { return errors.New("down") })
+	server := health.NewServer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	WatchGRPC(ctx, checker, server, time.Hour, "QuestionaryService")
+
+	for _, service := range []string{"", "QuestionaryService"} {
+		resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		assert.Nil(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+	}
+}
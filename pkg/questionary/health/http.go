@@ -0,0 +1,31 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+//Method that returns the liveness handler, it answers while the server is running
+func LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, http.StatusOK, Report{Status: StatusUp})
+	})
+}
+
+//Method that returns the readiness handler, it answers 503 Service Unavailable when a dependency is down
+func ReadinessHandler(checker *Checker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := checker.Check(r.Context())
+		status := http.StatusOK
+		if report.Status != StatusUp {
+			status = http.StatusServiceUnavailable
+		}
+		writeReport(w, status, report)
+	})
+}
+
+func writeReport(w http.ResponseWriter, status int, report Report) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,38 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+
+	httpError "github.com/ismaeljpv/qa-api/pkg/questionary/transport/http/error"
+)
+
+type levelMessage struct {
+	Level string `json:"level"`
+}
+
+//Method that returns a handler that shows the log level on GET and changes it on PUT with a body like {"level": "debug"}
+func LevelHandler(l *Level) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if r.Method == http.MethodPut {
+			var msg levelMessage
+			if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+				writeError(w, httpError.NewClientError(err, http.StatusBadRequest, "Invalid Request Body"))
+				return
+			}
+			if err := l.Set(msg.Level); err != nil {
+				writeError(w, httpError.NewClientError(err, http.StatusBadRequest, err.Error()))
+				return
+			}
+		}
+		json.NewEncoder(w).Encode(levelMessage{Level: l.String()})
+	})
+}
+
+func writeError(w http.ResponseWriter, err httpError.ClientError) {
+	status, _ := err.ResponseHeaders()
+	body, _ := err.ResponseBody()
+	w.WriteHeader(status)
+	w.Write(body)
+}
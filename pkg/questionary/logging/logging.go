@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+)
+
+//This is the logging configuration of the API
+//The logs are written in logfmt or JSON format and its level can be changed while the server is running.
+
+//Formats of the logs
+const (
+	FormatLogfmt = "logfmt"
+	FormatJSON   = "json"
+)
+
+//Method that returns a logger that writes the logs in the format, logfmt is the default format
+func NewLogger(format string, w io.Writer) (log.Logger, error) {
+	switch strings.ToLower(format) {
+	case "", FormatLogfmt:
+		return log.NewLogfmtLogger(w), nil
+	case FormatJSON:
+		return log.NewJSONLogger(w), nil
+	default:
+		return nil, fmt.Errorf("Invalid log format %q, it must be logfmt or json.", format)
+	}
+}
+
+//Levels of the logs from the most to the least verbose
+var levels = []string{"debug", "info", "warn", "error"}
+
+//A Level is the minimum level of the logs that are written, it is safe to change it while the logger is used
+type Level struct {
+	value int32
+}
+
+//Method that returns a level from its name, info is the default level
+func NewLevel(name string) (*Level, error) {
+	l := &Level{}
+	if name == "" {
+		name = "info"
+	}
+	return l, l.Set(name)
+}
+
+//Method that changes the level to the level with the name
+func (l *Level) Set(name string) error {
+	for i, lvl := range levels {
+		if lvl == strings.ToLower(name) {
+			atomic.StoreInt32(&l.value, int32(i))
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid log level %q, it must be debug, info, warn or error.", name)
+}
+
+func (l *Level) String() string {
+	return levels[atomic.LoadInt32(&l.value)]
+}
+
+func (l *Level) allows(name string) bool {
+	for i, lvl := range levels {
+		if lvl == name {
+			return int32(i) >= atomic.LoadInt32(&l.value)
+		}
+	}
+	return true
+}
+
+//Method that returns a logger that drops the logs below the level,
+//the logs without level are always written.
+func NewLevelFilter(next log.Logger, l *Level) log.Logger {
+	return log.LoggerFunc(func(keyvals ...interface{}) error {
+		for i := 0; i+1 < len(keyvals); i += 2 {
+			if keyvals[i] != level.Key() {
+				continue
+			}
+			if value, ok := keyvals[i+1].(level.Value); ok && !l.allows(value.String()) {
+				return nil
+			}
+			break
+		}
+		return next.Log(keyvals...)
+	})
+}
@@ -0,0 +1,71 @@
+package logging
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewLogger(FormatJSON, &buf)
+	assert.Nil(t, err)
+	logger.Log("method", "FindByID")
+	assert.Equal(t, "{\"method\":\"FindByID\"}\n", buf.String())
+
+	buf.Reset()
+	logger, err = NewLogger("", &buf)
+	assert.Nil(t, err)
+	logger.Log("method", "FindByID")
+	assert.Equal(t, "method=FindByID\n", buf.String())
+
+	_, err = NewLogger("xml", &buf)
+	assert.NotNil(t, err)
+}
+
+func TestLevelFilter(t *testing.T) {
+	var buf bytes.Buffer
+	lvl, err := NewLevel("")
+	assert.Nil(t, err)
+	assert.Equal(t, "info", lvl.String())
+	logger := NewLevelFilter(log.NewLogfmtLogger(&buf), lvl)
+
+	level.Debug(logger).Log("msg", "debug")
+	level.Info(logger).Log("msg", "info")
+	logger.Log("msg", "without level")
+	assert.Equal(t, "level=info msg=info\nmsg=\"without level\"\n", buf.String())
+
+	buf.Reset()
+	assert.Nil(t, lvl.Set("error"))
+	level.Warn(logger).Log("msg", "warn")
+	level.Error(logger).Log("msg", "error")
+	assert.Equal(t, "level=error msg=error\n", buf.String())
+
+	assert.NotNil(t, lvl.Set("verbose"))
+	assert.Equal(t, "error", lvl.String())
+}
+
+func TestLevelHandler(t *testing.T) {
+	lvl, _ := NewLevel("info")
+	handler := LevelHandler(lvl)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/loglevel", nil))
+	assert.Equal(t, "{\"level\":\"info\"}\n", w.Body.String())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"debug"}`)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "debug", lvl.String())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"verbose"}`)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "debug", lvl.String())
+}
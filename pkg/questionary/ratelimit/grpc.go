@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+//Method that returns the gRPC server options that send the quota of the client in the response header metadata,
+//the rejected requests also get a retry-after header with the seconds to wait.
+func GRPCServerOptions() []grpctransport.ServerOption {
+	return []grpctransport.ServerOption{
+		grpctransport.ServerBefore(func(ctx context.Context, md metadata.MD) context.Context {
+			ip := ""
+			if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+				ip = remoteIP(p.Addr.String())
+			}
+			return withClient(ctx, ip)
+		}),
+		grpctransport.ServerAfter(func(ctx context.Context, header *metadata.MD, trailer *metadata.MD) context.Context {
+			if md := grpcHeaders(ctx); md != nil {
+				*header = metadata.Join(*header, md)
+			}
+			return ctx
+		}),
+		//The after functions are not called when the endpoint fails, so the quota of the rejected requests is sent here
+		grpctransport.ServerFinalizer(func(ctx context.Context, err error) {
+			if quota, ok := quotaFrom(ctx); ok && !quota.Allowed {
+				grpc.SetHeader(ctx, grpcHeaders(ctx))
+			}
+		}),
+	}
+}
+
+func grpcHeaders(ctx context.Context) metadata.MD {
+	quota, ok := quotaFrom(ctx)
+	if !ok {
+		return nil
+	}
+	md := metadata.Pairs(
+		"x-ratelimit-limit", strconv.Itoa(quota.Limit),
+		"x-ratelimit-remaining", strconv.Itoa(quota.Remaining),
+	)
+	if !quota.Allowed {
+		md.Set("retry-after", strconv.FormatInt(retryAfterSeconds(quota), 10))
+	}
+	return md
+}
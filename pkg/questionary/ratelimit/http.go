@@ -0,0 +1,48 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+)
+
+//Method that returns the HTTP server options that send the quota of the client in the response headers,
+//the rejected requests also get a Retry-After header with the seconds to wait.
+func HTTPServerOptions(errorEncoder httptransport.ErrorEncoder) []httptransport.ServerOption {
+	return []httptransport.ServerOption{
+		httptransport.ServerBefore(func(ctx context.Context, r *http.Request) context.Context {
+			return withClient(ctx, remoteIP(r.RemoteAddr))
+		}),
+		httptransport.ServerAfter(func(ctx context.Context, w http.ResponseWriter) context.Context {
+			setHTTPHeaders(ctx, w)
+			return ctx
+		}),
+		httptransport.ServerErrorEncoder(func(ctx context.Context, err error, w http.ResponseWriter) {
+			setHTTPHeaders(ctx, w)
+			errorEncoder(ctx, err, w)
+		}),
+	}
+}
+
+func setHTTPHeaders(ctx context.Context, w http.ResponseWriter) {
+	quota, ok := quotaFrom(ctx)
+	if !ok {
+		return
+	}
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(quota.Limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(quota.Remaining))
+	if !quota.Allowed {
+		w.Header().Set("Retry-After", strconv.FormatInt(retryAfterSeconds(quota), 10))
+	}
+}
+
+func remoteIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
@@ -0,0 +1,169 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//This is the rate limiting of the API
+//Each endpoint has a token bucket per client, the clients are identified by its API key,
+//its authenticated user or its remote IP in that order.
+
+//The rate is the number of requests per second that refill the bucket
+//and the burst is the size of the bucket.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+//The limits of the endpoints by the name of its service method, the "*" limit applies to the methods that are not listed
+type Limits map[string]Limit
+
+//Name of the limit that applies to the methods without its own limit
+const DefaultLimit = "*"
+
+//Method that parses limits like "*=20:40,Create=0.2:5", each limit is the method, the rate and the burst
+func ParseLimits(value string) (Limits, error) {
+	limits := Limits{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		method, limit := entry, ""
+		if i := strings.Index(entry, "="); i >= 0 {
+			method, limit = strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+		}
+		parts := strings.Split(limit, ":")
+		if method == "" || len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid rate limit %q, the format is method=rate:burst.", entry)
+		}
+
+		rate, rateErr := strconv.ParseFloat(parts[0], 64)
+		burst, burstErr := strconv.Atoi(parts[1])
+		if rateErr != nil || burstErr != nil || rate <= 0 || burst < 1 {
+			return nil, fmt.Errorf("Invalid rate limit %q, the rate and the burst must be positive.", entry)
+		}
+		limits[method] = Limit{Rate: rate, Burst: burst}
+	}
+	return limits, nil
+}
+
+//The result of taking a token from the bucket of a client
+type Quota struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+//A Limiter keeps the token buckets of the clients of an endpoint,
+//the buckets of the clients that stop calling the endpoint are removed once they are full again.
+type Limiter struct {
+	limit     Limit
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewLimiter(limit Limit) *Limiter {
+	return &Limiter{
+		limit:   limit,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+//Method that takes a token from the bucket of the client
+func (l *Limiter) Allow(client string, now time.Time) Quota {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &bucket{tokens: float64(l.limit.Burst), last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.last = now
+
+	quota := Quota{Limit: l.limit.Burst}
+	if b.tokens < 1 {
+		wait := (1 - b.tokens) / l.limit.Rate
+		quota.RetryAfter = time.Duration(math.Ceil(wait * float64(time.Second)))
+		return quota
+	}
+	b.tokens--
+	quota.Allowed = true
+	quota.Remaining = int(b.tokens)
+	return quota
+}
+
+func (l *Limiter) refill(b *bucket, now time.Time) float64 {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return math.Min(float64(l.limit.Burst), b.tokens+elapsed*l.limit.Rate)
+}
+
+//The full buckets are removed at most once per minute, a new bucket of the same client starts full anyway
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+	for client, b := range l.buckets {
+		if l.refill(b, now) >= float64(l.limit.Burst) {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+//The limiters of all the endpoints, the HTTP and gRPC endpoints of the same method share its limiter
+//so a client has the same quota in both transports.
+type Limiters struct {
+	limits   Limits
+	mu       sync.Mutex
+	limiters map[string]*Limiter
+}
+
+func NewLimiters(limits Limits) *Limiters {
+	return &Limiters{
+		limits:   limits,
+		limiters: make(map[string]*Limiter),
+	}
+}
+
+//Method that returns the limiter of a method, a method without limit has no limiter
+func (l *Limiters) For(method string) *Limiter {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if limiter, ok := l.limiters[method]; ok {
+		return limiter
+	}
+	limit, ok := l.limits[method]
+	if !ok {
+		limit, ok = l.limits[DefaultLimit]
+	}
+	if !ok {
+		return nil
+	}
+	limiter := NewLimiter(limit)
+	l.limiters[method] = limiter
+	return limiter
+}
@@ -0,0 +1,134 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/auth"
+	httpError "github.com/ismaeljpv/qa-api/pkg/questionary/transport/http/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLimits(t *testing.T) {
+	limits, err := ParseLimits("*=20:40, Create=0.2:5")
+	assert.Nil(t, err)
+	assert.Equal(t, Limits{"*": {Rate: 20, Burst: 40}, "Create": {Rate: 0.2, Burst: 5}}, limits)
+
+	limits, err = ParseLimits("")
+	assert.Nil(t, err)
+	assert.Empty(t, limits)
+
+	for _, value := range []string{"Create", "Create=1", "=1:1", "Create=0:1", "Create=1:0", "Create=a:1"} {
+		_, err := ParseLimits(value)
+		assert.NotNil(t, err, value)
+	}
+}
+
+func TestLimiterAllow(t *testing.T) {
+	limiter := NewLimiter(Limit{Rate: 0.5, Burst: 2})
+	now := time.Now()
+
+	quota := limiter.Allow("user:1", now)
+	assert.True(t, quota.Allowed)
+	assert.Equal(t, 2, quota.Limit)
+	assert.Equal(t, 1, quota.Remaining)
+
+	quota = limiter.Allow("user:1", now)
+	assert.True(t, quota.Allowed)
+	assert.Equal(t, 0, quota.Remaining)
+
+	quota = limiter.Allow("user:1", now)
+	assert.False(t, quota.Allowed)
+	assert.Equal(t, 2*time.Second, quota.RetryAfter)
+
+	//Other clients have their own bucket
+	assert.True(t, limiter.Allow("user:2", now).Allowed)
+
+	quota = limiter.Allow("user:1", now.Add(2*time.Second))
+	assert.True(t, quota.Allowed)
+	assert.Equal(t, 0, quota.Remaining)
+}
+
+func TestLimitersFor(t *testing.T) {
+	limiters := NewLimiters(Limits{"*": {Rate: 10, Burst: 10}, "Create": {Rate: 1, Burst: 1}})
+
+	assert.Same(t, limiters.For("Create"), limiters.For("Create"))
+	assert.Equal(t, 1, limiters.For("Create").limit.Burst)
+	assert.Equal(t, 10, limiters.For("FindAll").limit.Burst)
+
+	assert.Nil(t, NewLimiters(Limits{"Create": {Rate: 1, Burst: 1}}).For("FindAll"))
+	var none *Limiters
+	assert.Nil(t, none.For("Create"))
+}
+
+func TestClient(t *testing.T) {
+	ctx := withClient(context.Background(), "10.0.0.1")
+	assert.Equal(t, "ip:10.0.0.1", Client(ctx))
+
+	ctx = auth.WithSubject(ctx, "1")
+	assert.Equal(t, "user:1", Client(ctx))
+
+	assert.Equal(t, "anonymous", Client(context.Background()))
+}
+
+func TestMiddleware(t *testing.T) {
+	limiters := NewLimiters(Limits{"Create": {Rate: 1, Burst: 1}})
+	next := func(ctx context.Context, request interface{}) (interface{}, error) {
+		return "created", nil
+	}
+	create := Middleware(limiters, "Create")(next)
+	ctx := auth.WithSubject(context.Background(), "1")
+
+	response, err := create(ctx, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "created", response)
+
+	_, err = create(ctx, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, apperror.RateLimited, apperror.KindOf(err))
+
+	//The methods without limit are not limited
+	findAll := Middleware(limiters, "FindAll")(next)
+	for i := 0; i < 3; i++ {
+		_, err := findAll(ctx, nil)
+		assert.Nil(t, err)
+	}
+}
+
+func TestHTTPServerOptions(t *testing.T) {
+	limiters := NewLimiters(Limits{"Create": {Rate: 0.1, Burst: 1}})
+	server := httptransport.NewServer(
+		Middleware(limiters, "Create")(func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, nil
+		}),
+		func(ctx context.Context, r *http.Request) (interface{}, error) { return nil, nil },
+		func(ctx context.Context, w http.ResponseWriter, response interface{}) error { return nil },
+		HTTPServerOptions(func(ctx context.Context, err error, w http.ResponseWriter) {
+			w.WriteHeader(httpError.StatusCode(apperror.KindOf(err)))
+		})...,
+	)
+
+	serve := func() *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/question", nil)
+		r.RemoteAddr = "10.0.0.1:4000"
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Empty(t, w.Header().Get("Retry-After"))
+
+	w = serve()
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "10", w.Header().Get("Retry-After"))
+}
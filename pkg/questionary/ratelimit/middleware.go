@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	kitratelimit "github.com/go-kit/kit/ratelimit"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/auth"
+)
+
+type contextKey int
+
+const (
+	quotaKey contextKey = iota
+	clientIPKey
+)
+
+//Method that returns an endpoint middleware that rejects the requests of the clients without tokens in the bucket of the method,
+//it works like the erroring limiter of go-kit with a bucket per client.
+//The quota of the request is stored in the context prepared by the transport to be sent on the response.
+func Middleware(limiters *Limiters, method string) endpoint.Middleware {
+	limiter := limiters.For(method)
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		if limiter == nil {
+			return next
+		}
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			quota := limiter.Allow(Client(ctx), time.Now())
+			if holder, ok := ctx.Value(quotaKey).(*Quota); ok {
+				*holder = quota
+			}
+
+			if !quota.Allowed {
+				return nil, apperror.New(kitratelimit.ErrLimited, apperror.RateLimited, "Too Many Requests")
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+//Method that returns the key of the client of the request
+func Client(ctx context.Context) string {
+	if id, ok := auth.APIKeyID(ctx); ok {
+		return "apikey:" + id
+	}
+	if subject, ok := auth.Subject(ctx); ok {
+		return "user:" + subject
+	}
+	if ip, ok := ctx.Value(clientIPKey).(string); ok && ip != "" {
+		return "ip:" + ip
+	}
+	return "anonymous"
+}
+
+//Method that returns a copy of the context with the remote IP of the request and an empty quota
+func withClient(ctx context.Context, ip string) context.Context {
+	ctx = context.WithValue(ctx, clientIPKey, ip)
+	return context.WithValue(ctx, quotaKey, &Quota{})
+}
+
+//Method that returns the quota of the request when the endpoint has a limiter
+func quotaFrom(ctx context.Context) (Quota, bool) {
+	quota, ok := ctx.Value(quotaKey).(*Quota)
+	if !ok || quota.Limit == 0 {
+		return Quota{}, false
+	}
+	return *quota, true
+}
+
+//The seconds to wait are rounded up so the client does not retry too early
+func retryAfterSeconds(quota Quota) int64 {
+	return int64((quota.RetryAfter + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+)
+
+//The questions are listed ordered by its creation date or score and then by its ID,
+//a cursor holds the sort order and the position of the last question of a page so the next page starts right after it.
+type Cursor struct {
+	CreatedOn int64  `json:"c"`
+	ID        string `json:"id"`
+	Score     int64  `json:"s,omitempty"`
+	Sort      string `json:"o,omitempty"`
+}
+
+//Method that returns the opaque token of the position of a question in a listing with the given sort order
+func EncodeCursor(question domain.Question, sort string) string {
+	token, _ := json.Marshal(Cursor{CreatedOn: question.CreatedOn, ID: question.ID, Score: question.Score, Sort: sort})
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+//Method that reads the position stored in an opaque token, an empty token is the start of the listing.
+//The token is rejected when it was returned by a listing with a different sort order.
+func DecodeCursor(token string, sort string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	var cursor Cursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(data, &cursor)
+	}
+	if cursor.Sort == "" {
+		cursor.Sort = domain.SortOldest
+	}
+	if err != nil || cursor.ID == "" || cursor.Sort != sort {
+		return nil, apperror.New(errors.New("Invalid cursor"),
+			apperror.Invalid,
+			"The cursor is not valid")
+	}
+	return &cursor, nil
+}
+
+//Method that tells if a question is placed after the position of the cursor
+func (c *Cursor) Before(question domain.Question) bool {
+	if c == nil {
+		return true
+	}
+	position := domain.Question{ID: c.ID, CreatedOn: c.CreatedOn, Score: c.Score}
+	return Precedes(position, question, c.Sort)
+}
+
+//Method that tells if the question a is listed before the question b in the given sort order,
+//the newest and oldest orders use the creation date and the score order lists the highest scores first
+func Precedes(a domain.Question, b domain.Question, sort string) bool {
+	switch sort {
+	case domain.SortNewest:
+		if a.CreatedOn != b.CreatedOn {
+			return a.CreatedOn > b.CreatedOn
+		}
+		return a.ID > b.ID
+	case domain.SortScore:
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.ID < b.ID
+	default:
+		if a.CreatedOn != b.CreatedOn {
+			return a.CreatedOn < b.CreatedOn
+		}
+		return a.ID < b.ID
+	}
+}
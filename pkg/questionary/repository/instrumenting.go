@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+)
+
+//A Middleware decorates a Repository with cross cutting behavior.
+type Middleware func(Repository) Repository
+
+//This is the instrumenting layer of the database
+//The latency of each call to the repository is recorded by its method and outcome ("success" or "error").
+type instrumentingMiddleware struct {
+	next    Repository
+	latency metrics.Histogram
+}
+
+//The latency histogram uses the "method" and "outcome" labels
+func NewInstrumentingMiddleware(latency metrics.Histogram) Middleware {
+	return func(next Repository) Repository {
+		return &instrumentingMiddleware{
+			next:    next,
+			latency: latency,
+		}
+	}
+}
+
+func (mw *instrumentingMiddleware) observe(method string, begin time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	mw.latency.With("method", method, "outcome", outcome).Observe(time.Since(begin).Seconds())
+}
+
+func (mw *instrumentingMiddleware) FindAll(ctx context.Context, filter domain.QuestionFilter, page domain.Page) (result domain.QuestionPage, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindAll", begin, err)
+	}(time.Now())
+	return mw.next.FindAll(ctx, filter, page)
+}
+
+func (mw *instrumentingMiddleware) FindByID(ctx context.Context, id string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindByID", begin, err)
+	}(time.Now())
+	return mw.next.FindByID(ctx, id)
+}
+
+func (mw *instrumentingMiddleware) FindByUser(ctx context.Context, userId string, page domain.Page) (result domain.QuestionPage, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindByUser", begin, err)
+	}(time.Now())
+	return mw.next.FindByUser(ctx, userId, page)
+}
+
+func (mw *instrumentingMiddleware) FindByTag(ctx context.Context, tag string) (result []domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindByTag", begin, err)
+	}(time.Now())
+	return mw.next.FindByTag(ctx, tag)
+}
+
+func (mw *instrumentingMiddleware) Search(ctx context.Context, query string, filter domain.QuestionFilter) (result []domain.SearchResult, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Search", begin, err)
+	}(time.Now())
+	return mw.next.Search(ctx, query, filter)
+}
+
+func (mw *instrumentingMiddleware) FindTags(ctx context.Context) (result []domain.TagCount, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindTags", begin, err)
+	}(time.Now())
+	return mw.next.FindTags(ctx)
+}
+
+func (mw *instrumentingMiddleware) Create(ctx context.Context, question domain.Question) (result domain.Question, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Create", begin, err)
+	}(time.Now())
+	return mw.next.Create(ctx, question)
+}
+
+func (mw *instrumentingMiddleware) Update(ctx context.Context, questionInfo domain.QuestionInfo) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Update", begin, err)
+	}(time.Now())
+	return mw.next.Update(ctx, questionInfo)
+}
+
+func (mw *instrumentingMiddleware) Delete(ctx context.Context, id string, userId string) (result string, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Delete", begin, err)
+	}(time.Now())
+	return mw.next.Delete(ctx, id, userId)
+}
+
+func (mw *instrumentingMiddleware) Restore(ctx context.Context, id string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Restore", begin, err)
+	}(time.Now())
+	return mw.next.Restore(ctx, id)
+}
+
+func (mw *instrumentingMiddleware) Purge(ctx context.Context, deletedBefore int64) (result int64, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Purge", begin, err)
+	}(time.Now())
+	return mw.next.Purge(ctx, deletedBefore)
+}
+
+func (mw *instrumentingMiddleware) AddAnswer(ctx context.Context, answer domain.Answer) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.observe("AddAnswer", begin, err)
+	}(time.Now())
+	return mw.next.AddAnswer(ctx, answer)
+}
+
+func (mw *instrumentingMiddleware) FindAnswers(ctx context.Context, questionId string) (result []domain.Answer, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindAnswers", begin, err)
+	}(time.Now())
+	return mw.next.FindAnswers(ctx, questionId)
+}
+
+func (mw *instrumentingMiddleware) UpdateAnswer(ctx context.Context, answer domain.Answer) (result domain.Answer, err error) {
+	defer func(begin time.Time) {
+		mw.observe("UpdateAnswer", begin, err)
+	}(time.Now())
+	return mw.next.UpdateAnswer(ctx, answer)
+}
+
+func (mw *instrumentingMiddleware) DeleteAnswer(ctx context.Context, questionId string, answerId string) (result string, err error) {
+	defer func(begin time.Time) {
+		mw.observe("DeleteAnswer", begin, err)
+	}(time.Now())
+	return mw.next.DeleteAnswer(ctx, questionId, answerId)
+}
+
+func (mw *instrumentingMiddleware) AcceptAnswer(ctx context.Context, questionId string, answerId string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.observe("AcceptAnswer", begin, err)
+	}(time.Now())
+	return mw.next.AcceptAnswer(ctx, questionId, answerId)
+}
+
+func (mw *instrumentingMiddleware) UnacceptAnswer(ctx context.Context, questionId string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.observe("UnacceptAnswer", begin, err)
+	}(time.Now())
+	return mw.next.UnacceptAnswer(ctx, questionId)
+}
+
+func (mw *instrumentingMiddleware) Vote(ctx context.Context, vote domain.Vote) (result domain.Score, err error) {
+	defer func(begin time.Time) {
+		mw.observe("Vote", begin, err)
+	}(time.Now())
+	return mw.next.Vote(ctx, vote)
+}
+
+func (mw *instrumentingMiddleware) RetractVote(ctx context.Context, targetType string, targetId string, userId string) (result domain.Score, err error) {
+	defer func(begin time.Time) {
+		mw.observe("RetractVote", begin, err)
+	}(time.Now())
+	return mw.next.RetractVote(ctx, targetType, targetId, userId)
+}
+
+func (mw *instrumentingMiddleware) AddComment(ctx context.Context, comment domain.Comment) (result domain.Comment, err error) {
+	defer func(begin time.Time) {
+		mw.observe("AddComment", begin, err)
+	}(time.Now())
+	return mw.next.AddComment(ctx, comment)
+}
+
+func (mw *instrumentingMiddleware) FindComments(ctx context.Context, parentType string, parentId string) (result []domain.Comment, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindComments", begin, err)
+	}(time.Now())
+	return mw.next.FindComments(ctx, parentType, parentId)
+}
+
+func (mw *instrumentingMiddleware) FindComment(ctx context.Context, questionId string, commentId string) (result domain.Comment, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindComment", begin, err)
+	}(time.Now())
+	return mw.next.FindComment(ctx, questionId, commentId)
+}
+
+func (mw *instrumentingMiddleware) UpdateComment(ctx context.Context, comment domain.Comment) (result domain.Comment, err error) {
+	defer func(begin time.Time) {
+		mw.observe("UpdateComment", begin, err)
+	}(time.Now())
+	return mw.next.UpdateComment(ctx, comment)
+}
+
+func (mw *instrumentingMiddleware) DeleteComment(ctx context.Context, questionId string, commentId string) (result string, err error) {
+	defer func(begin time.Time) {
+		mw.observe("DeleteComment", begin, err)
+	}(time.Now())
+	return mw.next.DeleteComment(ctx, questionId, commentId)
+}
+
+func (mw *instrumentingMiddleware) FindRevisions(ctx context.Context, questionId string) (result []domain.Revision, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindRevisions", begin, err)
+	}(time.Now())
+	return mw.next.FindRevisions(ctx, questionId)
+}
+
+func (mw *instrumentingMiddleware) FindRevision(ctx context.Context, questionId string, number int64) (result domain.Revision, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindRevision", begin, err)
+	}(time.Now())
+	return mw.next.FindRevision(ctx, questionId, number)
+}
+
+func (mw *instrumentingMiddleware) RestoreRevision(ctx context.Context, revision domain.Revision, userId string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.observe("RestoreRevision", begin, err)
+	}(time.Now())
+	return mw.next.RestoreRevision(ctx, revision, userId)
+}
+
+func (mw *instrumentingMiddleware) FindRoles(ctx context.Context, userId string) (result domain.UserRoles, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindRoles", begin, err)
+	}(time.Now())
+	return mw.next.FindRoles(ctx, userId)
+}
+
+func (mw *instrumentingMiddleware) GrantRole(ctx context.Context, userId string, role string) (result domain.UserRoles, err error) {
+	defer func(begin time.Time) {
+		mw.observe("GrantRole", begin, err)
+	}(time.Now())
+	return mw.next.GrantRole(ctx, userId, role)
+}
+
+func (mw *instrumentingMiddleware) RevokeRole(ctx context.Context, userId string, role string) (result domain.UserRoles, err error) {
+	defer func(begin time.Time) {
+		mw.observe("RevokeRole", begin, err)
+	}(time.Now())
+	return mw.next.RevokeRole(ctx, userId, role)
+}
+
+func (mw *instrumentingMiddleware) CreateAPIKey(ctx context.Context, apiKey domain.APIKey) (result domain.APIKey, err error) {
+	defer func(begin time.Time) {
+		mw.observe("CreateAPIKey", begin, err)
+	}(time.Now())
+	return mw.next.CreateAPIKey(ctx, apiKey)
+}
+
+func (mw *instrumentingMiddleware) FindAPIKeys(ctx context.Context) (result []domain.APIKey, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindAPIKeys", begin, err)
+	}(time.Now())
+	return mw.next.FindAPIKeys(ctx)
+}
+
+func (mw *instrumentingMiddleware) FindAPIKeyByHash(ctx context.Context, hash string) (result domain.APIKey, err error) {
+	defer func(begin time.Time) {
+		mw.observe("FindAPIKeyByHash", begin, err)
+	}(time.Now())
+	return mw.next.FindAPIKeyByHash(ctx, hash)
+}
+
+func (mw *instrumentingMiddleware) RevokeAPIKey(ctx context.Context, id string, revokedOn int64) (result string, err error) {
+	defer func(begin time.Time) {
+		mw.observe("RevokeAPIKey", begin, err)
+	}(time.Now())
+	return mw.next.RevokeAPIKey(ctx, id, revokedOn)
+}
+
+func (mw *instrumentingMiddleware) TouchAPIKey(ctx context.Context, id string, usedOn int64) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("TouchAPIKey", begin, err)
+	}(time.Now())
+	return mw.next.TouchAPIKey(ctx, id, usedOn)
+}
+
+func (mw *instrumentingMiddleware) Ping(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("Ping", begin, err)
+	}(time.Now())
+	return mw.next.Ping(ctx)
+}
+
+func (mw *instrumentingMiddleware) Close(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		mw.observe("Close", begin, err)
+	}(time.Now())
+	return mw.next.Close(ctx)
+}
@@ -4,15 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
 	repo "github.com/ismaeljpv/qa-api/pkg/questionary/repository"
-	httpError "github.com/ismaeljpv/qa-api/pkg/questionary/transport/http/error"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/requestid"
 )
 
 //This is a Mock implementation of a real database using in memory data
@@ -24,13 +26,16 @@ var questionData = []domain.QuestionInfo{
 			Statement: "Do You Think That GO Rocks?",
 			UserID:    "1",
 			CreatedOn: time.Now().Unix(),
+			Tags:      []string{"go"},
 		},
-		Answer: domain.Answer{
-			ID:         "1",
-			Answer:     "Yes!",
-			QuestionID: "1",
-			UserID:     "2",
-			CreatedOn:  time.Now().Unix(),
+		Answers: []domain.Answer{
+			{
+				ID:         "1",
+				Answer:     "Yes!",
+				QuestionID: "1",
+				UserID:     "2",
+				CreatedOn:  time.Now().Unix(),
+			},
 		},
 	},
 	{
@@ -40,6 +45,7 @@ var questionData = []domain.QuestionInfo{
 			UserID:    "1",
 			CreatedOn: time.Now().Unix(),
 		},
+		Answers: []domain.Answer{},
 	},
 	{
 		Question: domain.Question{
@@ -47,48 +53,99 @@ var questionData = []domain.QuestionInfo{
 			Statement: "What is a chanel in GO?",
 			UserID:    "2",
 			CreatedOn: time.Now().Unix(),
+			Tags:      []string{"go", "channels"},
 		},
-		Answer: domain.Answer{
-			ID:         "2",
-			Answer:     "Is the conduct that let goroutines to comunicate to each other",
-			QuestionID: "3",
-			UserID:     "1",
-			CreatedOn:  time.Now().Unix(),
+		Answers: []domain.Answer{
+			{
+				ID:         "2",
+				Answer:     "Is the conduct that let goroutines to comunicate to each other",
+				QuestionID: "3",
+				UserID:     "1",
+				CreatedOn:  time.Now().Unix(),
+			},
 		},
 	},
 }
 
+//The HTTP and gRPC servers call the repository at the same time, so every method holds the mutex
+//and the methods that need another one call its unexported version that does not lock it.
 type repository struct {
-	db     []domain.QuestionInfo
-	logger log.Logger
+	mu        sync.Mutex
+	db        []domain.QuestionInfo
+	deleted   []domain.QuestionInfo
+	votes     []domain.Vote
+	comments  []domain.Comment
+	revisions []domain.Revision
+	roles     map[string][]string
+	apiKeys   []domain.APIKey
+	logger    log.Logger
 }
 
 func NewRepository(logger log.Logger) repo.Repository {
 	return &repository{
-		db:     questionData,
-		logger: logger,
+		db:        seedData(),
+		deleted:   []domain.QuestionInfo{},
+		votes:     []domain.Vote{},
+		comments:  []domain.Comment{},
+		revisions: []domain.Revision{},
+		roles:     map[string][]string{},
+		apiKeys:   []domain.APIKey{},
+		logger:    logger,
 	}
 }
 
-func (r *repository) FindAll(ctx context.Context) ([]domain.QuestionInfo, error) {
+//Every repository starts from its own copy of the seed, so the changes of one repository
+//are not seen by the others
+func seedData() []domain.QuestionInfo {
+	data := make([]domain.QuestionInfo, len(questionData))
+	for i, questionInfo := range questionData {
+		questionInfo.Question.Tags = append([]string(nil), questionInfo.Question.Tags...)
+		questionInfo.Answers = append([]domain.Answer{}, questionInfo.Answers...)
+		data[i] = questionInfo
+	}
+	return data
+}
+
+//The logs of a request have its request ID
+func (r *repository) log(ctx context.Context) log.Logger {
+	return requestid.Logger(ctx, r.logger)
+}
+
+func (r *repository) FindAll(ctx context.Context, filter domain.QuestionFilter, page domain.Page) (domain.QuestionPage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	questions := []domain.QuestionInfo{}
 	DBQuestions := r.db
-	return DBQuestions, nil
+	for _, questionInfo := range DBQuestions {
+		if matchesFilter(questionInfo, filter) {
+			questions = append(questions, questionInfo)
+		}
+	}
+	return paginate(questions, page)
 }
 
 func (r *repository) FindByID(ctx context.Context, id string) (domain.QuestionInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.findByID(ctx, id)
+}
+
+func (r *repository) findByID(ctx context.Context, id string) (domain.QuestionInfo, error) {
 	DBQuestions := r.db
 	for _, questionInfo := range DBQuestions {
 		if questionInfo.Question.ID == id {
 			return questionInfo, nil
 		}
 	}
-	level.Warn(r.logger).Log("msg", fmt.Sprintf("No Question Found by ID %v, method FindByID", id))
-	return domain.QuestionInfo{}, httpError.NewClientError(errors.New(fmt.Sprintf("No question found by ID %v", id)),
-		http.StatusNotFound,
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Question Found by ID %v, method FindByID", id))
+	return domain.QuestionInfo{}, apperror.New(errors.New(fmt.Sprintf("No question found by ID %v", id)),
+		apperror.NotFound,
 		"No Question Found")
 }
 
-func (r *repository) FindByUser(ctx context.Context, userId string) ([]domain.QuestionInfo, error) {
+func (r *repository) FindByUser(ctx context.Context, userId string, page domain.Page) (domain.QuestionPage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	userQuestions := []domain.QuestionInfo{}
 	DBQuestions := r.db
 	for _, questionInfo := range DBQuestions {
@@ -96,84 +153,720 @@ func (r *repository) FindByUser(ctx context.Context, userId string) ([]domain.Qu
 			userQuestions = append(userQuestions, questionInfo)
 		}
 	}
-	return userQuestions, nil
+	return paginate(userQuestions, page)
+}
+
+func (r *repository) FindByTag(ctx context.Context, tag string) ([]domain.QuestionInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tagQuestions := []domain.QuestionInfo{}
+	DBQuestions := r.db
+	for _, questionInfo := range DBQuestions {
+		for _, questionTag := range questionInfo.Question.Tags {
+			if questionTag == tag {
+				tagQuestions = append(tagQuestions, questionInfo)
+				break
+			}
+		}
+	}
+	return tagQuestions, nil
+}
+
+func (r *repository) Search(ctx context.Context, query string, filter domain.QuestionFilter) ([]domain.SearchResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	questions := []domain.QuestionInfo{}
+	for _, questionInfo := range r.db {
+		if matchesFilter(questionInfo, filter) {
+			questions = append(questions, questionInfo)
+		}
+	}
+
+	results := []domain.SearchResult{}
+	for i, relevance := range newSearchIndex(questions).search(query) {
+		results = append(results, domain.SearchResult{QuestionInfo: questions[i], Relevance: relevance})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Relevance != results[j].Relevance {
+			return results[i].Relevance > results[j].Relevance
+		}
+		return results[i].QuestionInfo.Question.ID < results[j].QuestionInfo.Question.ID
+	})
+	if len(results) > domain.MaxSearchResults {
+		results = results[:domain.MaxSearchResults]
+	}
+	return results, nil
+}
+
+func (r *repository) FindTags(ctx context.Context) ([]domain.TagCount, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tags := []domain.TagCount{}
+	counts := make(map[string]int64)
+	for _, questionInfo := range r.db {
+		for _, tag := range questionInfo.Question.Tags {
+			counts[tag]++
+		}
+	}
+
+	for tag, count := range counts {
+		tags = append(tags, domain.TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+	return tags, nil
 }
 
 func (r *repository) Create(ctx context.Context, question domain.Question) (domain.Question, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	for _, questionInfo := range r.db {
 		if questionInfo.Question.ID == question.ID {
-			return domain.Question{}, httpError.NewClientError(errors.New("Conflict - Question already exists"),
-				http.StatusConflict,
+			return domain.Question{}, apperror.New(errors.New("Conflict - Question already exists"),
+				apperror.Conflict,
 				"Question Already Exists")
 		}
 	}
-	r.db = append(r.db, domain.QuestionInfo{Question: question})
+	r.db = append(r.db, domain.QuestionInfo{Question: question, Answers: []domain.Answer{}})
 	return question, nil
 }
 
 func (r *repository) Update(ctx context.Context, questionInfo domain.QuestionInfo) (domain.QuestionInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	var updated bool
 	for i, questionData := range r.db {
 		if questionData.Question.ID == questionInfo.Question.ID {
 
 			if strings.Compare(questionData.Question.Statement, questionInfo.Question.Statement) != 0 {
+				r.recordRevision(i, domain.TargetQuestion, questionData.Question.ID, questionData.Question.Statement, questionInfo.Question.UserID)
 				r.db[i].Question.Statement = questionInfo.Question.Statement
 				updated = true
 			}
 
-			if r.db[i].Answer.ID != "" && r.db[i].Answer.ID == questionInfo.Answer.ID {
-				if strings.Compare(questionData.Answer.Answer, questionInfo.Answer.Answer) != 0 {
-					r.db[i].Answer.Answer = questionInfo.Answer.Answer
+			if questionInfo.Question.Tags != nil && strings.Join(questionData.Question.Tags, ",") != strings.Join(questionInfo.Question.Tags, ",") {
+				r.db[i].Question.Tags = questionInfo.Question.Tags
+				updated = true
+			}
+
+			for j, answer := range questionData.Answers {
+				if answer.ID == questionInfo.Answer.ID && strings.Compare(answer.Answer, questionInfo.Answer.Answer) != 0 {
+					r.recordRevision(i, domain.TargetAnswer, answer.ID, answer.Answer, questionInfo.Answer.UserID)
+					r.db[i].Answers[j].Answer = questionInfo.Answer.Answer
 					updated = true
 				}
 			}
 
-			if updated {
-				return r.db[i], nil
+			if !updated {
+				return domain.QuestionInfo{}, apperror.New(errors.New("The Question/Answer Has No Modifications"),
+					apperror.Invalid,
+					"The Question/Answer Has No Modifications")
 			}
+			return r.db[i], nil
 		}
 	}
 
-	level.Warn(r.logger).Log("msg", fmt.Sprintf("No Question Found by ID %v, method Update", questionInfo.Question.ID))
-	return domain.QuestionInfo{}, httpError.NewClientError(errors.New(fmt.Sprintf("No question found by ID %v", questionInfo.Question.ID)),
-		http.StatusNotFound,
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Question Found by ID %v, method Update", questionInfo.Question.ID))
+	return domain.QuestionInfo{}, apperror.New(errors.New(fmt.Sprintf("No question found by ID %v", questionInfo.Question.ID)),
+		apperror.NotFound,
 		"No Question Found To Update")
 }
 
-func (r *repository) Delete(ctx context.Context, id string) (string, error) {
-	var deleted bool
+func (r *repository) Delete(ctx context.Context, id string, userId string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	for i, questionInfo := range r.db {
 		if questionInfo.Question.ID == id {
+			questionInfo.DeletedOn = time.Now().Unix()
+			questionInfo.DeletedBy = userId
+			r.deleted = append(r.deleted, questionInfo)
 			r.db = append(r.db[:i], r.db[i+1:]...)
-			deleted = true
+			return "Question Deleted Successfully!", nil
 		}
 	}
 
-	if deleted {
-		return "Question Deleted Successfully!", nil
-	} else {
-		level.Warn(r.logger).Log("msg", fmt.Sprintf("No Question Found by ID %v, method Delete", id))
-		return "", httpError.NewClientError(errors.New(fmt.Sprintf("No question found by ID %v", id)),
-			http.StatusNotFound,
-			"No Question Found")
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Question Found by ID %v, method Delete", id))
+	return "", apperror.New(errors.New(fmt.Sprintf("No question found by ID %v", id)),
+		apperror.NotFound,
+		"No Question Found")
+}
+
+func (r *repository) Restore(ctx context.Context, id string) (domain.QuestionInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, questionInfo := range r.deleted {
+		if questionInfo.Question.ID == id {
+			questionInfo.DeletedOn = 0
+			questionInfo.DeletedBy = ""
+			r.db = append(r.db, questionInfo)
+			r.deleted = append(r.deleted[:i], r.deleted[i+1:]...)
+			return questionInfo, nil
+		}
 	}
+
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Deleted Question Found by ID %v, method Restore", id))
+	return domain.QuestionInfo{}, apperror.New(errors.New(fmt.Sprintf("No deleted question found by ID %v", id)),
+		apperror.NotFound,
+		"No Deleted Question Found")
 }
 
-func (r repository) AddAnswer(ctx context.Context, answer domain.Answer) (domain.QuestionInfo, error) {
+func (r *repository) Purge(ctx context.Context, deletedBefore int64) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var purged int64
+	kept := []domain.QuestionInfo{}
+	for _, questionInfo := range r.deleted {
+		if questionInfo.DeletedOn >= deletedBefore {
+			kept = append(kept, questionInfo)
+			continue
+		}
+
+		id := questionInfo.Question.ID
+		r.deleteComments(func(comment domain.Comment) bool { return comment.QuestionID == id })
+		r.deleteVotes(func(vote domain.Vote) bool {
+			_, found := questionInfo.Text(vote.TargetType, vote.TargetID)
+			return found
+		})
+		revisions := []domain.Revision{}
+		for _, revision := range r.revisions {
+			if revision.QuestionID != id {
+				revisions = append(revisions, revision)
+			}
+		}
+		r.revisions = revisions
+		purged++
+	}
+	r.deleted = kept
+	return purged, nil
+}
+
+//Method that tells if a question is waiting to be purged
+func (r *repository) isDeleted(questionId string) bool {
+	for _, questionInfo := range r.deleted {
+		if questionInfo.Question.ID == questionId {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *repository) AddAnswer(ctx context.Context, answer domain.Answer) (domain.QuestionInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	for i, questionInfo := range r.db {
 		if questionInfo.Question.ID == answer.QuestionID {
-			if r.db[i].Answer.ID == "" {
-				r.db[i].Answer = answer
+			r.db[i].Answers = append(r.db[i].Answers, answer)
+			return r.db[i], nil
+		}
+	}
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Question Found by ID %v, method AddAnswer", answer.QuestionID))
+	return domain.QuestionInfo{}, apperror.New(errors.New(fmt.Sprintf("No question found by ID %v", answer.QuestionID)),
+		apperror.NotFound,
+		"No Question Found")
+}
+
+func (r *repository) FindAnswers(ctx context.Context, questionId string) ([]domain.Answer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	questionInfo, err := r.findByID(ctx, questionId)
+	if err != nil {
+		return []domain.Answer{}, err
+	}
+	return questionInfo.Answers, nil
+}
+
+func (r *repository) UpdateAnswer(ctx context.Context, answer domain.Answer) (domain.Answer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, questionInfo := range r.db {
+		if questionInfo.Question.ID != answer.QuestionID {
+			continue
+		}
+		for j, answerData := range questionInfo.Answers {
+			if answerData.ID == answer.ID {
+				if answerData.Answer != answer.Answer {
+					r.recordRevision(i, domain.TargetAnswer, answer.ID, answerData.Answer, answer.UserID)
+					r.db[i].Answers[j].Answer = answer.Answer
+				}
+				return r.db[i].Answers[j], nil
+			}
+		}
+	}
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Answer Found by ID %v, method UpdateAnswer", answer.ID))
+	return domain.Answer{}, apperror.New(errors.New(fmt.Sprintf("No answer found by ID %v", answer.ID)),
+		apperror.NotFound,
+		"No Answer Found")
+}
+
+func (r *repository) DeleteAnswer(ctx context.Context, questionId string, answerId string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, questionInfo := range r.db {
+		if questionInfo.Question.ID != questionId {
+			continue
+		}
+		for j, answer := range questionInfo.Answers {
+			if answer.ID == answerId {
+				r.db[i].Answers = append(r.db[i].Answers[:j], r.db[i].Answers[j+1:]...)
+				if r.db[i].AcceptedAnswerID == answerId {
+					r.db[i].AcceptedAnswerID = ""
+				}
+				r.deleteComments(func(comment domain.Comment) bool {
+					return comment.ParentType == domain.TargetAnswer && comment.ParentID == answerId
+				})
+				r.deleteVotes(func(vote domain.Vote) bool {
+					return vote.TargetType == domain.TargetAnswer && vote.TargetID == answerId
+				})
+				return "Answer Deleted Successfully!", nil
+			}
+		}
+	}
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Answer Found by ID %v, method DeleteAnswer", answerId))
+	return "", apperror.New(errors.New(fmt.Sprintf("No answer found by ID %v", answerId)),
+		apperror.NotFound,
+		"No Answer Found")
+}
+
+func (r *repository) AcceptAnswer(ctx context.Context, questionId string, answerId string) (domain.QuestionInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, questionInfo := range r.db {
+		if questionInfo.Question.ID != questionId {
+			continue
+		}
+		for _, answer := range questionInfo.Answers {
+			if answer.ID == answerId {
+				r.db[i].AcceptedAnswerID = answerId
 				return r.db[i], nil
-			} else {
-				return domain.QuestionInfo{}, httpError.NewClientError(errors.New("Question is already answered"),
-					http.StatusConflict,
-					"The question already has an answer!")
 			}
 		}
 	}
-	level.Warn(r.logger).Log("msg", fmt.Sprintf("No Question Found by ID %v, method AddAnswer", answer.QuestionID))
-	return domain.QuestionInfo{}, httpError.NewClientError(errors.New(fmt.Sprintf("No question found by ID %v", answer.QuestionID)),
-		http.StatusNotFound,
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Answer Found by ID %v, method AcceptAnswer", answerId))
+	return domain.QuestionInfo{}, apperror.New(errors.New(fmt.Sprintf("No answer found by ID %v", answerId)),
+		apperror.NotFound,
+		"No Answer Found")
+}
+
+func (r *repository) UnacceptAnswer(ctx context.Context, questionId string) (domain.QuestionInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, questionInfo := range r.db {
+		if questionInfo.Question.ID == questionId {
+			r.db[i].AcceptedAnswerID = ""
+			return r.db[i], nil
+		}
+	}
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Question Found by ID %v, method UnacceptAnswer", questionId))
+	return domain.QuestionInfo{}, apperror.New(errors.New(fmt.Sprintf("No question found by ID %v", questionId)),
+		apperror.NotFound,
+		"No Question Found")
+}
+
+func (r *repository) Vote(ctx context.Context, vote domain.Vote) (domain.Score, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	score, err := r.findScore(ctx, vote.TargetType, vote.TargetID)
+	if err != nil {
+		return domain.Score{}, err
+	}
+
+	for i, voteData := range r.votes {
+		if voteData.TargetType == vote.TargetType && voteData.TargetID == vote.TargetID && voteData.UserID == vote.UserID {
+			*score += int64(vote.Value - voteData.Value)
+			r.votes[i] = vote
+			return domain.Score{TargetType: vote.TargetType, TargetID: vote.TargetID, Score: *score}, nil
+		}
+	}
+	*score += int64(vote.Value)
+	r.votes = append(r.votes, vote)
+	return domain.Score{TargetType: vote.TargetType, TargetID: vote.TargetID, Score: *score}, nil
+}
+
+func (r *repository) RetractVote(ctx context.Context, targetType string, targetId string, userId string) (domain.Score, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	score, err := r.findScore(ctx, targetType, targetId)
+	if err != nil {
+		return domain.Score{}, err
+	}
+
+	for i, voteData := range r.votes {
+		if voteData.TargetType == targetType && voteData.TargetID == targetId && voteData.UserID == userId {
+			*score -= int64(voteData.Value)
+			r.votes = append(r.votes[:i], r.votes[i+1:]...)
+			return domain.Score{TargetType: targetType, TargetID: targetId, Score: *score}, nil
+		}
+	}
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Vote Found for user %v, method RetractVote", userId))
+	return domain.Score{}, apperror.New(errors.New(fmt.Sprintf("No vote found for user %v", userId)),
+		apperror.NotFound,
+		"No Vote Found")
+}
+
+//Method that returns a reference to the score of the question or answer that receives a vote
+func (r *repository) findScore(ctx context.Context, targetType string, targetId string) (*int64, error) {
+	for i, questionInfo := range r.db {
+		if targetType == domain.TargetQuestion && questionInfo.Question.ID == targetId {
+			return &r.db[i].Question.Score, nil
+		}
+		if targetType != domain.TargetAnswer {
+			continue
+		}
+		for j, answer := range questionInfo.Answers {
+			if answer.ID == targetId {
+				return &r.db[i].Answers[j].Score, nil
+			}
+		}
+	}
+
+	if targetType == domain.TargetAnswer {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Answer Found by ID %v, method findScore", targetId))
+		return nil, apperror.New(errors.New(fmt.Sprintf("No answer found by ID %v", targetId)),
+			apperror.NotFound,
+			"No Answer Found")
+	}
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Question Found by ID %v, method findScore", targetId))
+	return nil, apperror.New(errors.New(fmt.Sprintf("No question found by ID %v", targetId)),
+		apperror.NotFound,
 		"No Question Found")
 }
+
+//Method that removes the votes that match the condition, it is used to delete the votes of a deleted answer or a purged question
+func (r *repository) deleteVotes(match func(vote domain.Vote) bool) {
+	votes := []domain.Vote{}
+	for _, vote := range r.votes {
+		if !match(vote) {
+			votes = append(votes, vote)
+		}
+	}
+	r.votes = votes
+}
+
+func (r *repository) AddComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, questionInfo := range r.db {
+		if questionInfo.Question.ID != comment.QuestionID {
+			continue
+		}
+		if comment.ParentType == domain.TargetQuestion {
+			r.comments = append(r.comments, comment)
+			return comment, nil
+		}
+		for _, answer := range questionInfo.Answers {
+			if answer.ID == comment.ParentID {
+				r.comments = append(r.comments, comment)
+				return comment, nil
+			}
+		}
+	}
+
+	if comment.ParentType == domain.TargetAnswer {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Answer Found by ID %v, method AddComment", comment.ParentID))
+		return domain.Comment{}, apperror.New(errors.New(fmt.Sprintf("No answer found by ID %v", comment.ParentID)),
+			apperror.NotFound,
+			"No Answer Found")
+	}
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Question Found by ID %v, method AddComment", comment.QuestionID))
+	return domain.Comment{}, apperror.New(errors.New(fmt.Sprintf("No question found by ID %v", comment.QuestionID)),
+		apperror.NotFound,
+		"No Question Found")
+}
+
+func (r *repository) FindComments(ctx context.Context, parentType string, parentId string) ([]domain.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	comments := []domain.Comment{}
+	for _, comment := range r.comments {
+		if comment.ParentType == parentType && comment.ParentID == parentId && !r.isDeleted(comment.QuestionID) {
+			comments = append(comments, comment)
+		}
+	}
+	return comments, nil
+}
+
+func (r *repository) FindComment(ctx context.Context, questionId string, commentId string) (domain.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, comment := range r.comments {
+		if comment.ID == commentId && comment.QuestionID == questionId && !r.isDeleted(questionId) {
+			return comment, nil
+		}
+	}
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Comment Found by ID %v, method FindComment", commentId))
+	return domain.Comment{}, apperror.New(errors.New(fmt.Sprintf("No comment found by ID %v", commentId)),
+		apperror.NotFound,
+		"No Comment Found")
+}
+
+func (r *repository) UpdateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, commentData := range r.comments {
+		if commentData.ID == comment.ID && commentData.QuestionID == comment.QuestionID {
+			r.comments[i].Comment = comment.Comment
+			return r.comments[i], nil
+		}
+	}
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Comment Found by ID %v, method UpdateComment", comment.ID))
+	return domain.Comment{}, apperror.New(errors.New(fmt.Sprintf("No comment found by ID %v", comment.ID)),
+		apperror.NotFound,
+		"No Comment Found")
+}
+
+func (r *repository) DeleteComment(ctx context.Context, questionId string, commentId string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, comment := range r.comments {
+		if comment.ID == commentId && comment.QuestionID == questionId {
+			r.comments = append(r.comments[:i], r.comments[i+1:]...)
+			return "Comment Deleted Successfully!", nil
+		}
+	}
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Comment Found by ID %v, method DeleteComment", commentId))
+	return "", apperror.New(errors.New(fmt.Sprintf("No comment found by ID %v", commentId)),
+		apperror.NotFound,
+		"No Comment Found")
+}
+
+//Method that removes the comments that match the condition, it is used to delete the comments of a deleted question or answer
+func (r *repository) deleteComments(match func(comment domain.Comment) bool) {
+	comments := []domain.Comment{}
+	for _, comment := range r.comments {
+		if !match(comment) {
+			comments = append(comments, comment)
+		}
+	}
+	r.comments = comments
+}
+
+func (r *repository) FindRevisions(ctx context.Context, questionId string) ([]domain.Revision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	revisions := []domain.Revision{}
+	if _, err := r.findByID(ctx, questionId); err != nil {
+		return revisions, err
+	}
+	for _, revision := range r.revisions {
+		if revision.QuestionID == questionId {
+			revisions = append(revisions, revision)
+		}
+	}
+	return revisions, nil
+}
+
+func (r *repository) FindRevision(ctx context.Context, questionId string, number int64) (domain.Revision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, revision := range r.revisions {
+		if revision.QuestionID == questionId && revision.Number == number {
+			return revision, nil
+		}
+	}
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No Revision %v Found for Question %v, method FindRevision", number, questionId))
+	return domain.Revision{}, apperror.New(errors.New(fmt.Sprintf("No revision %v found for question %v", number, questionId)),
+		apperror.NotFound,
+		"No Revision Found")
+}
+
+func (r *repository) RestoreRevision(ctx context.Context, revision domain.Revision, userId string) (domain.QuestionInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, questionInfo := range r.db {
+		if questionInfo.Question.ID != revision.QuestionID {
+			continue
+		}
+		text, found := r.db[i].Text(revision.TargetType, revision.TargetID)
+		if !found {
+			break
+		}
+		if text != revision.Text {
+			r.recordRevision(i, revision.TargetType, revision.TargetID, text, userId)
+			r.db[i].SetText(revision.TargetType, revision.TargetID, revision.Text)
+		}
+		return r.db[i], nil
+	}
+	notFound := "No Question Found"
+	if revision.TargetType == domain.TargetAnswer {
+		notFound = "No Answer Found"
+	}
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("%v by ID %v, method RestoreRevision", notFound, revision.TargetID))
+	return domain.QuestionInfo{}, apperror.New(errors.New(fmt.Sprintf("No %v found by ID %v", revision.TargetType, revision.TargetID)),
+		apperror.NotFound,
+		notFound)
+}
+
+func (r *repository) FindRoles(ctx context.Context, userId string) (domain.UserRoles, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.findRoles(userId), nil
+}
+
+func (r *repository) findRoles(userId string) domain.UserRoles {
+	roles := append([]string{}, r.roles[userId]...)
+	return domain.UserRoles{UserID: userId, Roles: roles}
+}
+
+func (r *repository) GrantRole(ctx context.Context, userId string, role string) (domain.UserRoles, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, granted := range r.roles[userId] {
+		if granted == role {
+			return r.findRoles(userId), nil
+		}
+	}
+	r.roles[userId] = append(r.roles[userId], role)
+	return r.findRoles(userId), nil
+}
+
+func (r *repository) RevokeRole(ctx context.Context, userId string, role string) (domain.UserRoles, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, granted := range r.roles[userId] {
+		if granted == role {
+			r.roles[userId] = append(r.roles[userId][:i:i], r.roles[userId][i+1:]...)
+			return r.findRoles(userId), nil
+		}
+	}
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("The user %v does not have the role %v, method RevokeRole", userId, role))
+	return domain.UserRoles{}, apperror.New(errors.New(fmt.Sprintf("The user %v does not have the role %v", userId, role)),
+		apperror.NotFound,
+		"The User Does Not Have The Role")
+}
+
+func (r *repository) CreateAPIKey(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := apiKey
+	stored.Key = ""
+	r.apiKeys = append(r.apiKeys, stored)
+	return apiKey, nil
+}
+
+func (r *repository) FindAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]domain.APIKey{}, r.apiKeys...), nil
+}
+
+func (r *repository) FindAPIKeyByHash(ctx context.Context, hash string) (domain.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, apiKey := range r.apiKeys {
+		if apiKey.Hash == hash && apiKey.RevokedOn == 0 {
+			return apiKey, nil
+		}
+	}
+	return domain.APIKey{}, apperror.New(errors.New("No active API key found by hash"),
+		apperror.Unauthenticated,
+		"Invalid API Key")
+}
+
+func (r *repository) RevokeAPIKey(ctx context.Context, id string, revokedOn int64) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, apiKey := range r.apiKeys {
+		if apiKey.ID == id && apiKey.RevokedOn == 0 {
+			r.apiKeys[i].RevokedOn = revokedOn
+			return "API Key Revoked Successfully", nil
+		}
+	}
+	level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No active API key found by ID %v, method RevokeAPIKey", id))
+	return "", apperror.New(errors.New(fmt.Sprintf("No active API key found by ID %v", id)),
+		apperror.NotFound,
+		"No API Key Found")
+}
+
+func (r *repository) TouchAPIKey(ctx context.Context, id string, usedOn int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, apiKey := range r.apiKeys {
+		if apiKey.ID == id {
+			r.apiKeys[i].LastUsedOn = usedOn
+		}
+	}
+	return nil
+}
+
+//The in memory database is always available
+func (r *repository) Ping(ctx context.Context) error {
+	return nil
+}
+
+//The in memory database has no connection to close
+func (r *repository) Close(ctx context.Context) error {
+	return nil
+}
+
+//Method that records the previous text of an edited question or answer as a new revision of the question at the given index
+func (r *repository) recordRevision(index int, targetType string, targetId string, previousText string, userId string) {
+	r.db[index].RevisionCount++
+	r.revisions = append(r.revisions, domain.Revision{
+		QuestionID: r.db[index].Question.ID,
+		Number:     r.db[index].RevisionCount,
+		TargetType: targetType,
+		TargetID:   targetId,
+		Text:       previousText,
+		UserID:     userId,
+		CreatedOn:  time.Now().Unix(),
+	})
+}
+
+//Method that checks if a question matches the filters of a listing
+func matchesFilter(questionInfo domain.QuestionInfo, filter domain.QuestionFilter) bool {
+	question := questionInfo.Question
+	if filter.Unresolved && questionInfo.AcceptedAnswerID != "" {
+		return false
+	}
+	if filter.Answered != nil && *filter.Answered != (len(questionInfo.Answers) > 0) {
+		return false
+	}
+	if filter.AuthorID != "" && question.UserID != filter.AuthorID {
+		return false
+	}
+	if filter.CreatedFrom > 0 && question.CreatedOn < filter.CreatedFrom {
+		return false
+	}
+	if filter.CreatedTo > 0 && question.CreatedOn > filter.CreatedTo {
+		return false
+	}
+	if filter.AnswererID != "" {
+		for _, answer := range questionInfo.Answers {
+			if answer.UserID == filter.AnswererID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+//Method that sorts the questions in the order of the page and returns the ones placed after its cursor
+func paginate(questions []domain.QuestionInfo, page domain.Page) (domain.QuestionPage, error) {
+	page.Normalize()
+	position, err := repo.DecodeCursor(page.Cursor, page.Sort)
+	if err != nil {
+		return domain.QuestionPage{Questions: []domain.QuestionInfo{}}, err
+	}
+
+	sort.SliceStable(questions, func(i, j int) bool {
+		return repo.Precedes(questions[i].Question, questions[j].Question, page.Sort)
+	})
+
+	result := domain.QuestionPage{Questions: []domain.QuestionInfo{}}
+	for _, questionInfo := range questions {
+		if !position.Before(questionInfo.Question) {
+			continue
+		}
+		if int64(len(result.Questions)) == page.Limit {
+			result.NextCursor = repo.EncodeCursor(result.Questions[len(result.Questions)-1].Question, page.Sort)
+			break
+		}
+		result.Questions = append(result.Questions, questionInfo)
+	}
+	return result, nil
+}
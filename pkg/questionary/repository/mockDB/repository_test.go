@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/go-kit/kit/log"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/repository"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/repository/mockDB"
@@ -79,14 +81,25 @@ var (
 		},
 	}
 
-	addAnswerDataQuestionAnsweredConflict = []testBody{
+	addAnswerDataAnsweredQuestion = []testBody{
 		{
-			value:    domain.Answer{ID: "w0212", QuestionID: "1"},
-			expected: "The question already has an answer!",
+			value:    domain.Answer{ID: "w0212", Answer: "Of course!", UserID: "3", QuestionID: "1"},
+			expected: "w0212",
 		},
 		{
-			value:    domain.Answer{ID: "e312", QuestionID: "3"},
-			expected: "The question already has an answer!",
+			value:    domain.Answer{ID: "e312", Answer: "A typed conduit", UserID: "4", QuestionID: "3"},
+			expected: "e312",
+		},
+	}
+
+	addAnswerDataQuestionNotFound = []testBody{
+		{
+			value:    domain.Answer{ID: "w0213", QuestionID: "1212"},
+			expected: "No Question Found",
+		},
+		{
+			value:    domain.Answer{ID: "e313", QuestionID: "3212"},
+			expected: "No Question Found",
 		},
 	}
 
@@ -186,8 +199,40 @@ var (
 		{value: "333", expected: "No Question Found"},
 		{value: "222", expected: "No Question Found"},
 	}
+
+	updateAnswerDataSuccess = []testBody{
+		{
+			value:    domain.Answer{ID: "1", Answer: "Yes! Always!", UserID: "2", QuestionID: "1"},
+			expected: "Yes! Always!",
+		},
+	}
+
+	updateAnswerDataNotFound = []testBody{
+		{
+			value:    domain.Answer{ID: "331", Answer: "Yes! Always!", UserID: "2", QuestionID: "1"},
+			expected: "No Answer Found",
+		},
+		{
+			value:    domain.Answer{ID: "1", Answer: "Yes! Always!", UserID: "2", QuestionID: "3"},
+			expected: "No Answer Found",
+		},
+	}
+
+	deleteAnswerDataNotFound = []testBody{
+		{value: domain.Answer{ID: "331", QuestionID: "1"}, expected: "No Answer Found"},
+		{value: domain.Answer{ID: "1", QuestionID: "3"}, expected: "No Answer Found"},
+	}
 )
 
+func findAnswer(questionInfo domain.QuestionInfo, id string) domain.Answer {
+	for _, answer := range questionInfo.Answers {
+		if answer.ID == id {
+			return answer
+		}
+	}
+	return domain.Answer{}
+}
+
 func TestFindByID_Success(t *testing.T) {
 	repo := NewMockRepository(logger)
 	for _, data := range findByIDDataSuccess {
@@ -216,14 +261,134 @@ func TestFindByUser_Success(t *testing.T) {
 	repo := NewMockRepository(logger)
 	for _, data := range findByUserDataSuccess {
 		userID := fmt.Sprintf("%v", data.value)
-		questions, err := repo.FindByUser(ctx, userID)
+		page, err := repo.FindByUser(ctx, userID, domain.Page{Limit: domain.DefaultPageLimit})
 		if err != nil {
 			t.Error(err.Error())
 		}
-		assert.Equal(t, data.expected, len(questions))
+		assert.Equal(t, data.expected, len(page.Questions))
+	}
+}
+
+func TestFindAll_Pagination(t *testing.T) {
+	repo := NewMockRepository(logger)
+	all, err := repo.FindAll(ctx, domain.QuestionFilter{}, domain.Page{Limit: domain.MaxPageLimit})
+	if err != nil {
+		t.Error(err)
+	}
+
+	seen := make([]string, 0, len(all.Questions))
+	page := domain.Page{Limit: 1}
+	for {
+		result, err := repo.FindAll(ctx, domain.QuestionFilter{}, page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, info := range result.Questions {
+			seen = append(seen, info.Question.ID)
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		page.Cursor = result.NextCursor
+	}
+
+	assert.Equal(t, len(all.Questions), len(seen))
+	for i, info := range all.Questions {
+		assert.Equal(t, info.Question.ID, seen[i])
+	}
+}
+
+func TestFindAll_Filters(t *testing.T) {
+	repo := NewMockRepository(logger)
+	page := domain.Page{Limit: domain.MaxPageLimit}
+	answered, unanswered := true, false
+
+	result, err := repo.FindAll(ctx, domain.QuestionFilter{AuthorID: "1", Answered: &answered}, page)
+	if err != nil {
+		t.Error(err)
+	}
+	for _, info := range result.Questions {
+		assert.Equal(t, "1", info.Question.UserID)
+		assert.NotEmpty(t, info.Answers)
+	}
+
+	result, err = repo.FindAll(ctx, domain.QuestionFilter{Answered: &unanswered}, page)
+	if err != nil {
+		t.Error(err)
+	}
+	for _, info := range result.Questions {
+		assert.Empty(t, info.Answers)
+	}
+
+	result, err = repo.FindAll(ctx, domain.QuestionFilter{AnswererID: "1"}, page)
+	if err != nil {
+		t.Error(err)
+	}
+	for _, info := range result.Questions {
+		found := false
+		for _, answer := range info.Answers {
+			found = found || answer.UserID == "1"
+		}
+		assert.True(t, found)
+	}
+
+	result, err = repo.FindAll(ctx, domain.QuestionFilter{CreatedTo: 1}, page)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, 0, len(result.Questions))
+}
+
+func TestFindAll_Sort(t *testing.T) {
+	repo := NewMockRepository(logger)
+	for _, sortOrder := range []string{domain.SortNewest, domain.SortScore} {
+		page := domain.Page{Limit: 1, Sort: sortOrder}
+		seen := []domain.Question{}
+		for {
+			result, err := repo.FindAll(ctx, domain.QuestionFilter{}, page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, info := range result.Questions {
+				seen = append(seen, info.Question)
+			}
+			if result.NextCursor == "" {
+				break
+			}
+			page.Cursor = result.NextCursor
+		}
+		for i := 1; i < len(seen); i++ {
+			if sortOrder == domain.SortNewest {
+				assert.GreaterOrEqual(t, seen[i-1].CreatedOn, seen[i].CreatedOn)
+			} else {
+				assert.GreaterOrEqual(t, seen[i-1].Score, seen[i].Score)
+			}
+		}
 	}
 }
 
+func TestFindAll_CursorOfAnotherSort(t *testing.T) {
+	repo := NewMockRepository(logger)
+	result, err := repo.FindAll(ctx, domain.QuestionFilter{}, domain.Page{Limit: 1, Sort: domain.SortNewest})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = repo.FindAll(ctx, domain.QuestionFilter{}, domain.Page{Limit: 1, Sort: domain.SortScore, Cursor: result.NextCursor})
+	if err == nil {
+		t.Fatal("Error expected for a cursor of another sort order")
+	}
+	assert.Equal(t, "The cursor is not valid", err.Error())
+}
+
+func TestFindAll_InvalidCursor(t *testing.T) {
+	repo := NewMockRepository(logger)
+	_, err := repo.FindAll(ctx, domain.QuestionFilter{}, domain.Page{Limit: 1, Cursor: "not-a-cursor"})
+	if err == nil {
+		t.Fatal("Error expected for an invalid cursor")
+	}
+	assert.Equal(t, "The cursor is not valid", err.Error())
+}
+
 func TestCreateQuestion_Success(t *testing.T) {
 	repo := NewMockRepository(logger)
 	for _, data := range createQuestionDataSuccess {
@@ -253,13 +418,31 @@ func TestAddAnswer_Success(t *testing.T) {
 		if err != nil {
 			t.Error(err)
 		}
-		assert.Equal(t, data.expected, createdAnswer.Answer.ID)
+		assert.Equal(t, data.expected, createdAnswer.Answers[len(createdAnswer.Answers)-1].ID)
+	}
+}
+
+func TestAddAnswer_AnsweredQuestion(t *testing.T) {
+	repo := NewMockRepository(logger)
+	for _, data := range addAnswerDataAnsweredQuestion {
+		answer := data.value.(domain.Answer)
+		answers, err := repo.FindAnswers(ctx, answer.QuestionID)
+		if err != nil {
+			t.Error(err)
+		}
+
+		updatedInfo, err := repo.AddAnswer(ctx, answer)
+		if err != nil {
+			t.Error(err)
+		}
+		assert.Equal(t, len(answers)+1, len(updatedInfo.Answers))
+		assert.Equal(t, data.expected, findAnswer(updatedInfo, answer.ID).ID)
 	}
 }
 
-func TestAddAnswer_QuestionAnsweredConflict(t *testing.T) {
+func TestAddAnswer_QuestionNotFound(t *testing.T) {
 	repo := NewMockRepository(logger)
-	for _, data := range addAnswerDataQuestionAnsweredConflict {
+	for _, data := range addAnswerDataQuestionNotFound {
 		_, err := repo.AddAnswer(ctx, data.value.(domain.Answer))
 		if err == nil {
 			t.Errorf("Error = [%v] expected", data.expected)
@@ -287,11 +470,52 @@ func TestUpdateQuestionInfo_Success(t *testing.T) {
 			if err != nil {
 				t.Error(err)
 			}
-			assert.Equal(t, data.expected, updatedInfo.Answer.Answer)
+			assert.Equal(t, data.expected, findAnswer(updatedInfo, data.value.(domain.QuestionInfo).Answer.ID).Answer)
 		}
 	})
 }
 
+func TestUpdateQuestion_WithoutAnswers(t *testing.T) {
+	repo := NewMockRepository(logger)
+	question := domain.Question{ID: "u100", Statement: "Can I edit an unanswered question?", UserID: "5"}
+	_, err := repo.Create(ctx, question)
+	if err != nil {
+		t.Error(err)
+	}
+
+	question.Statement = "Can I edit a question without answers?"
+	updatedInfo, err := repo.Update(ctx, domain.QuestionInfo{Question: question})
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, question.Statement, updatedInfo.Question.Statement)
+
+	revisions, err := repo.FindRevisions(ctx, question.ID)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, 1, len(revisions))
+}
+
+//An update that does not change the question nor its answer is rejected like in MongoDB
+func TestUpdateQuestionInfo_NoModifications(t *testing.T) {
+	repo := NewMockRepository(logger)
+	questionInfo, err := repo.FindByID(ctx, "1")
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = repo.Update(ctx, domain.QuestionInfo{Question: questionInfo.Question, Answer: questionInfo.Answers[0]})
+	assert.Equal(t, apperror.Invalid, apperror.KindOf(err))
+	assert.Equal(t, "The Question/Answer Has No Modifications", err.Error())
+
+	revisions, err := repo.FindRevisions(ctx, "1")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Empty(t, revisions)
+}
+
 func TestUpdateQuestionInfo_NotFound(t *testing.T) {
 	repo := NewMockRepository(logger)
 	for _, data := range updateQuestionInfoNotFound {
@@ -307,7 +531,7 @@ func TestDeleteQuestion_Success(t *testing.T) {
 	repo := NewMockRepository(logger)
 	for _, data := range deleteQuestionSuccess {
 		id := fmt.Sprintf("%v", data.value)
-		msg, err := repo.Delete(ctx, id)
+		msg, err := repo.Delete(ctx, id, "1")
 		if err != nil {
 			t.Error(err)
 		}
@@ -319,10 +543,594 @@ func TestDeleteQuestion_NotFound(t *testing.T) {
 	repo := NewMockRepository(logger)
 	for _, data := range deleteQuestionNotFound {
 		id := fmt.Sprintf("%v", data.value)
-		_, err := repo.Delete(ctx, id)
+		_, err := repo.Delete(ctx, id, "1")
+		if err == nil {
+			t.Errorf("Error = [%v] expected", data.expected)
+		}
+		assert.Equal(t, data.expected, err.Error())
+	}
+}
+
+func TestUpdateAnswer_Success(t *testing.T) {
+	repo := NewMockRepository(logger)
+	for _, data := range updateAnswerDataSuccess {
+		updatedAnswer, err := repo.UpdateAnswer(ctx, data.value.(domain.Answer))
+		if err != nil {
+			t.Error(err)
+		}
+		assert.Equal(t, data.expected, updatedAnswer.Answer)
+	}
+}
+
+func TestUpdateAnswer_NotFound(t *testing.T) {
+	repo := NewMockRepository(logger)
+	for _, data := range updateAnswerDataNotFound {
+		_, err := repo.UpdateAnswer(ctx, data.value.(domain.Answer))
+		if err == nil {
+			t.Errorf("Error = [%v] expected", data.expected)
+		}
+		assert.Equal(t, data.expected, err.Error())
+	}
+}
+
+func TestDeleteAnswer_Success(t *testing.T) {
+	repo := NewMockRepository(logger)
+	answer := domain.Answer{ID: "d1212", Answer: "To be deleted", UserID: "5", QuestionID: "1"}
+	_, err := repo.AddAnswer(ctx, answer)
+	if err != nil {
+		t.Error(err)
+	}
+
+	msg, err := repo.DeleteAnswer(ctx, answer.QuestionID, answer.ID)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "Answer Deleted Successfully!", msg)
+
+	answers, err := repo.FindAnswers(ctx, answer.QuestionID)
+	if err != nil {
+		t.Error(err)
+	}
+	for _, remaining := range answers {
+		assert.NotEqual(t, answer.ID, remaining.ID)
+	}
+}
+
+func TestDeleteAnswer_NotFound(t *testing.T) {
+	repo := NewMockRepository(logger)
+	for _, data := range deleteAnswerDataNotFound {
+		answer := data.value.(domain.Answer)
+		_, err := repo.DeleteAnswer(ctx, answer.QuestionID, answer.ID)
+		if err == nil {
+			t.Errorf("Error = [%v] expected", data.expected)
+		}
+		assert.Equal(t, data.expected, err.Error())
+	}
+}
+
+func TestAcceptAnswer_Success(t *testing.T) {
+	repo := NewMockRepository(logger)
+	questionInfo, err := repo.AcceptAnswer(ctx, "3", "2")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "2", questionInfo.AcceptedAnswerID)
+
+	unresolved, err := repo.FindAll(ctx, domain.QuestionFilter{Unresolved: true}, domain.Page{Limit: domain.MaxPageLimit})
+	if err != nil {
+		t.Error(err)
+	}
+	for _, info := range unresolved.Questions {
+		assert.NotEqual(t, "3", info.Question.ID)
+	}
+
+	questionInfo, err = repo.UnacceptAnswer(ctx, "3")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "", questionInfo.AcceptedAnswerID)
+}
+
+func TestAcceptAnswer_NotFound(t *testing.T) {
+	repo := NewMockRepository(logger)
+	for _, data := range deleteAnswerDataNotFound {
+		answer := data.value.(domain.Answer)
+		_, err := repo.AcceptAnswer(ctx, answer.QuestionID, answer.ID)
+		if err == nil {
+			t.Errorf("Error = [%v] expected", data.expected)
+		}
+		assert.Equal(t, data.expected, err.Error())
+	}
+}
+
+func TestDeleteAnswer_RemovesAcceptedAnswer(t *testing.T) {
+	repo := NewMockRepository(logger)
+	answer := domain.Answer{ID: "a1212", Answer: "To be accepted", UserID: "5", QuestionID: "1"}
+	_, err := repo.AddAnswer(ctx, answer)
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = repo.AcceptAnswer(ctx, answer.QuestionID, answer.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = repo.DeleteAnswer(ctx, answer.QuestionID, answer.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	questionInfo, err := repo.FindByID(ctx, answer.QuestionID)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "", questionInfo.AcceptedAnswerID)
+}
+
+func TestVote_OneVotePerUser(t *testing.T) {
+	repo := NewMockRepository(logger)
+	answer := domain.Answer{ID: "a3434", Answer: "To be voted", UserID: "5", QuestionID: "2"}
+	_, err := repo.AddAnswer(ctx, answer)
+	if err != nil {
+		t.Error(err)
+	}
+
+	votes := []testBody{
+		{value: domain.Vote{TargetType: domain.TargetAnswer, TargetID: answer.ID, UserID: "1", Value: 1}, expected: int64(1)},
+		{value: domain.Vote{TargetType: domain.TargetAnswer, TargetID: answer.ID, UserID: "1", Value: 1}, expected: int64(1)},
+		{value: domain.Vote{TargetType: domain.TargetAnswer, TargetID: answer.ID, UserID: "2", Value: 1}, expected: int64(2)},
+		{value: domain.Vote{TargetType: domain.TargetAnswer, TargetID: answer.ID, UserID: "1", Value: -1}, expected: int64(0)},
+	}
+	for _, data := range votes {
+		score, err := repo.Vote(ctx, data.value.(domain.Vote))
+		if err != nil {
+			t.Error(err)
+		}
+		assert.Equal(t, data.expected, score.Score)
+	}
+
+	score, err := repo.RetractVote(ctx, domain.TargetAnswer, answer.ID, "1")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, int64(1), score.Score)
+
+	questionInfo, err := repo.FindByID(ctx, answer.QuestionID)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, int64(1), findAnswer(questionInfo, answer.ID).Score)
+}
+
+//Every repository starts from the seed, the changes of another repository are not seen
+func TestNewRepository_IsolatedSeed(t *testing.T) {
+	repo := NewMockRepository(logger)
+	_, err := repo.AddAnswer(ctx, domain.Answer{ID: "s100", Answer: "Only here", UserID: "5", QuestionID: "2"})
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = repo.Vote(ctx, domain.Vote{TargetType: domain.TargetAnswer, TargetID: "1", UserID: "5", Value: 1})
+	if err != nil {
+		t.Error(err)
+	}
+
+	questionInfo, err := NewMockRepository(logger).FindByID(ctx, "1")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, int64(0), findAnswer(questionInfo, "1").Score)
+
+	questionInfo, err = NewMockRepository(logger).FindByID(ctx, "2")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Empty(t, questionInfo.Answers)
+}
+
+//The votes of a deleted answer or a purged question are removed, so a new target with the same ID starts without votes
+func TestVote_RemovedWithTarget(t *testing.T) {
+	repo := NewMockRepository(logger)
+	question := domain.Question{ID: "v100", Statement: "Are my votes removed?", UserID: "5"}
+	answer := domain.Answer{ID: "v101", Answer: "They should be", UserID: "6", QuestionID: question.ID}
+	votes := []domain.Vote{
+		{TargetType: domain.TargetQuestion, TargetID: question.ID, UserID: "1", Value: 1},
+		{TargetType: domain.TargetAnswer, TargetID: answer.ID, UserID: "1", Value: 1},
+	}
+	create := func() {
+		_, err := repo.Create(ctx, question)
+		assert.Nil(t, err)
+		_, err = repo.AddAnswer(ctx, answer)
+		assert.Nil(t, err)
+		for _, vote := range votes {
+			score, err := repo.Vote(ctx, vote)
+			assert.Nil(t, err)
+			assert.Equal(t, int64(1), score.Score)
+		}
+	}
+
+	create()
+	_, err := repo.DeleteAnswer(ctx, question.ID, answer.ID)
+	assert.Nil(t, err)
+	_, err = repo.AddAnswer(ctx, answer)
+	assert.Nil(t, err)
+	score, err := repo.Vote(ctx, votes[1])
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), score.Score)
+
+	_, err = repo.Delete(ctx, question.ID, question.UserID)
+	assert.Nil(t, err)
+	_, err = repo.Purge(ctx, time.Now().Add(time.Hour).Unix())
+	assert.Nil(t, err)
+	create()
+}
+
+func TestVote_Concurrent(t *testing.T) {
+	repo := NewMockRepository(logger)
+	question := domain.Question{ID: "v200", Statement: "Can everybody vote at once?", UserID: "5"}
+	_, err := repo.Create(ctx, question)
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(userId string) {
+			defer wg.Done()
+			_, err := repo.Vote(ctx, domain.Vote{TargetType: domain.TargetQuestion, TargetID: question.ID, UserID: userId, Value: 1})
+			assert.Nil(t, err)
+		}(fmt.Sprint(i))
+	}
+	wg.Wait()
+
+	questionInfo, err := repo.FindByID(ctx, question.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(50), questionInfo.Question.Score)
+}
+
+func TestVote_NotFound(t *testing.T) {
+	repo := NewMockRepository(logger)
+	votes := []testBody{
+		{value: domain.Vote{TargetType: domain.TargetQuestion, TargetID: "333", UserID: "1", Value: 1}, expected: "No Question Found"},
+		{value: domain.Vote{TargetType: domain.TargetAnswer, TargetID: "333", UserID: "1", Value: 1}, expected: "No Answer Found"},
+	}
+	for _, data := range votes {
+		_, err := repo.Vote(ctx, data.value.(domain.Vote))
+		if err == nil {
+			t.Errorf("Error = [%v] expected", data.expected)
+		}
+		assert.Equal(t, data.expected, err.Error())
+	}
+}
+
+func TestRetractVote_NotFound(t *testing.T) {
+	repo := NewMockRepository(logger)
+	_, err := repo.RetractVote(ctx, domain.TargetQuestion, "1", "99")
+	if err == nil {
+		t.Errorf("Error = [%v] expected", "No Vote Found")
+	}
+	assert.Equal(t, "No Vote Found", err.Error())
+}
+
+func TestFindByTag_Success(t *testing.T) {
+	repo := NewMockRepository(logger)
+	questions, err := repo.FindByTag(ctx, "channels")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, 1, len(questions))
+	assert.Equal(t, "3", questions[0].Question.ID)
+
+	questions, err = repo.FindByTag(ctx, "rust")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, 0, len(questions))
+}
+
+func TestFindTags_Success(t *testing.T) {
+	repo := NewMockRepository(logger)
+	tags, err := repo.FindTags(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	counts := make(map[string]int64)
+	for _, tag := range tags {
+		counts[tag.Tag] = tag.Count
+	}
+	assert.Equal(t, "go", tags[0].Tag)
+	assert.Equal(t, counts["go"], tags[0].Count)
+	assert.Equal(t, int64(1), counts["channels"])
+}
+
+func TestSearch_Success(t *testing.T) {
+	repo := NewMockRepository(logger)
+	questions := []domain.Question{
+		{ID: "s1", Statement: "How do I close a buffered channel?", UserID: "1"},
+		{ID: "s2", Statement: "Is a buffered channel faster than an unbuffered channel?", UserID: "2"},
+		{ID: "s3", Statement: "Where are all the gophers?", UserID: "3"},
+	}
+	for _, question := range questions {
+		if _, err := repo.Create(ctx, question); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := repo.Search(ctx, "Buffered CHANNEL", domain.QuestionFilter{})
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, 2, len(results))
+	assert.Equal(t, "s1", results[0].QuestionInfo.Question.ID)
+	assert.Equal(t, "s2", results[1].QuestionInfo.Question.ID)
+	assert.Greater(t, results[0].Relevance, results[1].Relevance)
+}
+
+func TestSearch_NoMatches(t *testing.T) {
+	repo := NewMockRepository(logger)
+	results, err := repo.Search(ctx, "where is the python", domain.QuestionFilter{})
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, 0, len(results))
+}
+
+func TestComment_Lifecycle(t *testing.T) {
+	repo := NewMockRepository(logger)
+	comment := domain.Comment{ID: "c1", ParentType: domain.TargetAnswer, ParentID: "2", QuestionID: "3", Comment: "Can you add an example?", UserID: "4"}
+	_, err := repo.AddComment(ctx, comment)
+	if err != nil {
+		t.Error(err)
+	}
+
+	comments, err := repo.FindComments(ctx, domain.TargetAnswer, "2")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, 1, len(comments))
+
+	found, err := repo.FindComment(ctx, comment.QuestionID, comment.ID)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, comment.UserID, found.UserID)
+
+	comment.Comment = "Can you add a code example?"
+	updated, err := repo.UpdateComment(ctx, comment)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, comment.Comment, updated.Comment)
+
+	msg, err := repo.DeleteComment(ctx, comment.QuestionID, comment.ID)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "Comment Deleted Successfully!", msg)
+
+	_, err = repo.DeleteComment(ctx, comment.QuestionID, comment.ID)
+	if err == nil {
+		t.Errorf("Error = [%v] expected", "No Comment Found")
+	}
+	assert.Equal(t, "No Comment Found", err.Error())
+
+	_, err = repo.FindComment(ctx, comment.QuestionID, comment.ID)
+	if err == nil {
+		t.Errorf("Error = [%v] expected", "No Comment Found")
+	}
+}
+
+func TestAddComment_NotFound(t *testing.T) {
+	repo := NewMockRepository(logger)
+	comments := []testBody{
+		{value: domain.Comment{ID: "c2", ParentType: domain.TargetQuestion, ParentID: "333", QuestionID: "333", Comment: "Hi?", UserID: "4"}, expected: "No Question Found"},
+		{value: domain.Comment{ID: "c3", ParentType: domain.TargetAnswer, ParentID: "333", QuestionID: "1", Comment: "Hi?", UserID: "4"}, expected: "No Answer Found"},
+	}
+	for _, data := range comments {
+		_, err := repo.AddComment(ctx, data.value.(domain.Comment))
 		if err == nil {
 			t.Errorf("Error = [%v] expected", data.expected)
 		}
 		assert.Equal(t, data.expected, err.Error())
 	}
 }
+
+func TestDelete_CascadeComments(t *testing.T) {
+	repo := NewMockRepository(logger)
+	question := domain.Question{ID: "c999", Statement: "Will my comments be deleted?", UserID: "7"}
+	_, err := repo.Create(ctx, question)
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = repo.AddComment(ctx, domain.Comment{ID: "c4", ParentType: domain.TargetQuestion, ParentID: question.ID, QuestionID: question.ID, Comment: "Yes", UserID: "8"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = repo.Delete(ctx, question.ID, question.UserID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	comments, err := repo.FindComments(ctx, domain.TargetQuestion, question.ID)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, 0, len(comments))
+
+	purged, err := repo.Purge(ctx, time.Now().Add(time.Hour).Unix())
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, int64(1), purged)
+
+	_, err = repo.Restore(ctx, question.ID)
+	if err == nil {
+		t.Fatal("Error expected for a purged question")
+	}
+	assert.Equal(t, "No Deleted Question Found", err.Error())
+}
+
+func TestDelete_Restore(t *testing.T) {
+	repo := NewMockRepository(logger)
+	question := domain.Question{ID: "d100", Statement: "Can I undo a delete?", UserID: "5"}
+	_, err := repo.Create(ctx, question)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = repo.AddComment(ctx, domain.Comment{ID: "d101", ParentType: domain.TargetQuestion, ParentID: question.ID, QuestionID: question.ID, Comment: "Why?", UserID: "6"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = repo.Delete(ctx, question.ID, "5")
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = repo.FindByID(ctx, question.ID)
+	assert.NotNil(t, err)
+	page, err := repo.FindByUser(ctx, "5", domain.Page{Limit: domain.MaxPageLimit})
+	if err != nil {
+		t.Error(err)
+	}
+	for _, info := range page.Questions {
+		assert.NotEqual(t, question.ID, info.Question.ID)
+	}
+
+	purged, err := repo.Purge(ctx, time.Now().Add(-time.Hour).Unix())
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, int64(0), purged)
+
+	restored, err := repo.Restore(ctx, question.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(0), restored.DeletedOn)
+	assert.Equal(t, "", restored.DeletedBy)
+
+	_, err = repo.FindByID(ctx, question.ID)
+	assert.Nil(t, err)
+	comments, err := repo.FindComments(ctx, domain.TargetQuestion, question.ID)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, 1, len(comments))
+}
+
+func TestRevisions_RecordAndRestore(t *testing.T) {
+	repo := NewMockRepository(logger)
+	question := domain.Question{ID: "r100", Statement: "What is an interface?", UserID: "1"}
+	answer := domain.Answer{ID: "r101", Answer: "A set of methods", QuestionID: question.ID, UserID: "2"}
+	_, err := repo.Create(ctx, question)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = repo.AddAnswer(ctx, answer)
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = repo.UpdateAnswer(ctx, domain.Answer{ID: answer.ID, QuestionID: question.ID, Answer: "A set of method signatures", UserID: "3"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	revisions, err := repo.FindRevisions(ctx, question.ID)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, 1, len(revisions))
+	assert.Equal(t, int64(1), revisions[0].Number)
+	assert.Equal(t, answer.Answer, revisions[0].Text)
+	assert.Equal(t, "3", revisions[0].UserID)
+
+	questionInfo, err := repo.RestoreRevision(ctx, revisions[0], "2")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, answer.Answer, findAnswer(questionInfo, answer.ID).Answer)
+	assert.Equal(t, int64(2), questionInfo.RevisionCount)
+
+	revision, err := repo.FindRevision(ctx, question.ID, 2)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "A set of method signatures", revision.Text)
+	assert.Equal(t, "2", revision.UserID)
+}
+
+func TestFindRevision_NotFound(t *testing.T) {
+	repo := NewMockRepository(logger)
+	_, err := repo.FindRevision(ctx, "1", 999)
+	if err == nil {
+		t.Errorf("Error = [%v] expected", "No Revision Found")
+	}
+	assert.Equal(t, "No Revision Found", err.Error())
+}
+
+func TestRoles_GrantAndRevoke(t *testing.T) {
+	repo := NewMockRepository(logger)
+	roles, err := repo.FindRoles(ctx, "7")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, 0, len(roles.Roles))
+
+	repo.GrantRole(ctx, "7", domain.RoleModerator)
+	roles, err = repo.GrantRole(ctx, "7", domain.RoleModerator)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, []string{domain.RoleModerator}, roles.Roles)
+
+	roles, err = repo.RevokeRole(ctx, "7", domain.RoleModerator)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, 0, len(roles.Roles))
+
+	_, err = repo.RevokeRole(ctx, "7", domain.RoleModerator)
+	if err == nil {
+		t.Errorf("Error = [%v] expected", "The User Does Not Have The Role")
+	}
+	assert.Equal(t, "The User Does Not Have The Role", err.Error())
+}
+
+func TestAPIKeys_Revoke(t *testing.T) {
+	repo := NewMockRepository(logger)
+	apiKey := domain.APIKey{ID: "k1", Name: "job", UserID: "job", Scopes: []string{domain.ScopeRead}, Key: "qak_secret", Hash: "hash-1"}
+	created, err := repo.CreateAPIKey(ctx, apiKey)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "qak_secret", created.Key)
+
+	apiKeys, _ := repo.FindAPIKeys(ctx)
+	assert.Equal(t, 1, len(apiKeys))
+	assert.Empty(t, apiKeys[0].Key)
+
+	found, err := repo.FindAPIKeyByHash(ctx, "hash-1")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "k1", found.ID)
+
+	repo.TouchAPIKey(ctx, "k1", 1700000000)
+	found, _ = repo.FindAPIKeyByHash(ctx, "hash-1")
+	assert.Equal(t, int64(1700000000), found.LastUsedOn)
+
+	_, err = repo.RevokeAPIKey(ctx, "k1", 1700000100)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = repo.FindAPIKeyByHash(ctx, "hash-1")
+	if err == nil {
+		t.Errorf("Error = [%v] expected", "Invalid API Key")
+	}
+	assert.Equal(t, "Invalid API Key", err.Error())
+
+	_, err = repo.RevokeAPIKey(ctx, "k1", 1700000200)
+	assert.Equal(t, "No API Key Found", err.Error())
+}
@@ -0,0 +1,82 @@
+package mockDB
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+)
+
+//This is an in memory inverted index over the statement and the answers of the questions.
+//The terms and the scores follow the MongoDB text index, the words are compared in lowercase,
+//the english stop words are ignored and every field that contains a term of the query adds
+//(0.5 * occurrences / words of the field + 0.5) to the relevance of its question.
+type searchIndex struct {
+	postings map[string][]posting
+}
+
+//A field of a question that contains a term
+type posting struct {
+	question    int
+	occurrences int
+	words       int
+}
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"do": true, "does": true, "for": true, "from": true, "how": true, "i": true, "in": true, "is": true,
+	"it": true, "of": true, "on": true, "or": true, "that": true, "the": true, "this": true, "to": true,
+	"what": true, "when": true, "where": true, "which": true, "who": true, "why": true, "with": true, "you": true,
+}
+
+func newSearchIndex(questions []domain.QuestionInfo) *searchIndex {
+	index := &searchIndex{postings: make(map[string][]posting)}
+	for i, questionInfo := range questions {
+		index.add(i, questionInfo.Question.Statement)
+		for _, answer := range questionInfo.Answers {
+			index.add(i, answer.Answer)
+		}
+	}
+	return index
+}
+
+func (index *searchIndex) add(question int, text string) {
+	words := tokenize(text)
+	occurrences := make(map[string]int)
+	for _, word := range words {
+		occurrences[word]++
+	}
+	for term, count := range occurrences {
+		index.postings[term] = append(index.postings[term], posting{question: question, occurrences: count, words: len(words)})
+	}
+}
+
+//Method that returns the relevance of every indexed question that contains at least one term of the query
+func (index *searchIndex) search(query string) map[int]float64 {
+	scores := make(map[int]float64)
+	searched := make(map[string]bool)
+	for _, term := range tokenize(query) {
+		if searched[term] {
+			continue
+		}
+		searched[term] = true
+		for _, field := range index.postings[term] {
+			scores[field.question] += 0.5*float64(field.occurrences)/float64(field.words) + 0.5
+		}
+	}
+	return scores
+}
+
+//The text is split in lowercase words of letters and numbers without the stop words
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsNumber(c)
+	})
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		if !stopWords[word] {
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
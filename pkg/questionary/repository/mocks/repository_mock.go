@@ -35,6 +35,21 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AcceptAnswer mocks base method.
+func (m *MockRepository) AcceptAnswer(ctx context.Context, questionId, answerId string) (domain.QuestionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptAnswer", ctx, questionId, answerId)
+	ret0, _ := ret[0].(domain.QuestionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptAnswer indicates an expected call of AcceptAnswer.
+func (mr *MockRepositoryMockRecorder) AcceptAnswer(ctx, questionId, answerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptAnswer", reflect.TypeOf((*MockRepository)(nil).AcceptAnswer), ctx, questionId, answerId)
+}
+
 // AddAnswer mocks base method.
 func (m *MockRepository) AddAnswer(ctx context.Context, answer domain.Answer) (domain.QuestionInfo, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +65,35 @@ func (mr *MockRepositoryMockRecorder) AddAnswer(ctx, answer interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAnswer", reflect.TypeOf((*MockRepository)(nil).AddAnswer), ctx, answer)
 }
 
+// AddComment mocks base method.
+func (m *MockRepository) AddComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddComment", ctx, comment)
+	ret0, _ := ret[0].(domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddComment indicates an expected call of AddComment.
+func (mr *MockRepositoryMockRecorder) AddComment(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComment", reflect.TypeOf((*MockRepository)(nil).AddComment), ctx, comment)
+}
+
+// Close mocks base method.
+func (m *MockRepository) Close(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRepositoryMockRecorder) Close(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRepository)(nil).Close), ctx)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(ctx context.Context, question domain.Question) (domain.Question, error) {
 	m.ctrl.T.Helper()
@@ -65,34 +109,124 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, question interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, question)
 }
 
+// CreateAPIKey mocks base method.
+func (m *MockRepository) CreateAPIKey(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, apiKey)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockRepositoryMockRecorder) CreateAPIKey(ctx, apiKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockRepository)(nil).CreateAPIKey), ctx, apiKey)
+}
+
 // Delete mocks base method.
-func (m *MockRepository) Delete(ctx context.Context, id string) (string, error) {
+func (m *MockRepository) Delete(ctx context.Context, id, userId string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userId)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Delete(ctx, id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id, userId)
+}
+
+// DeleteAnswer mocks base method.
+func (m *MockRepository) DeleteAnswer(ctx context.Context, questionId, answerId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAnswer", ctx, questionId, answerId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAnswer indicates an expected call of DeleteAnswer.
+func (mr *MockRepositoryMockRecorder) DeleteAnswer(ctx, questionId, answerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAnswer", reflect.TypeOf((*MockRepository)(nil).DeleteAnswer), ctx, questionId, answerId)
+}
+
+// DeleteComment mocks base method.
+func (m *MockRepository) DeleteComment(ctx context.Context, questionId, commentId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, questionId, commentId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockRepositoryMockRecorder) DeleteComment(ctx, questionId, commentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockRepository)(nil).DeleteComment), ctx, questionId, commentId)
+}
+
+// FindAPIKeyByHash mocks base method.
+func (m *MockRepository) FindAPIKeyByHash(ctx context.Context, hash string) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAPIKeyByHash", ctx, hash)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAPIKeyByHash indicates an expected call of FindAPIKeyByHash.
+func (mr *MockRepositoryMockRecorder) FindAPIKeyByHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAPIKeyByHash", reflect.TypeOf((*MockRepository)(nil).FindAPIKeyByHash), ctx, hash)
+}
+
+// FindAPIKeys mocks base method.
+func (m *MockRepository) FindAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAPIKeys", ctx)
+	ret0, _ := ret[0].([]domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAPIKeys indicates an expected call of FindAPIKeys.
+func (mr *MockRepositoryMockRecorder) FindAPIKeys(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAPIKeys", reflect.TypeOf((*MockRepository)(nil).FindAPIKeys), ctx)
 }
 
 // FindAll mocks base method.
-func (m *MockRepository) FindAll(ctx context.Context) ([]domain.QuestionInfo, error) {
+func (m *MockRepository) FindAll(ctx context.Context, filter domain.QuestionFilter, page domain.Page) (domain.QuestionPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx)
-	ret0, _ := ret[0].([]domain.QuestionInfo)
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter, page)
+	ret0, _ := ret[0].(domain.QuestionPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockRepositoryMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) FindAll(ctx, filter, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRepository)(nil).FindAll), ctx, filter, page)
+}
+
+// FindAnswers mocks base method.
+func (m *MockRepository) FindAnswers(ctx context.Context, questionId string) ([]domain.Answer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAnswers", ctx, questionId)
+	ret0, _ := ret[0].([]domain.Answer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAnswers indicates an expected call of FindAnswers.
+func (mr *MockRepositoryMockRecorder) FindAnswers(ctx, questionId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRepository)(nil).FindAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAnswers", reflect.TypeOf((*MockRepository)(nil).FindAnswers), ctx, questionId)
 }
 
 // FindByID mocks base method.
@@ -110,19 +244,287 @@ func (mr *MockRepositoryMockRecorder) FindByID(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepository)(nil).FindByID), ctx, id)
 }
 
-// FindByUser mocks base method.
-func (m *MockRepository) FindByUser(ctx context.Context, userId string) ([]domain.QuestionInfo, error) {
+// FindByTag mocks base method.
+func (m *MockRepository) FindByTag(ctx context.Context, tag string) ([]domain.QuestionInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByUser", ctx, userId)
+	ret := m.ctrl.Call(m, "FindByTag", ctx, tag)
 	ret0, _ := ret[0].([]domain.QuestionInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// FindByTag indicates an expected call of FindByTag.
+func (mr *MockRepositoryMockRecorder) FindByTag(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTag", reflect.TypeOf((*MockRepository)(nil).FindByTag), ctx, tag)
+}
+
+// FindByUser mocks base method.
+func (m *MockRepository) FindByUser(ctx context.Context, userId string, page domain.Page) (domain.QuestionPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUser", ctx, userId, page)
+	ret0, _ := ret[0].(domain.QuestionPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // FindByUser indicates an expected call of FindByUser.
-func (mr *MockRepositoryMockRecorder) FindByUser(ctx, userId interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) FindByUser(ctx, userId, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUser", reflect.TypeOf((*MockRepository)(nil).FindByUser), ctx, userId, page)
+}
+
+// FindComments mocks base method.
+func (m *MockRepository) FindComments(ctx context.Context, parentType, parentId string) ([]domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindComments", ctx, parentType, parentId)
+	ret0, _ := ret[0].([]domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindComments indicates an expected call of FindComments.
+func (mr *MockRepositoryMockRecorder) FindComments(ctx, parentType, parentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindComments", reflect.TypeOf((*MockRepository)(nil).FindComments), ctx, parentType, parentId)
+}
+
+// FindComment mocks base method.
+func (m *MockRepository) FindComment(ctx context.Context, questionId, commentId string) (domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindComment", ctx, questionId, commentId)
+	ret0, _ := ret[0].(domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindComment indicates an expected call of FindComment.
+func (mr *MockRepositoryMockRecorder) FindComment(ctx, questionId, commentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindComment", reflect.TypeOf((*MockRepository)(nil).FindComment), ctx, questionId, commentId)
+}
+
+// FindRevision mocks base method.
+func (m *MockRepository) FindRevision(ctx context.Context, questionId string, number int64) (domain.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRevision", ctx, questionId, number)
+	ret0, _ := ret[0].(domain.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRevision indicates an expected call of FindRevision.
+func (mr *MockRepositoryMockRecorder) FindRevision(ctx, questionId, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRevision", reflect.TypeOf((*MockRepository)(nil).FindRevision), ctx, questionId, number)
+}
+
+// FindRevisions mocks base method.
+func (m *MockRepository) FindRevisions(ctx context.Context, questionId string) ([]domain.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRevisions", ctx, questionId)
+	ret0, _ := ret[0].([]domain.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRevisions indicates an expected call of FindRevisions.
+func (mr *MockRepositoryMockRecorder) FindRevisions(ctx, questionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRevisions", reflect.TypeOf((*MockRepository)(nil).FindRevisions), ctx, questionId)
+}
+
+// FindRoles mocks base method.
+func (m *MockRepository) FindRoles(ctx context.Context, userId string) (domain.UserRoles, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRoles", ctx, userId)
+	ret0, _ := ret[0].(domain.UserRoles)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRoles indicates an expected call of FindRoles.
+func (mr *MockRepositoryMockRecorder) FindRoles(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRoles", reflect.TypeOf((*MockRepository)(nil).FindRoles), ctx, userId)
+}
+
+// FindTags mocks base method.
+func (m *MockRepository) FindTags(ctx context.Context) ([]domain.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTags", ctx)
+	ret0, _ := ret[0].([]domain.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTags indicates an expected call of FindTags.
+func (mr *MockRepositoryMockRecorder) FindTags(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTags", reflect.TypeOf((*MockRepository)(nil).FindTags), ctx)
+}
+
+// GrantRole mocks base method.
+func (m *MockRepository) GrantRole(ctx context.Context, userId, role string) (domain.UserRoles, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRole", ctx, userId, role)
+	ret0, _ := ret[0].(domain.UserRoles)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantRole indicates an expected call of GrantRole.
+func (mr *MockRepositoryMockRecorder) GrantRole(ctx, userId, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRole", reflect.TypeOf((*MockRepository)(nil).GrantRole), ctx, userId, role)
+}
+
+// Ping mocks base method.
+func (m *MockRepository) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockRepositoryMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockRepository)(nil).Ping), ctx)
+}
+
+// Purge mocks base method.
+func (m *MockRepository) Purge(ctx context.Context, deletedBefore int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockRepositoryMockRecorder) Purge(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockRepository)(nil).Purge), ctx, deletedBefore)
+}
+
+// Restore mocks base method.
+func (m *MockRepository) Restore(ctx context.Context, id string) (domain.QuestionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(domain.QuestionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockRepositoryMockRecorder) Restore(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockRepository)(nil).Restore), ctx, id)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockRepository) RestoreRevision(ctx context.Context, revision domain.Revision, userId string) (domain.QuestionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", ctx, revision, userId)
+	ret0, _ := ret[0].(domain.QuestionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockRepositoryMockRecorder) RestoreRevision(ctx, revision, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockRepository)(nil).RestoreRevision), ctx, revision, userId)
+}
+
+// RetractVote mocks base method.
+func (m *MockRepository) RetractVote(ctx context.Context, targetType, targetId, userId string) (domain.Score, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetractVote", ctx, targetType, targetId, userId)
+	ret0, _ := ret[0].(domain.Score)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetractVote indicates an expected call of RetractVote.
+func (mr *MockRepositoryMockRecorder) RetractVote(ctx, targetType, targetId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetractVote", reflect.TypeOf((*MockRepository)(nil).RetractVote), ctx, targetType, targetId, userId)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockRepository) RevokeAPIKey(ctx context.Context, id string, revokedOn int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id, revokedOn)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockRepositoryMockRecorder) RevokeAPIKey(ctx, id, revokedOn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockRepository)(nil).RevokeAPIKey), ctx, id, revokedOn)
+}
+
+// RevokeRole mocks base method.
+func (m *MockRepository) RevokeRole(ctx context.Context, userId, role string) (domain.UserRoles, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRole", ctx, userId, role)
+	ret0, _ := ret[0].(domain.UserRoles)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockRepositoryMockRecorder) RevokeRole(ctx, userId, role interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUser", reflect.TypeOf((*MockRepository)(nil).FindByUser), ctx, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockRepository)(nil).RevokeRole), ctx, userId, role)
+}
+
+// Search mocks base method.
+func (m *MockRepository) Search(ctx context.Context, query string, filter domain.QuestionFilter) ([]domain.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, filter)
+	ret0, _ := ret[0].([]domain.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockRepositoryMockRecorder) Search(ctx, query, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockRepository)(nil).Search), ctx, query, filter)
+}
+
+// TouchAPIKey mocks base method.
+func (m *MockRepository) TouchAPIKey(ctx context.Context, id string, usedOn int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", ctx, id, usedOn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockRepositoryMockRecorder) TouchAPIKey(ctx, id, usedOn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockRepository)(nil).TouchAPIKey), ctx, id, usedOn)
+}
+
+// UnacceptAnswer mocks base method.
+func (m *MockRepository) UnacceptAnswer(ctx context.Context, questionId string) (domain.QuestionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnacceptAnswer", ctx, questionId)
+	ret0, _ := ret[0].(domain.QuestionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnacceptAnswer indicates an expected call of UnacceptAnswer.
+func (mr *MockRepositoryMockRecorder) UnacceptAnswer(ctx, questionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnacceptAnswer", reflect.TypeOf((*MockRepository)(nil).UnacceptAnswer), ctx, questionId)
 }
 
 // Update mocks base method.
@@ -139,3 +541,48 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, questionInfo interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, questionInfo)
 }
+
+// UpdateAnswer mocks base method.
+func (m *MockRepository) UpdateAnswer(ctx context.Context, answer domain.Answer) (domain.Answer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnswer", ctx, answer)
+	ret0, _ := ret[0].(domain.Answer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAnswer indicates an expected call of UpdateAnswer.
+func (mr *MockRepositoryMockRecorder) UpdateAnswer(ctx, answer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnswer", reflect.TypeOf((*MockRepository)(nil).UpdateAnswer), ctx, answer)
+}
+
+// UpdateComment mocks base method.
+func (m *MockRepository) UpdateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, comment)
+	ret0, _ := ret[0].(domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockRepositoryMockRecorder) UpdateComment(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockRepository)(nil).UpdateComment), ctx, comment)
+}
+
+// Vote mocks base method.
+func (m *MockRepository) Vote(ctx context.Context, vote domain.Vote) (domain.Score, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Vote", ctx, vote)
+	ret0, _ := ret[0].(domain.Score)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Vote indicates an expected call of Vote.
+func (mr *MockRepositoryMockRecorder) Vote(ctx, vote interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vote", reflect.TypeOf((*MockRepository)(nil).Vote), ctx, vote)
+}
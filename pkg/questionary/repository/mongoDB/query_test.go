@@ -0,0 +1,262 @@
+package mongoDB
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+	repo "github.com/ismaeljpv/qa-api/pkg/questionary/repository"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+//The answered condition matches the documents with answers in the list or in the legacy answer field
+var answeredQuery = bson.A{
+	bson.D{{Key: "answers.0", Value: bson.D{{Key: "$exists", Value: true}}}},
+	bson.D{{Key: "answer.id", Value: bson.D{{Key: "$nin", Value: bson.A{nil, ""}}}}},
+}
+
+func TestQuestionFilter(t *testing.T) {
+	answered, unanswered := true, false
+	filters := map[string]struct {
+		filter   domain.QuestionFilter
+		expected bson.D
+	}{
+		"Empty": {
+			filter:   domain.QuestionFilter{},
+			expected: bson.D{notDeleted},
+		},
+		"Unresolved": {
+			filter: domain.QuestionFilter{Unresolved: true},
+			expected: bson.D{notDeleted,
+				{Key: "acceptedanswerid", Value: bson.D{{Key: "$in", Value: bson.A{nil, ""}}}},
+			},
+		},
+		"Author": {
+			filter:   domain.QuestionFilter{AuthorID: "1"},
+			expected: bson.D{notDeleted, {Key: "question.userid", Value: "1"}},
+		},
+		"CreatedFrom": {
+			filter:   domain.QuestionFilter{CreatedFrom: 100},
+			expected: bson.D{notDeleted, {Key: "question.createdon", Value: bson.D{{Key: "$gte", Value: int64(100)}}}},
+		},
+		"CreatedTo": {
+			filter:   domain.QuestionFilter{CreatedTo: 200},
+			expected: bson.D{notDeleted, {Key: "question.createdon", Value: bson.D{{Key: "$lte", Value: int64(200)}}}},
+		},
+		"CreatedBetween": {
+			filter: domain.QuestionFilter{CreatedFrom: 100, CreatedTo: 200},
+			expected: bson.D{notDeleted, {Key: "question.createdon", Value: bson.D{
+				{Key: "$gte", Value: int64(100)},
+				{Key: "$lte", Value: int64(200)},
+			}}},
+		},
+		"Answered": {
+			filter: domain.QuestionFilter{Answered: &answered},
+			expected: bson.D{notDeleted, {Key: "$and", Value: bson.A{
+				bson.D{{Key: "$or", Value: answeredQuery}},
+			}}},
+		},
+		"Unanswered": {
+			filter: domain.QuestionFilter{Answered: &unanswered},
+			expected: bson.D{notDeleted, {Key: "$and", Value: bson.A{
+				bson.D{{Key: "$nor", Value: answeredQuery}},
+			}}},
+		},
+		"Answerer": {
+			filter: domain.QuestionFilter{AnswererID: "2"},
+			expected: bson.D{notDeleted, {Key: "$and", Value: bson.A{
+				bson.D{{Key: "$or", Value: bson.A{
+					bson.D{{Key: "answers.userid", Value: "2"}},
+					bson.D{{Key: "answer.userid", Value: "2"}},
+				}}},
+			}}},
+		},
+		"All": {
+			filter: domain.QuestionFilter{Unresolved: true, Answered: &unanswered, AuthorID: "1", AnswererID: "2", CreatedFrom: 100, CreatedTo: 200},
+			expected: bson.D{notDeleted,
+				{Key: "acceptedanswerid", Value: bson.D{{Key: "$in", Value: bson.A{nil, ""}}}},
+				{Key: "question.userid", Value: "1"},
+				{Key: "question.createdon", Value: bson.D{
+					{Key: "$gte", Value: int64(100)},
+					{Key: "$lte", Value: int64(200)},
+				}},
+				{Key: "$and", Value: bson.A{
+					bson.D{{Key: "$nor", Value: answeredQuery}},
+					bson.D{{Key: "$or", Value: bson.A{
+						bson.D{{Key: "answers.userid", Value: "2"}},
+						bson.D{{Key: "answer.userid", Value: "2"}},
+					}}},
+				}},
+			},
+		},
+	}
+
+	for name, data := range filters {
+		assert.Equal(t, data.expected, questionFilter(data.filter), name)
+	}
+}
+
+func TestVoteFilter(t *testing.T) {
+	expected := bson.D{
+		{Key: "targettype", Value: domain.TargetAnswer},
+		{Key: "targetid", Value: "1"},
+		{Key: "userid", Value: "2"},
+	}
+	assert.Equal(t, expected, voteFilter(domain.TargetAnswer, "1", "2"))
+}
+
+func TestCommentParentFilter(t *testing.T) {
+	expected := bson.D{
+		{Key: "parenttype", Value: domain.TargetQuestion},
+		{Key: "parentid", Value: "1"},
+	}
+	assert.Equal(t, expected, commentParentFilter(domain.TargetQuestion, "1"))
+}
+
+//The timeouts of the database are returned as unavailable, any other error is internal
+func TestServerError(t *testing.T) {
+	err := serverError(context.DeadlineExceeded, "Internal Server Error!")
+	assert.Equal(t, apperror.Unavailable, apperror.KindOf(err))
+
+	err = serverError(errors.New("Duplicated key"), "Internal Server Error!")
+	assert.Equal(t, apperror.Internal, apperror.KindOf(err))
+	detail, _ := apperror.DetailOf(err)
+	assert.Equal(t, "Internal Server Error!", detail)
+}
+
+func TestPageSort(t *testing.T) {
+	sorts := map[string]bson.D{
+		"":                {{Key: "question.createdon", Value: 1}, {Key: "question.id", Value: 1}},
+		domain.SortOldest: {{Key: "question.createdon", Value: 1}, {Key: "question.id", Value: 1}},
+		domain.SortNewest: {{Key: "question.createdon", Value: -1}, {Key: "question.id", Value: -1}},
+		domain.SortScore:  {{Key: "question.score", Value: -1}, {Key: "question.id", Value: 1}},
+	}
+
+	for sort, expected := range sorts {
+		assert.Equal(t, expected, pageSort(sort), sort)
+	}
+}
+
+func TestAfterCursor(t *testing.T) {
+	after := func(field string, value int64, next string, idNext string) bson.D {
+		return bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: field, Value: bson.D{{Key: next, Value: value}}}},
+			bson.D{
+				{Key: field, Value: value},
+				{Key: "question.id", Value: bson.D{{Key: idNext, Value: "5"}}},
+			},
+		}}}
+	}
+	cursors := map[string]struct {
+		position repo.Cursor
+		expected bson.D
+	}{
+		domain.SortOldest: {
+			position: repo.Cursor{CreatedOn: 100, ID: "5", Score: 3, Sort: domain.SortOldest},
+			expected: after("question.createdon", 100, "$gt", "$gt"),
+		},
+		domain.SortNewest: {
+			position: repo.Cursor{CreatedOn: 100, ID: "5", Score: 3, Sort: domain.SortNewest},
+			expected: after("question.createdon", 100, "$lt", "$lt"),
+		},
+		domain.SortScore: {
+			position: repo.Cursor{CreatedOn: 100, ID: "5", Score: 3, Sort: domain.SortScore},
+			expected: after("question.score", 3, "$lt", "$gt"),
+		},
+	}
+
+	for sort, data := range cursors {
+		assert.Equal(t, data.expected, afterCursor(&data.position), sort)
+	}
+}
+
+//Every question is listed once when a listing is read one question at a time, even when the questions
+//share the creation date or the score and only the ID breaks the tie
+func TestAfterCursor_Continuation(t *testing.T) {
+	questions := []domain.Question{
+		{ID: "1", CreatedOn: 100, Score: 2},
+		{ID: "2", CreatedOn: 100, Score: 5},
+		{ID: "3", CreatedOn: 200, Score: 2},
+		{ID: "4", CreatedOn: 100, Score: 2},
+		{ID: "5", CreatedOn: 300, Score: -1},
+		{ID: "6", CreatedOn: 200, Score: 5},
+	}
+
+	for _, sort := range []string{domain.SortOldest, domain.SortNewest, domain.SortScore} {
+		listed := []string{}
+		var position *repo.Cursor
+		for len(listed) <= len(questions) {
+			var next *domain.Question
+			for i, question := range questions {
+				if position != nil && !matches(afterCursor(position), question) {
+					continue
+				}
+				if next == nil || repo.Precedes(question, *next, sort) {
+					next = &questions[i]
+				}
+			}
+			if next == nil {
+				break
+			}
+			listed = append(listed, next.ID)
+			position, _ = repo.DecodeCursor(repo.EncodeCursor(*next, sort), sort)
+		}
+
+		expected := map[string][]string{
+			domain.SortOldest: {"1", "2", "4", "3", "6", "5"},
+			domain.SortNewest: {"5", "6", "3", "4", "2", "1"},
+			domain.SortScore:  {"2", "6", "1", "3", "4", "5"},
+		}
+		assert.Equal(t, expected[sort], listed, sort)
+	}
+}
+
+//Method that evaluates the conditions generated by afterCursor over a question
+func matches(query bson.D, question domain.Question) bool {
+	fields := map[string]interface{}{
+		"question.createdon": question.CreatedOn,
+		"question.score":     question.Score,
+		"question.id":        question.ID,
+	}
+	for _, condition := range query {
+		if condition.Key == "$or" {
+			found := false
+			for _, option := range condition.Value.(bson.A) {
+				found = found || matches(option.(bson.D), question)
+			}
+			if !found {
+				return false
+			}
+			continue
+		}
+
+		operator, ok := condition.Value.(bson.D)
+		if !ok {
+			if fields[condition.Key] != condition.Value {
+				return false
+			}
+			continue
+		}
+		if !compare(fields[condition.Key], operator[0].Key, operator[0].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func compare(field interface{}, operator string, value interface{}) bool {
+	var less, greater bool
+	switch field := field.(type) {
+	case int64:
+		less, greater = field < value.(int64), field > value.(int64)
+	case string:
+		less, greater = field < value.(string), field > value.(string)
+	}
+	if operator == "$lt" {
+		return less
+	}
+	return greater
+}
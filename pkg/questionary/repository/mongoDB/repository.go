@@ -4,15 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"strings"
 	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
 	repo "github.com/ismaeljpv/qa-api/pkg/questionary/repository"
-	httpError "github.com/ismaeljpv/qa-api/pkg/questionary/transport/http/error"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/requestid"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/tracing"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -22,21 +23,57 @@ import (
 //This is a MongoDB implementation for storing the API data.
 //The Repository interface is implemented
 //This repository injects the database driver that will handle the communication between the aplication and MongoDB
+//The database and the questions collection can be changed with the Config of the repository,
+//these are the names used when they are not set.
 const (
 	DBName                 = "questionary"
 	QuestionInfoCollection = "questionInfo"
 )
 
+const (
+	VoteCollection     = "votes"
+	CommentCollection  = "comments"
+	RevisionCollection = "revisions"
+	RoleCollection     = "roles"
+	APIKeyCollection   = "apiKeys"
+)
+
+//The connection URI of the database and the names of the database and the questions collection
+type Config struct {
+	URI                string
+	Database           string
+	QuestionCollection string
+}
+
 type repository struct {
-	db     *mongo.Database
-	logger log.Logger
+	db        *mongo.Database
+	questions string
+	logger    log.Logger
+}
+
+//The errors of the database are internal, unless the database could not be reached in time
+func serverError(err error, detail string) error {
+	if mongo.IsNetworkError(err) || mongo.IsTimeout(err) {
+		return apperror.New(err, apperror.Unavailable, "The Database Is Not Available")
+	}
+	return apperror.New(err, apperror.Internal, detail)
+}
+
+//The deleted questions keep its document with the deletedon and deletedby fields until they are purged,
+//every query over the questions that are not deleted includes this condition
+var notDeleted = bson.E{Key: "deletedon", Value: bson.D{{Key: "$in", Value: bson.A{nil, 0}}}}
+
+//A question document with the text score of a search
+type searchRecord struct {
+	domain.QuestionInfo `bson:",inline"`
+	Relevance           float64 `bson:"relevance"`
 }
 
-func initDBConnection(ctx context.Context, logger log.Logger, uri string) (*mongo.Database, error) {
+func initDBConnection(ctx context.Context, logger log.Logger, uri, name string) (*mongo.Database, error) {
 
 	ctxTO, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	client, connErr := mongo.Connect(ctxTO, options.Client().ApplyURI(uri))
+	client, connErr := mongo.Connect(ctxTO, options.Client().ApplyURI(uri).SetMonitor(tracing.NewCommandMonitor()))
 	if connErr != nil {
 		return nil, errors.New("Error connecting to the database...")
 	}
@@ -47,90 +84,164 @@ func initDBConnection(ctx context.Context, logger log.Logger, uri string) (*mong
 	if err != nil {
 		level.Warn(logger).Log("msg", err.Error())
 	}
-	database := client.Database(DBName)
+	database := client.Database(name)
 	return database, nil
 }
 
-func NewRepository(ctx context.Context, logger log.Logger, uri string) (repo.Repository, error) {
-	dabatase, err := initDBConnection(ctx, logger, uri)
+func NewRepository(ctx context.Context, logger log.Logger, cfg Config) (repo.Repository, error) {
+	if cfg.Database == "" {
+		cfg.Database = DBName
+	}
+	if cfg.QuestionCollection == "" {
+		cfg.QuestionCollection = QuestionInfoCollection
+	}
+	dabatase, err := initDBConnection(ctx, logger, cfg.URI, cfg.Database)
 	if err != nil {
 		return &repository{}, err
 	}
 
-	return &repository{
-		db:     dabatase,
-		logger: logger,
-	}, nil
+	r := &repository{
+		db:        dabatase,
+		questions: cfg.QuestionCollection,
+		logger:    logger,
+	}
+	r.createIndexes(ctx)
+	return r, nil
 }
 
-func (r *repository) FindAll(ctx context.Context) ([]domain.QuestionInfo, error) {
-	var results []domain.QuestionInfo
-	QICollection := r.db.Collection(QuestionInfoCollection)
-	cursor, err := QICollection.Find(ctx, bson.D{{}})
+//The logs of a request have its request ID
+func (r *repository) log(ctx context.Context) log.Logger {
+	return requestid.Logger(ctx, r.logger)
+}
+
+//The unique index of the votes collection guarantees that each user has only one vote per target,
+//the questions are indexed by its tags to search them by tag, by its position in the pages, by the users that answered them,
+//by the words of its text and by its deletion date to purge them, the comments by its parent and question,
+//the revisions by its question and number, the roles by its user and the API keys by its hash
+func (r *repository) createIndexes(ctx context.Context) {
+	ctxTO, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	voteIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "targettype", Value: 1},
+			{Key: "targetid", Value: 1},
+			{Key: "userid", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+	_, err := r.db.Collection(VoteCollection).Indexes().CreateOne(ctxTO, voteIndex)
 	if err != nil {
-		level.Warn(r.logger).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
-		return []domain.QuestionInfo{}, httpError.NewServerError(err, "Internal Server Error! There was a problem processing your request.")
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error creating the indexes of the votes collection => %v", err.Error()))
 	}
 
-	for cursor.Next(ctx) {
-		var questionInfo domain.QuestionInfo
-		err := cursor.Decode(&questionInfo)
-		if err != nil {
-			level.Warn(r.logger).Log("msg", fmt.Sprintf("Error reading data from the database => %v", err.Error()))
-			return []domain.QuestionInfo{}, httpError.NewServerError(err, "Internal Server Error! There was a problem processing your request.")
-		}
-		results = append(results, questionInfo)
+	questionIndexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "question.tags", Value: 1}}},
+		{Keys: bson.D{{Key: "question.createdon", Value: 1}, {Key: "question.id", Value: 1}}},
+		{Keys: bson.D{{Key: "question.userid", Value: 1}, {Key: "question.createdon", Value: 1}, {Key: "question.id", Value: 1}}},
+		{Keys: bson.D{{Key: "question.score", Value: -1}, {Key: "question.id", Value: 1}}},
+		{Keys: bson.D{{Key: "answers.userid", Value: 1}}},
+		{Keys: bson.D{{Key: "deletedon", Value: 1}}},
+		{
+			Keys: bson.D{
+				{Key: "question.statement", Value: "text"},
+				{Key: "answers.answer", Value: "text"},
+				{Key: "answer.answer", Value: "text"},
+			},
+			Options: options.Index().SetName("question_text"),
+		},
+	}
+	_, err = r.db.Collection(r.questions).Indexes().CreateMany(ctxTO, questionIndexes)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error creating the indexes of the questions collection => %v", err.Error()))
 	}
 
-	if err := cursor.Err(); err != nil {
-		level.Warn(r.logger).Log("msg", fmt.Sprintf("Error working with the cursor of the database => %v", err.Error()))
-		return []domain.QuestionInfo{}, httpError.NewServerError(err, "Internal Server Error! There was a problem processing your request.")
+	commentIndexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "parenttype", Value: 1}, {Key: "parentid", Value: 1}}},
+		{Keys: bson.D{{Key: "questionid", Value: 1}}},
+	}
+	_, err = r.db.Collection(CommentCollection).Indexes().CreateMany(ctxTO, commentIndexes)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error creating the indexes of the comments collection => %v", err.Error()))
 	}
-	cursor.Close(ctx)
 
-	if len(results) == 0 {
-		return []domain.QuestionInfo{}, nil
+	revisionIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "questionid", Value: 1}, {Key: "number", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	_, err = r.db.Collection(RevisionCollection).Indexes().CreateOne(ctxTO, revisionIndex)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error creating the indexes of the revisions collection => %v", err.Error()))
 	}
 
-	return results, nil
+	roleIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "userid", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	_, err = r.db.Collection(RoleCollection).Indexes().CreateOne(ctxTO, roleIndex)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error creating the indexes of the roles collection => %v", err.Error()))
+	}
+
+	apiKeyIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "hash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	_, err = r.db.Collection(APIKeyCollection).Indexes().CreateOne(ctxTO, apiKeyIndex)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error creating the indexes of the API keys collection => %v", err.Error()))
+	}
+}
+
+func (r *repository) FindAll(ctx context.Context, filter domain.QuestionFilter, page domain.Page) (domain.QuestionPage, error) {
+	return r.findPage(ctx, questionFilter(filter), page)
 }
 
 func (r *repository) FindByID(ctx context.Context, id string) (domain.QuestionInfo, error) {
 	var result domain.QuestionInfo
-	filter := bson.D{{Key: "question.id", Value: id}}
-	QICollection := r.db.Collection(QuestionInfoCollection)
+	filter := bson.D{{Key: "question.id", Value: id}, notDeleted}
+	QICollection := r.db.Collection(r.questions)
 
 	err := QICollection.FindOne(ctx, filter).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return domain.QuestionInfo{}, apperror.New(err, apperror.NotFound, "Question Not Found")
+	}
 	if err != nil {
-		level.Warn(r.logger).Log("msg", err.Error())
-		return domain.QuestionInfo{}, httpError.NewClientError(err, http.StatusNotFound, "Question Not Found")
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
+		return domain.QuestionInfo{}, serverError(err, "Internal Server Error! There was a problem processing your request.")
 	}
+	result.NormalizeAnswers()
 	return result, nil
 }
 
-func (r *repository) FindByUser(ctx context.Context, userId string) ([]domain.QuestionInfo, error) {
+func (r *repository) FindByUser(ctx context.Context, userId string, page domain.Page) (domain.QuestionPage, error) {
+	filter := bson.D{{Key: "question.userid", Value: userId}, notDeleted}
+	return r.findPage(ctx, filter, page)
+}
+
+func (r *repository) FindByTag(ctx context.Context, tag string) ([]domain.QuestionInfo, error) {
 	var results []domain.QuestionInfo
-	filter := bson.D{{Key: "question.userid", Value: userId}}
-	QICollection := r.db.Collection(QuestionInfoCollection)
+	filter := bson.D{{Key: "question.tags", Value: tag}, notDeleted}
+	QICollection := r.db.Collection(r.questions)
 
 	cursor, err := QICollection.Find(ctx, filter)
 	if err != nil {
-		level.Warn(r.logger).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
-		return []domain.QuestionInfo{}, httpError.NewServerError(err, "Internal Server Error! There was a problem processing your request.")
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
+		return []domain.QuestionInfo{}, serverError(err, "Internal Server Error! There was a problem processing your request.")
 	}
 
 	for cursor.Next(ctx) {
 		var questionInfo domain.QuestionInfo
 		err := cursor.Decode(&questionInfo)
 		if err != nil {
-			level.Warn(r.logger).Log("msg", fmt.Sprintf("Error decoding data from the database => %v", err.Error()))
-			return []domain.QuestionInfo{}, httpError.NewServerError(err, "Internal Server Error! There was a problem processing your request.")
+			level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error decoding data from the database => %v", err.Error()))
+			return []domain.QuestionInfo{}, serverError(err, "Internal Server Error! There was a problem processing your request.")
 		}
+		questionInfo.NormalizeAnswers()
 		results = append(results, questionInfo)
 	}
 
 	if err := cursor.Err(); err != nil {
-		return []domain.QuestionInfo{}, httpError.NewServerError(err, "Internal Server Error! There was a problem processing your request.")
+		return []domain.QuestionInfo{}, serverError(err, "Internal Server Error! There was a problem processing your request.")
 	}
 	cursor.Close(ctx)
 
@@ -140,114 +251,1134 @@ func (r *repository) FindByUser(ctx context.Context, userId string) ([]domain.Qu
 	return results, nil
 }
 
+func (r *repository) Search(ctx context.Context, query string, filter domain.QuestionFilter) ([]domain.SearchResult, error) {
+	results := []domain.SearchResult{}
+	search := append(bson.D{{Key: "$text", Value: bson.D{{Key: "$search", Value: query}}}}, questionFilter(filter)...)
+	relevance := bson.D{{Key: "relevance", Value: bson.D{{Key: "$meta", Value: "textScore"}}}}
+	opts := options.Find().
+		SetProjection(relevance).
+		SetSort(relevance).
+		SetLimit(domain.MaxSearchResults)
+	QICollection := r.db.Collection(r.questions)
+
+	cursor, err := QICollection.Find(ctx, search, opts)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error searching data in the database => %v", err.Error()))
+		return results, serverError(err, "Internal Server Error! There was a problem processing your request.")
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var record searchRecord
+		err := cursor.Decode(&record)
+		if err != nil {
+			level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error decoding data from the database => %v", err.Error()))
+			return []domain.SearchResult{}, serverError(err, "Internal Server Error! There was a problem processing your request.")
+		}
+		record.QuestionInfo.NormalizeAnswers()
+		results = append(results, domain.SearchResult{QuestionInfo: record.QuestionInfo, Relevance: record.Relevance})
+	}
+
+	if err := cursor.Err(); err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error working with the cursor of the database => %v", err.Error()))
+		return []domain.SearchResult{}, serverError(err, "Internal Server Error! There was a problem processing your request.")
+	}
+	return results, nil
+}
+
+func (r *repository) FindTags(ctx context.Context) ([]domain.TagCount, error) {
+	var results []domain.TagCount
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{notDeleted}}},
+		{{Key: "$unwind", Value: "$question.tags"}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$question.tags"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: 0},
+			{Key: "tag", Value: "$_id"},
+			{Key: "count", Value: 1},
+		}}},
+	}
+	QICollection := r.db.Collection(r.questions)
+
+	cursor, err := QICollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error aggregating the tags of the database => %v", err.Error()))
+		return []domain.TagCount{}, serverError(err, "Internal Server Error! There was a problem processing your request.")
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &results); err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error decoding data from the database => %v", err.Error()))
+		return []domain.TagCount{}, serverError(err, "Internal Server Error! There was a problem processing your request.")
+	}
+
+	if len(results) == 0 {
+		return []domain.TagCount{}, nil
+	}
+	return results, nil
+}
+
 func (r *repository) Create(ctx context.Context, question domain.Question) (domain.Question, error) {
-	QICollection := r.db.Collection(QuestionInfoCollection)
-	newQuestionInfo := domain.QuestionInfo{Question: question}
+	QICollection := r.db.Collection(r.questions)
+	newQuestionInfo := domain.QuestionInfo{Question: question, Answers: []domain.Answer{}}
 	_, err := QICollection.InsertOne(ctx, newQuestionInfo)
 	if err != nil {
-		level.Warn(r.logger).Log("msg", fmt.Sprintf("Error creating a new question in the database => %v", err.Error()))
-		return domain.Question{}, httpError.NewServerError(err, "Internal Server Error! There was a problem processing your request.")
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error creating a new question in the database => %v", err.Error()))
+		return domain.Question{}, serverError(err, "Internal Server Error! There was a problem processing your request.")
 	}
 
-	level.Info(r.logger).Log("msg", fmt.Sprintf("New Question created with ID [%v]", question.ID))
+	level.Info(r.log(ctx)).Log("msg", fmt.Sprintf("New Question created with ID [%v]", question.ID))
 	return question, nil
 }
 
 func (r *repository) Update(ctx context.Context, questionInfo domain.QuestionInfo) (domain.QuestionInfo, error) {
 	var result domain.QuestionInfo
-	filter := bson.D{{Key: "question.id", Value: questionInfo.Question.ID}}
-	QICollection := r.db.Collection(QuestionInfoCollection)
+	filter := bson.D{{Key: "question.id", Value: questionInfo.Question.ID}, notDeleted}
+	QICollection := r.db.Collection(r.questions)
 	er := QICollection.FindOne(ctx, filter).Decode(&result)
 	if er != nil {
-		level.Warn(r.logger).Log("msg", er.Error())
-		return domain.QuestionInfo{}, httpError.NewClientError(er, http.StatusNotFound, "No Question Found")
+		level.Warn(r.log(ctx)).Log("msg", er.Error())
+		return domain.QuestionInfo{}, apperror.New(er, apperror.NotFound, "No Question Found")
 	}
+	result.NormalizeAnswers()
 
-	if result.Answer.ID == "" {
-		return domain.QuestionInfo{}, httpError.NewClientError(errors.New(fmt.Sprintf("Question With ID %v Has No Answer To Update", questionInfo.Question.ID)),
-			http.StatusNotFound,
-			"Question Has No Anwers To Update")
+	//The updates without an answer only change the question, so a question without answers can be edited
+	if questionInfo.HasAnswerUpdate() && len(result.Answers) == 0 {
+		return domain.QuestionInfo{}, apperror.New(errors.New(fmt.Sprintf("Question With ID %v Has No Answer To Update", questionInfo.Question.ID)),
+			apperror.NotFound,
+			"Question Has No Answers To Update")
 	}
 
+	var edited []domain.Revision
 	if strings.Compare(result.Question.Statement, questionInfo.Question.Statement) != 0 {
 		result.Question.Statement = questionInfo.Question.Statement
+		edited = append(edited, domain.Revision{TargetType: domain.TargetQuestion, TargetID: questionInfo.Question.ID, UserID: questionInfo.Question.UserID})
 	}
 
-	if result.Answer.ID == questionInfo.Answer.ID && strings.Compare(result.Answer.Answer, questionInfo.Answer.Answer) != 0 {
-		result.Answer.Answer = questionInfo.Answer.Answer
+	fields := bson.D{{Key: "question.statement", Value: result.Question.Statement}}
+	tagsUpdated := false
+	if questionInfo.Question.Tags != nil {
+		tagsUpdated = strings.Join(result.Question.Tags, ",") != strings.Join(questionInfo.Question.Tags, ",")
+		result.Question.Tags = questionInfo.Question.Tags
+		fields = append(fields, bson.E{Key: "question.tags", Value: questionInfo.Question.Tags})
+	}
+	for i, answer := range result.Answers {
+		if answer.ID == questionInfo.Answer.ID && strings.Compare(answer.Answer, questionInfo.Answer.Answer) != 0 {
+			result.Answers[i].Answer = questionInfo.Answer.Answer
+			filter = append(filter, bson.E{Key: "answers.id", Value: answer.ID})
+			fields = append(fields, bson.E{Key: "answers.$.answer", Value: questionInfo.Answer.Answer})
+			edited = append(edited, domain.Revision{TargetType: domain.TargetAnswer, TargetID: answer.ID, UserID: questionInfo.Answer.UserID})
+		}
 	}
 
-	update := bson.D{{
-		Key: "$set",
-		Value: bson.D{
-			{Key: "question.statement", Value: result.Question.Statement},
-			{Key: "answer.answer", Value: result.Answer.Answer},
-		}}}
+	if len(edited) == 0 && !tagsUpdated {
+		return domain.QuestionInfo{}, apperror.New(errors.New("The Question/Answer Has No Modifications"),
+			apperror.Invalid,
+			"The Question/Answer Has No Modifications")
+	}
+
+	if err := r.upgradeLegacyAnswer(ctx, questionInfo.Question.ID); err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There Was An Error Upgrading The Answer Of Question With ID [%v] => %v", questionInfo.Question.ID, err.Error()))
+		return domain.QuestionInfo{}, serverError(err, "There Was A Problem Processing Your Request")
+	}
 
-	updated, err := QICollection.UpdateOne(ctx, filter, update)
+	//The document before the update holds the previous texts and the number of the last revision
+	var previous domain.QuestionInfo
+	update := bson.D{
+		{Key: "$set", Value: fields},
+		{Key: "$inc", Value: bson.D{{Key: "revisioncount", Value: len(edited)}}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+	err := QICollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous)
+	if err == mongo.ErrNoDocuments {
+		return domain.QuestionInfo{}, apperror.New(errors.New(fmt.Sprintf("No Question Found With ID %v", questionInfo.Question.ID)),
+			apperror.NotFound, "No Question Found")
+	}
 	if err != nil {
-		level.Warn(r.logger).Log("msg", fmt.Sprintf("There Was An Error Updating The Data Of Question With ID [%v]", questionInfo.Question.ID))
-		return domain.QuestionInfo{}, httpError.NewServerError(err, "There Was A Problem Processing Your Request")
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There Was An Error Updating The Data Of Question With ID [%v]", questionInfo.Question.ID))
+		return domain.QuestionInfo{}, serverError(err, "There Was A Problem Processing Your Request")
 	}
+	previous.NormalizeAnswers()
 
-	if updated.ModifiedCount == 0 {
-		return domain.QuestionInfo{}, httpError.NewClientError(errors.New("The Question/Answer Has No Modifications"),
-			http.StatusBadRequest,
-			"The Question/Answer Has No Modifications")
+	for i, revision := range edited {
+		revision.QuestionID = questionInfo.Question.ID
+		revision.Number = previous.RevisionCount + int64(i) + 1
+		revision.Text, _ = previous.Text(revision.TargetType, revision.TargetID)
+		revision.CreatedOn = time.Now().Unix()
+		if err := r.recordRevision(ctx, revision); err != nil {
+			return domain.QuestionInfo{}, err
+		}
 	}
+	result.RevisionCount = previous.RevisionCount + int64(len(edited))
 
 	return result, nil
 }
 
-func (r *repository) Delete(ctx context.Context, id string) (string, error) {
-	filter := bson.D{{Key: "question.id", Value: id}}
-	QICollection := r.db.Collection(QuestionInfoCollection)
+func (r *repository) Delete(ctx context.Context, id string, userId string) (string, error) {
+	filter := bson.D{{Key: "question.id", Value: id}, notDeleted}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "deletedon", Value: time.Now().Unix()},
+		{Key: "deletedby", Value: userId},
+	}}}
+	QICollection := r.db.Collection(r.questions)
 
-	deleted, err := QICollection.DeleteOne(ctx, filter)
+	deleted, err := QICollection.UpdateOne(ctx, filter, update)
 	if err != nil {
-		level.Warn(r.logger).Log("msg", fmt.Sprintf("There was a problem deleting the question => %v", err.Error()))
-		return "", httpError.NewServerError(err, "There Was A Problem Processing Your Request.")
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There was a problem deleting the question => %v", err.Error()))
+		return "", serverError(err, "There Was A Problem Processing Your Request.")
 	}
 
-	if deleted.DeletedCount == 0 {
-		return "", httpError.NewClientError(errors.New(fmt.Sprintf("No Question Found With ID %v", id)),
-			http.StatusNotFound,
+	if deleted.MatchedCount == 0 {
+		return "", apperror.New(errors.New(fmt.Sprintf("No Question Found With ID %v", id)),
+			apperror.NotFound,
 			"No Question Found")
 	}
 	return "Question Deleted Successfully", nil
 }
 
-func (r repository) AddAnswer(ctx context.Context, answer domain.Answer) (domain.QuestionInfo, error) {
+func (r *repository) Restore(ctx context.Context, id string) (domain.QuestionInfo, error) {
 	var result domain.QuestionInfo
-	filter := bson.D{{Key: "question.id", Value: answer.QuestionID}}
-	QICollection := r.db.Collection(QuestionInfoCollection)
-	er := QICollection.FindOne(ctx, filter).Decode(&result)
-	if er != nil {
-		level.Warn(r.logger).Log("msg", er.Error())
-		return domain.QuestionInfo{}, httpError.NewClientError(er, http.StatusNotFound, "No Question Found")
+	filter := bson.D{
+		{Key: "question.id", Value: id},
+		{Key: "deletedon", Value: bson.D{{Key: "$gt", Value: 0}}},
 	}
+	update := bson.D{{Key: "$unset", Value: bson.D{
+		{Key: "deletedon", Value: ""},
+		{Key: "deletedby", Value: ""},
+	}}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	QICollection := r.db.Collection(r.questions)
 
-	if result.Question.ID == "" {
-		return domain.QuestionInfo{}, httpError.NewClientError(errors.New(fmt.Sprintf("No Question Found With ID %v", answer.QuestionID)),
-			http.StatusNotFound, "No Question Found")
+	err := QICollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return domain.QuestionInfo{}, apperror.New(errors.New(fmt.Sprintf("No Deleted Question Found With ID %v", id)),
+			apperror.NotFound,
+			"No Deleted Question Found")
 	}
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There was a problem restoring the question => %v", err.Error()))
+		return domain.QuestionInfo{}, serverError(err, "There Was A Problem Processing Your Request.")
+	}
+	result.NormalizeAnswers()
+	return result, nil
+}
 
-	if result.Answer.ID != "" {
-		return domain.QuestionInfo{}, httpError.NewClientError(errors.New(fmt.Sprintf("Question With ID %v Is Already Answered", answer.QuestionID)),
-			http.StatusConflict,
-			"Question Is Already Answered!")
+func (r *repository) Purge(ctx context.Context, deletedBefore int64) (int64, error) {
+	var ids []string
+	answerIds := []string{}
+	filter := bson.D{{Key: "deletedon", Value: bson.D{{Key: "$gt", Value: 0}, {Key: "$lt", Value: deletedBefore}}}}
+	QICollection := r.db.Collection(r.questions)
+
+	projection := bson.D{{Key: "question.id", Value: 1}, {Key: "answer.id", Value: 1}, {Key: "answers.id", Value: 1}}
+	cursor, err := QICollection.Find(ctx, filter, options.Find().SetProjection(projection))
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
+		return 0, serverError(err, "There Was A Problem Processing Your Request.")
 	}
-	result.Answer = answer
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var questionInfo domain.QuestionInfo
+		if err := cursor.Decode(&questionInfo); err != nil {
+			level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error decoding data from the database => %v", err.Error()))
+			return 0, serverError(err, "There Was A Problem Processing Your Request.")
+		}
+		ids = append(ids, questionInfo.Question.ID)
+		questionInfo.NormalizeAnswers()
+		for _, answer := range questionInfo.Answers {
+			answerIds = append(answerIds, answer.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	byQuestion := bson.D{{Key: "questionid", Value: bson.D{{Key: "$in", Value: ids}}}}
+	_, err = r.db.Collection(CommentCollection).DeleteMany(ctx, byQuestion)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There was a problem purging the comments of the questions => %v", err.Error()))
+		return 0, serverError(err, "There Was A Problem Processing Your Request.")
+	}
+
+	_, err = r.db.Collection(RevisionCollection).DeleteMany(ctx, byQuestion)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There was a problem purging the revisions of the questions => %v", err.Error()))
+		return 0, serverError(err, "There Was A Problem Processing Your Request.")
+	}
+
+	//The votes only have its target, so the votes of the answers are found by the IDs of the answers
+	byTarget := bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "targettype", Value: domain.TargetQuestion}, {Key: "targetid", Value: bson.D{{Key: "$in", Value: ids}}}},
+		bson.D{{Key: "targettype", Value: domain.TargetAnswer}, {Key: "targetid", Value: bson.D{{Key: "$in", Value: answerIds}}}},
+	}}}
+	_, err = r.db.Collection(VoteCollection).DeleteMany(ctx, byTarget)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There was a problem purging the votes of the questions => %v", err.Error()))
+		return 0, serverError(err, "There Was A Problem Processing Your Request.")
+	}
+
+	purged, err := QICollection.DeleteMany(ctx, bson.D{{Key: "question.id", Value: bson.D{{Key: "$in", Value: ids}}}, filter[0]})
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There was a problem purging the questions => %v", err.Error()))
+		return 0, serverError(err, "There Was A Problem Processing Your Request.")
+	}
+	return purged.DeletedCount, nil
+}
+
+func (r *repository) AddAnswer(ctx context.Context, answer domain.Answer) (domain.QuestionInfo, error) {
+	var result domain.QuestionInfo
+	filter := bson.D{{Key: "question.id", Value: answer.QuestionID}, notDeleted}
+	QICollection := r.db.Collection(r.questions)
+
+	if err := r.upgradeLegacyAnswer(ctx, answer.QuestionID); err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There Was An Error Upgrading The Answer Of Question With ID [%v] => %v", answer.QuestionID, err.Error()))
+		return domain.QuestionInfo{}, serverError(err, "There Was An Error Adding The Answer")
+	}
+
+	update := bson.D{{
+		Key: "$push",
+		Value: bson.D{
+			{Key: "answers", Value: answer},
+		}}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := QICollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return domain.QuestionInfo{}, apperror.New(errors.New(fmt.Sprintf("No Question Found With ID %v", answer.QuestionID)),
+			apperror.NotFound, "No Question Found")
+	}
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There Was An Error Adding The Answer To Question With ID [%v]", answer.QuestionID))
+		return domain.QuestionInfo{}, serverError(err, "There Was An Error Adding The Answer")
+	}
+	result.NormalizeAnswers()
+	return result, nil
+}
+
+func (r *repository) FindAnswers(ctx context.Context, questionId string) ([]domain.Answer, error) {
+	questionInfo, err := r.FindByID(ctx, questionId)
+	if err != nil {
+		return []domain.Answer{}, err
+	}
+	return questionInfo.Answers, nil
+}
+
+func (r *repository) UpdateAnswer(ctx context.Context, answer domain.Answer) (domain.Answer, error) {
+	if err := r.upgradeLegacyAnswer(ctx, answer.QuestionID); err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There Was An Error Upgrading The Answer Of Question With ID [%v] => %v", answer.QuestionID, err.Error()))
+		return domain.Answer{}, serverError(err, "There Was A Problem Processing Your Request")
+	}
+
+	result, err := r.editText(ctx, domain.TargetAnswer, answer.QuestionID, answer.ID, answer.Answer, answer.UserID)
+	if err != nil {
+		return domain.Answer{}, err
+	}
+
+	for _, updated := range result.Answers {
+		if updated.ID == answer.ID {
+			return updated, nil
+		}
+	}
+	return domain.Answer{}, apperror.New(errors.New(fmt.Sprintf("No Answer Found With ID %v", answer.ID)),
+		apperror.NotFound, "No Answer Found")
+}
+
+func (r *repository) DeleteAnswer(ctx context.Context, questionId string, answerId string) (string, error) {
+	filter := bson.D{
+		{Key: "question.id", Value: questionId},
+		{Key: "answers.id", Value: answerId},
+		notDeleted,
+	}
+	QICollection := r.db.Collection(r.questions)
+
+	if err := r.upgradeLegacyAnswer(ctx, questionId); err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There Was An Error Upgrading The Answer Of Question With ID [%v] => %v", questionId, err.Error()))
+		return "", serverError(err, "There Was A Problem Processing Your Request.")
+	}
+
+	update := bson.D{{
+		Key: "$pull",
+		Value: bson.D{
+			{Key: "answers", Value: bson.D{{Key: "id", Value: answerId}}},
+		}}}
+	deleted, err := QICollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There was a problem deleting the answer => %v", err.Error()))
+		return "", serverError(err, "There Was A Problem Processing Your Request.")
+	}
+
+	if deleted.ModifiedCount == 0 {
+		return "", apperror.New(errors.New(fmt.Sprintf("No Answer Found With ID %v", answerId)),
+			apperror.NotFound,
+			"No Answer Found")
+	}
+
+	acceptedFilter := bson.D{
+		{Key: "question.id", Value: questionId},
+		{Key: "acceptedanswerid", Value: answerId},
+	}
+	unaccept := bson.D{{Key: "$unset", Value: bson.D{{Key: "acceptedanswerid", Value: ""}}}}
+	_, err = QICollection.UpdateOne(ctx, acceptedFilter, unaccept)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There was a problem removing the accepted answer => %v", err.Error()))
+		return "", serverError(err, "There Was A Problem Processing Your Request.")
+	}
+
+	CCollection := r.db.Collection(CommentCollection)
+	_, err = CCollection.DeleteMany(ctx, commentParentFilter(domain.TargetAnswer, answerId))
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There was a problem deleting the comments of the answer => %v", err.Error()))
+		return "", serverError(err, "There Was A Problem Processing Your Request.")
+	}
+
+	VCollection := r.db.Collection(VoteCollection)
+	_, err = VCollection.DeleteMany(ctx, bson.D{{Key: "targettype", Value: domain.TargetAnswer}, {Key: "targetid", Value: answerId}})
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There was a problem deleting the votes of the answer => %v", err.Error()))
+		return "", serverError(err, "There Was A Problem Processing Your Request.")
+	}
+	return "Answer Deleted Successfully", nil
+}
+
+func (r *repository) AcceptAnswer(ctx context.Context, questionId string, answerId string) (domain.QuestionInfo, error) {
+	var result domain.QuestionInfo
+	filter := bson.D{
+		{Key: "question.id", Value: questionId},
+		{Key: "answers.id", Value: answerId},
+		notDeleted,
+	}
+	QICollection := r.db.Collection(r.questions)
+
+	if err := r.upgradeLegacyAnswer(ctx, questionId); err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There Was An Error Upgrading The Answer Of Question With ID [%v] => %v", questionId, err.Error()))
+		return domain.QuestionInfo{}, serverError(err, "There Was A Problem Processing Your Request")
+	}
+
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "acceptedanswerid", Value: answerId}}}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := QICollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return domain.QuestionInfo{}, apperror.New(errors.New(fmt.Sprintf("No Answer Found With ID %v", answerId)),
+			apperror.NotFound, "No Answer Found")
+	}
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There Was An Error Accepting The Answer With ID [%v]", answerId))
+		return domain.QuestionInfo{}, serverError(err, "There Was A Problem Processing Your Request")
+	}
+	result.NormalizeAnswers()
+	return result, nil
+}
+
+func (r *repository) UnacceptAnswer(ctx context.Context, questionId string) (domain.QuestionInfo, error) {
+	var result domain.QuestionInfo
+	filter := bson.D{{Key: "question.id", Value: questionId}, notDeleted}
+	QICollection := r.db.Collection(r.questions)
+
+	update := bson.D{{Key: "$unset", Value: bson.D{{Key: "acceptedanswerid", Value: ""}}}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := QICollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return domain.QuestionInfo{}, apperror.New(errors.New(fmt.Sprintf("No Question Found With ID %v", questionId)),
+			apperror.NotFound, "No Question Found")
+	}
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There Was An Error Removing The Accepted Answer Of Question With ID [%v]", questionId))
+		return domain.QuestionInfo{}, serverError(err, "There Was A Problem Processing Your Request")
+	}
+	result.NormalizeAnswers()
+	return result, nil
+}
+
+func (r *repository) Vote(ctx context.Context, vote domain.Vote) (domain.Score, error) {
+	targetFilter, err := r.voteTargetFilter(ctx, vote.TargetType, vote.TargetID)
+	if err != nil {
+		return domain.Score{}, err
+	}
+
+	var previous domain.Vote
+	filter := voteFilter(vote.TargetType, vote.TargetID, vote.UserID)
 	update := bson.D{{
 		Key: "$set",
 		Value: bson.D{
-			{Key: "answer", Value: answer},
+			{Key: "value", Value: vote.Value},
+			{Key: "createdon", Value: vote.CreatedOn},
 		}}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+	VCollection := r.db.Collection(VoteCollection)
 
-	updated, err := QICollection.UpdateOne(ctx, filter, update)
-	if err != nil || updated.ModifiedCount == 0 {
-		level.Warn(r.logger).Log("msg", fmt.Sprintf("There Was An Error Adding The Answer To Question With ID [%v]", answer.QuestionID))
-		return domain.QuestionInfo{}, httpError.NewServerError(err, "There Was An Error Adding The Answer")
+	err = VCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous)
+	if mongo.IsDuplicateKeyError(err) {
+		//Two first votes of the same user raced on the upsert, the vote exists now so it can be replaced
+		err = VCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous)
+	}
+	if err != nil && err != mongo.ErrNoDocuments {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There Was An Error Saving The Vote Of User [%v] => %v", vote.UserID, err.Error()))
+		return domain.Score{}, serverError(err, "There Was A Problem Processing Your Request")
+	}
+
+	return r.incrementScore(ctx, vote.TargetType, vote.TargetID, targetFilter, int64(vote.Value-previous.Value))
+}
+
+func (r *repository) RetractVote(ctx context.Context, targetType string, targetId string, userId string) (domain.Score, error) {
+	targetFilter, err := r.voteTargetFilter(ctx, targetType, targetId)
+	if err != nil {
+		return domain.Score{}, err
+	}
+
+	var previous domain.Vote
+	VCollection := r.db.Collection(VoteCollection)
+	err = VCollection.FindOneAndDelete(ctx, voteFilter(targetType, targetId, userId)).Decode(&previous)
+	if err == mongo.ErrNoDocuments {
+		return domain.Score{}, apperror.New(errors.New(fmt.Sprintf("No Vote Found For User %v", userId)),
+			apperror.NotFound, "No Vote Found")
+	}
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There Was An Error Retracting The Vote Of User [%v] => %v", userId, err.Error()))
+		return domain.Score{}, serverError(err, "There Was A Problem Processing Your Request")
+	}
+
+	return r.incrementScore(ctx, targetType, targetId, targetFilter, int64(-previous.Value))
+}
+
+//Method that builds the filter of the document that holds the score of a vote target,
+//the answers are searched by its ID because the vote does not know the question of the answer
+func (r *repository) voteTargetFilter(ctx context.Context, targetType string, targetId string) (bson.D, error) {
+	var result domain.QuestionInfo
+	QICollection := r.db.Collection(r.questions)
+
+	if targetType == domain.TargetQuestion {
+		filter := bson.D{{Key: "question.id", Value: targetId}, notDeleted}
+		err := QICollection.FindOne(ctx, filter).Decode(&result)
+		if err == mongo.ErrNoDocuments {
+			return nil, apperror.New(errors.New(fmt.Sprintf("No Question Found With ID %v", targetId)),
+				apperror.NotFound, "No Question Found")
+		}
+		if err != nil {
+			level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
+			return nil, serverError(err, "There Was A Problem Processing Your Request")
+		}
+		return filter, nil
+	}
+
+	answerFilter := bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "answers.id", Value: targetId}},
+		bson.D{{Key: "answer.id", Value: targetId}},
+	}}, notDeleted}
+	err := QICollection.FindOne(ctx, answerFilter).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return nil, apperror.New(errors.New(fmt.Sprintf("No Answer Found With ID %v", targetId)),
+			apperror.NotFound, "No Answer Found")
+	}
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
+		return nil, serverError(err, "There Was A Problem Processing Your Request")
+	}
+
+	if err := r.upgradeLegacyAnswer(ctx, result.Question.ID); err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There Was An Error Upgrading The Answer Of Question With ID [%v] => %v", result.Question.ID, err.Error()))
+		return nil, serverError(err, "There Was A Problem Processing Your Request")
+	}
+	return bson.D{
+		{Key: "question.id", Value: result.Question.ID},
+		{Key: "answers.id", Value: targetId},
+	}, nil
+}
+
+//Method that atomically adds the difference between the new and the previous vote of a user to the score of the target
+func (r *repository) incrementScore(ctx context.Context, targetType string, targetId string, filter bson.D, delta int64) (domain.Score, error) {
+	var result domain.QuestionInfo
+	field := "question.score"
+	if targetType == domain.TargetAnswer {
+		field = "answers.$.score"
+	}
+
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: field, Value: delta}}}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	QICollection := r.db.Collection(r.questions)
+	err := QICollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There Was An Error Updating The Score Of [%v] With ID [%v] => %v", targetType, targetId, err.Error()))
+		return domain.Score{}, serverError(err, "There Was A Problem Processing Your Request")
+	}
+
+	score := domain.Score{TargetType: targetType, TargetID: targetId, Score: result.Question.Score}
+	if targetType == domain.TargetAnswer {
+		for _, answer := range result.Answers {
+			if answer.ID == targetId {
+				score.Score = answer.Score
+			}
+		}
+	}
+	return score, nil
+}
+
+func voteFilter(targetType string, targetId string, userId string) bson.D {
+	return bson.D{
+		{Key: "targettype", Value: targetType},
+		{Key: "targetid", Value: targetId},
+		{Key: "userid", Value: userId},
+	}
+}
+
+func (r *repository) AddComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	filter := bson.D{{Key: "question.id", Value: comment.QuestionID}, notDeleted}
+	notFound := "No Question Found"
+	if comment.ParentType == domain.TargetAnswer {
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "answers.id", Value: comment.ParentID}},
+			bson.D{{Key: "answer.id", Value: comment.ParentID}},
+		}})
+		notFound = "No Answer Found"
+	}
+	QICollection := r.db.Collection(r.questions)
+
+	count, err := QICollection.CountDocuments(ctx, filter)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
+		return domain.Comment{}, serverError(err, "There Was An Error Adding The Comment")
+	}
+
+	if count == 0 {
+		return domain.Comment{}, apperror.New(errors.New(fmt.Sprintf("%v With ID %v", notFound, comment.ParentID)),
+			apperror.NotFound, notFound)
+	}
+
+	CCollection := r.db.Collection(CommentCollection)
+	_, err = CCollection.InsertOne(ctx, comment)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error creating a new comment in the database => %v", err.Error()))
+		return domain.Comment{}, serverError(err, "There Was An Error Adding The Comment")
+	}
+	return comment, nil
+}
+
+func (r *repository) FindComments(ctx context.Context, parentType string, parentId string) ([]domain.Comment, error) {
+	var results []domain.Comment
+	if parentType == domain.TargetQuestion {
+		if _, err := r.FindByID(ctx, parentId); err != nil {
+			return []domain.Comment{}, err
+		}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdon", Value: 1}})
+	CCollection := r.db.Collection(CommentCollection)
+
+	cursor, err := CCollection.Find(ctx, commentParentFilter(parentType, parentId), opts)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
+		return []domain.Comment{}, serverError(err, "Internal Server Error! There was a problem processing your request.")
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &results); err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error decoding data from the database => %v", err.Error()))
+		return []domain.Comment{}, serverError(err, "Internal Server Error! There was a problem processing your request.")
+	}
+
+	if len(results) == 0 {
+		return []domain.Comment{}, nil
+	}
+	//The comments of an answer are not found while its question is deleted
+	if parentType == domain.TargetAnswer {
+		if _, err := r.FindByID(ctx, results[0].QuestionID); err != nil {
+			return []domain.Comment{}, err
+		}
+	}
+	return results, nil
+}
+
+func (r *repository) FindComment(ctx context.Context, questionId string, commentId string) (domain.Comment, error) {
+	var result domain.Comment
+	if _, err := r.FindByID(ctx, questionId); err != nil {
+		return domain.Comment{}, err
+	}
+
+	filter := bson.D{
+		{Key: "id", Value: commentId},
+		{Key: "questionid", Value: questionId},
+	}
+	CCollection := r.db.Collection(CommentCollection)
+
+	err := CCollection.FindOne(ctx, filter).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return domain.Comment{}, apperror.New(errors.New(fmt.Sprintf("No Comment Found With ID %v", commentId)),
+			apperror.NotFound, "No Comment Found")
+	}
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
+		return domain.Comment{}, serverError(err, "There Was A Problem Processing Your Request")
+	}
+	return result, nil
+}
+
+func (r *repository) UpdateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	var result domain.Comment
+	filter := bson.D{
+		{Key: "id", Value: comment.ID},
+		{Key: "questionid", Value: comment.QuestionID},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "comment", Value: comment.Comment}}}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	CCollection := r.db.Collection(CommentCollection)
+
+	err := CCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return domain.Comment{}, apperror.New(errors.New(fmt.Sprintf("No Comment Found With ID %v", comment.ID)),
+			apperror.NotFound, "No Comment Found")
+	}
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There Was An Error Updating The Comment With ID [%v]", comment.ID))
+		return domain.Comment{}, serverError(err, "There Was A Problem Processing Your Request")
+	}
+	return result, nil
+}
+
+func (r *repository) DeleteComment(ctx context.Context, questionId string, commentId string) (string, error) {
+	filter := bson.D{
+		{Key: "id", Value: commentId},
+		{Key: "questionid", Value: questionId},
+	}
+	CCollection := r.db.Collection(CommentCollection)
+
+	deleted, err := CCollection.DeleteOne(ctx, filter)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There was a problem deleting the comment => %v", err.Error()))
+		return "", serverError(err, "There Was A Problem Processing Your Request.")
+	}
+
+	if deleted.DeletedCount == 0 {
+		return "", apperror.New(errors.New(fmt.Sprintf("No Comment Found With ID %v", commentId)),
+			apperror.NotFound,
+			"No Comment Found")
+	}
+	return "Comment Deleted Successfully", nil
+}
+
+func (r *repository) FindRevisions(ctx context.Context, questionId string) ([]domain.Revision, error) {
+	var results []domain.Revision
+	if _, err := r.FindByID(ctx, questionId); err != nil {
+		return []domain.Revision{}, err
+	}
+
+	filter := bson.D{{Key: "questionid", Value: questionId}}
+	opts := options.Find().SetSort(bson.D{{Key: "number", Value: 1}})
+	RCollection := r.db.Collection(RevisionCollection)
+
+	cursor, err := RCollection.Find(ctx, filter, opts)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
+		return []domain.Revision{}, serverError(err, "Internal Server Error! There was a problem processing your request.")
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &results); err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error decoding data from the database => %v", err.Error()))
+		return []domain.Revision{}, serverError(err, "Internal Server Error! There was a problem processing your request.")
+	}
+
+	if len(results) == 0 {
+		return []domain.Revision{}, nil
+	}
+	return results, nil
+}
+
+func (r *repository) FindRevision(ctx context.Context, questionId string, number int64) (domain.Revision, error) {
+	var result domain.Revision
+	filter := bson.D{
+		{Key: "questionid", Value: questionId},
+		{Key: "number", Value: number},
+	}
+	RCollection := r.db.Collection(RevisionCollection)
+
+	err := RCollection.FindOne(ctx, filter).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return domain.Revision{}, apperror.New(errors.New(fmt.Sprintf("No Revision %v Found For Question %v", number, questionId)),
+			apperror.NotFound, "No Revision Found")
+	}
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
+		return domain.Revision{}, serverError(err, "There Was A Problem Processing Your Request")
+	}
+	return result, nil
+}
+
+func (r *repository) RestoreRevision(ctx context.Context, revision domain.Revision, userId string) (domain.QuestionInfo, error) {
+	return r.editText(ctx, revision.TargetType, revision.QuestionID, revision.TargetID, revision.Text, userId)
+}
+
+func (r *repository) FindRoles(ctx context.Context, userId string) (domain.UserRoles, error) {
+	result := domain.UserRoles{UserID: userId, Roles: []string{}}
+	RCollection := r.db.Collection(RoleCollection)
+	err := RCollection.FindOne(ctx, bson.D{{Key: "userid", Value: userId}}).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return result, nil
+	}
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
+		return domain.UserRoles{}, serverError(err, "There Was A Problem Processing Your Request")
+	}
+	return result, nil
+}
+
+func (r *repository) GrantRole(ctx context.Context, userId string, role string) (domain.UserRoles, error) {
+	var result domain.UserRoles
+	update := bson.D{{Key: "$addToSet", Value: bson.D{{Key: "roles", Value: role}}}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	RCollection := r.db.Collection(RoleCollection)
+
+	err := RCollection.FindOneAndUpdate(ctx, bson.D{{Key: "userid", Value: userId}}, update, opts).Decode(&result)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error granting the role %v to the user %v => %v", role, userId, err.Error()))
+		return domain.UserRoles{}, serverError(err, "There Was An Error Granting The Role")
+	}
+	return result, nil
+}
+
+func (r *repository) RevokeRole(ctx context.Context, userId string, role string) (domain.UserRoles, error) {
+	var result domain.UserRoles
+	filter := bson.D{{Key: "userid", Value: userId}, {Key: "roles", Value: role}}
+	update := bson.D{{Key: "$pull", Value: bson.D{{Key: "roles", Value: role}}}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	RCollection := r.db.Collection(RoleCollection)
+
+	err := RCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("The user %v does not have the role %v, method RevokeRole", userId, role))
+		return domain.UserRoles{}, apperror.New(err, apperror.NotFound, "The User Does Not Have The Role")
+	}
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error revoking the role %v of the user %v => %v", role, userId, err.Error()))
+		return domain.UserRoles{}, serverError(err, "There Was An Error Revoking The Role")
+	}
+	return result, nil
+}
+
+//Method that replaces the text of a question statement or of one of its answers and records the edit as a revision,
+//the revision counter is incremented in the same update so concurrent edits get different numbers.
+//Nothing is recorded when the target already has the given text.
+func (r *repository) editText(ctx context.Context, targetType string, questionId string, targetId string, text string, userId string) (domain.QuestionInfo, error) {
+	var previous domain.QuestionInfo
+	filter := bson.D{
+		{Key: "question.id", Value: questionId},
+		{Key: "question.statement", Value: bson.D{{Key: "$ne", Value: text}}},
+		notDeleted,
+	}
+	field := "question.statement"
+	notFound := "No Question Found"
+	if targetType == domain.TargetAnswer {
+		filter = bson.D{
+			{Key: "question.id", Value: questionId},
+			notDeleted,
+			{Key: "answers", Value: bson.D{{Key: "$elemMatch", Value: bson.D{
+				{Key: "id", Value: targetId},
+				{Key: "answer", Value: bson.D{{Key: "$ne", Value: text}}},
+			}}}},
+		}
+		field = "answers.$.answer"
+		notFound = "No Answer Found"
+	}
+
+	update := bson.D{
+		{Key: "$set", Value: bson.D{{Key: field, Value: text}}},
+		{Key: "$inc", Value: bson.D{{Key: "revisioncount", Value: 1}}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+	QICollection := r.db.Collection(r.questions)
+	err := QICollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous)
+	if err == mongo.ErrNoDocuments {
+		current, findErr := r.FindByID(ctx, questionId)
+		if _, found := current.Text(targetType, targetId); findErr == nil && found {
+			return current, nil
+		}
+		return domain.QuestionInfo{}, apperror.New(errors.New(fmt.Sprintf("%v With ID %v", notFound, targetId)),
+			apperror.NotFound, notFound)
+	}
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("There Was An Error Updating The Text Of [%v] With ID [%v]", targetType, targetId))
+		return domain.QuestionInfo{}, serverError(err, "There Was A Problem Processing Your Request")
+	}
+	previous.NormalizeAnswers()
+
+	previousText, _ := previous.Text(targetType, targetId)
+	revision := domain.Revision{
+		QuestionID: questionId,
+		Number:     previous.RevisionCount + 1,
+		TargetType: targetType,
+		TargetID:   targetId,
+		Text:       previousText,
+		UserID:     userId,
+		CreatedOn:  time.Now().Unix(),
+	}
+	if err := r.recordRevision(ctx, revision); err != nil {
+		return domain.QuestionInfo{}, err
+	}
+
+	previous.SetText(targetType, targetId, text)
+	previous.RevisionCount = revision.Number
+	return previous, nil
+}
+
+func (r *repository) recordRevision(ctx context.Context, revision domain.Revision) error {
+	RCollection := r.db.Collection(RevisionCollection)
+	_, err := RCollection.InsertOne(ctx, revision)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error recording the revision %v of Question [%v] => %v", revision.Number, revision.QuestionID, err.Error()))
+		return serverError(err, "There Was A Problem Processing Your Request")
+	}
+	return nil
+}
+
+func commentParentFilter(parentType string, parentId string) bson.D {
+	return bson.D{
+		{Key: "parenttype", Value: parentType},
+		{Key: "parentid", Value: parentId},
+	}
+}
+
+//Method that returns the questions that match the filter placed after the cursor of the page,
+//one more question than the limit is read to know if there is a next page
+func (r *repository) findPage(ctx context.Context, filter bson.D, page domain.Page) (domain.QuestionPage, error) {
+	results := []domain.QuestionInfo{}
+	page.Normalize()
+	position, err := repo.DecodeCursor(page.Cursor, page.Sort)
+	if err != nil {
+		return domain.QuestionPage{Questions: results}, err
+	}
+
+	if position != nil {
+		filter = bson.D{{Key: "$and", Value: bson.A{filter, afterCursor(position)}}}
+	}
+
+	opts := options.Find().
+		SetSort(pageSort(page.Sort)).
+		SetLimit(page.Limit + 1)
+	QICollection := r.db.Collection(r.questions)
+	cursor, err := QICollection.Find(ctx, filter, opts)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
+		return domain.QuestionPage{Questions: results}, serverError(err, "Internal Server Error! There was a problem processing your request.")
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var questionInfo domain.QuestionInfo
+		err := cursor.Decode(&questionInfo)
+		if err != nil {
+			level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error reading data from the database => %v", err.Error()))
+			return domain.QuestionPage{Questions: []domain.QuestionInfo{}}, serverError(err, "Internal Server Error! There was a problem processing your request.")
+		}
+		questionInfo.NormalizeAnswers()
+		results = append(results, questionInfo)
+	}
+
+	if err := cursor.Err(); err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error working with the cursor of the database => %v", err.Error()))
+		return domain.QuestionPage{Questions: []domain.QuestionInfo{}}, serverError(err, "Internal Server Error! There was a problem processing your request.")
+	}
+
+	result := domain.QuestionPage{Questions: results}
+	if int64(len(results)) > page.Limit {
+		result.Questions = results[:page.Limit]
+		result.NextCursor = repo.EncodeCursor(result.Questions[page.Limit-1].Question, page.Sort)
+	}
+	return result, nil
+}
+
+//Method that translate the sort order of a page into the MongoDB sort options, the ID breaks the ties
+func pageSort(sort string) bson.D {
+	switch sort {
+	case domain.SortNewest:
+		return bson.D{{Key: "question.createdon", Value: -1}, {Key: "question.id", Value: -1}}
+	case domain.SortScore:
+		return bson.D{{Key: "question.score", Value: -1}, {Key: "question.id", Value: 1}}
+	default:
+		return bson.D{{Key: "question.createdon", Value: 1}, {Key: "question.id", Value: 1}}
+	}
+}
+
+//Method that returns the query of the questions placed after the position of a cursor in its sort order
+func afterCursor(position *repo.Cursor) bson.D {
+	field, value, next, idNext := "question.createdon", position.CreatedOn, "$gt", "$gt"
+	switch position.Sort {
+	case domain.SortNewest:
+		next, idNext = "$lt", "$lt"
+	case domain.SortScore:
+		field, value, next = "question.score", position.Score, "$lt"
+	}
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: field, Value: bson.D{{Key: next, Value: value}}}},
+		bson.D{
+			{Key: field, Value: value},
+			{Key: "question.id", Value: bson.D{{Key: idNext, Value: position.ID}}},
+		},
+	}}}
+}
+
+//Method that translate the question filters into a MongoDB query over the questions that are not deleted,
+//the answers of the documents written before a question could have many answers are read from the "answer" field
+func questionFilter(filter domain.QuestionFilter) bson.D {
+	query := bson.D{notDeleted}
+	conditions := bson.A{}
+	if filter.Unresolved {
+		query = append(query, bson.E{Key: "acceptedanswerid", Value: bson.D{{Key: "$in", Value: bson.A{nil, ""}}}})
+	}
+	if filter.AuthorID != "" {
+		query = append(query, bson.E{Key: "question.userid", Value: filter.AuthorID})
+	}
+
+	createdOn := bson.D{}
+	if filter.CreatedFrom > 0 {
+		createdOn = append(createdOn, bson.E{Key: "$gte", Value: filter.CreatedFrom})
+	}
+	if filter.CreatedTo > 0 {
+		createdOn = append(createdOn, bson.E{Key: "$lte", Value: filter.CreatedTo})
+	}
+	if len(createdOn) > 0 {
+		query = append(query, bson.E{Key: "question.createdon", Value: createdOn})
+	}
+
+	if filter.Answered != nil {
+		answered := bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "answers.0", Value: bson.D{{Key: "$exists", Value: true}}}},
+			bson.D{{Key: "answer.id", Value: bson.D{{Key: "$nin", Value: bson.A{nil, ""}}}}},
+		}}}
+		if !*filter.Answered {
+			answered = bson.D{{Key: "$nor", Value: answered[0].Value}}
+		}
+		conditions = append(conditions, answered)
+	}
+	if filter.AnswererID != "" {
+		conditions = append(conditions, bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "answers.userid", Value: filter.AnswererID}},
+			bson.D{{Key: "answer.userid", Value: filter.AnswererID}},
+		}}})
+	}
+	if len(conditions) > 0 {
+		query = append(query, bson.E{Key: "$and", Value: conditions})
+	}
+	return query
+}
+
+func (r *repository) CreateAPIKey(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error) {
+	AKCollection := r.db.Collection(APIKeyCollection)
+	_, err := AKCollection.InsertOne(ctx, apiKey)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error creating a new API key in the database => %v", err.Error()))
+		return domain.APIKey{}, serverError(err, "There Was An Error Creating The API Key")
+	}
+
+	level.Info(r.log(ctx)).Log("msg", fmt.Sprintf("New API key created with ID [%v] for user [%v]", apiKey.ID, apiKey.UserID))
+	return apiKey, nil
+}
+
+func (r *repository) FindAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	var results []domain.APIKey
+	opts := options.Find().SetSort(bson.D{{Key: "createdon", Value: 1}})
+	AKCollection := r.db.Collection(APIKeyCollection)
+
+	cursor, err := AKCollection.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
+		return []domain.APIKey{}, serverError(err, "Internal Server Error! There was a problem processing your request.")
+	}
+
+	if err = cursor.All(ctx, &results); err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error parsing data from the database => %v", err.Error()))
+		return []domain.APIKey{}, serverError(err, "Internal Server Error! There was a problem processing your request.")
+	}
+	if results == nil {
+		results = []domain.APIKey{}
+	}
+	return results, nil
+}
+
+func (r *repository) FindAPIKeyByHash(ctx context.Context, hash string) (domain.APIKey, error) {
+	var result domain.APIKey
+	filter := bson.D{{Key: "hash", Value: hash}, {Key: "revokedon", Value: bson.D{{Key: "$in", Value: bson.A{nil, 0}}}}}
+	AKCollection := r.db.Collection(APIKeyCollection)
+
+	err := AKCollection.FindOne(ctx, filter).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return domain.APIKey{}, apperror.New(err, apperror.Unauthenticated, "Invalid API Key")
+	}
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error retrieving data from the database => %v", err.Error()))
+		return domain.APIKey{}, serverError(err, "There Was A Problem Processing Your Request")
 	}
 	return result, nil
 }
+
+func (r *repository) RevokeAPIKey(ctx context.Context, id string, revokedOn int64) (string, error) {
+	filter := bson.D{{Key: "id", Value: id}, {Key: "revokedon", Value: bson.D{{Key: "$in", Value: bson.A{nil, 0}}}}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "revokedon", Value: revokedOn}}}}
+	AKCollection := r.db.Collection(APIKeyCollection)
+
+	result, err := AKCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error revoking the API key %v => %v", id, err.Error()))
+		return "", serverError(err, "There Was An Error Revoking The API Key")
+	}
+
+	if result.MatchedCount == 0 {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("No active API key found by ID %v, method RevokeAPIKey", id))
+		return "", apperror.New(errors.New(fmt.Sprintf("No active API key found by ID %v", id)),
+			apperror.NotFound,
+			"No API Key Found")
+	}
+	return "API Key Revoked Successfully", nil
+}
+
+func (r *repository) TouchAPIKey(ctx context.Context, id string, usedOn int64) error {
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "lastusedon", Value: usedOn}}}}
+	AKCollection := r.db.Collection(APIKeyCollection)
+	_, err := AKCollection.UpdateOne(ctx, bson.D{{Key: "id", Value: id}}, update)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error recording the use of the API key %v => %v", id, err.Error()))
+		return serverError(err, "There Was A Problem Processing Your Request")
+	}
+	return nil
+}
+
+func (r *repository) Ping(ctx context.Context) error {
+	err := r.db.Client().Ping(ctx, readpref.Primary())
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error reaching the database => %v", err.Error()))
+		return apperror.New(err, apperror.Unavailable, "The Database Is Not Available")
+	}
+	return nil
+}
+
+func (r *repository) Close(ctx context.Context) error {
+	err := r.db.Client().Disconnect(ctx)
+	if err != nil {
+		level.Warn(r.log(ctx)).Log("msg", fmt.Sprintf("Error closing the connection with the database => %v", err.Error()))
+		return serverError(err, "There Was A Problem Closing The Database Connection")
+	}
+	return nil
+}
+
+//Documents written before a question could have many answers keep its only answer in the "answer" field,
+//this moves that answer into the "answers" list so the answer operations can work over a single schema.
+func (r *repository) upgradeLegacyAnswer(ctx context.Context, questionId string) error {
+	filter := bson.D{
+		{Key: "question.id", Value: questionId},
+		{Key: "answer.id", Value: bson.D{{Key: "$nin", Value: bson.A{nil, ""}}}},
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{
+			{Key: "answers", Value: bson.D{
+				{Key: "$concatArrays", Value: bson.A{
+					bson.A{"$answer"},
+					bson.D{{Key: "$ifNull", Value: bson.A{"$answers", bson.A{}}}},
+				}},
+			}},
+		}}},
+		{{Key: "$unset", Value: "answer"}},
+	}
+	QICollection := r.db.Collection(r.questions)
+	_, err := QICollection.UpdateOne(ctx, filter, pipeline)
+	return err
+}
@@ -69,14 +69,14 @@ var (
 		},
 	}
 
-	addAnswerDataQuestionAnsweredConflict = []testBody{
+	addAnswerDataQuestionNotFound = []testBody{
 		{
-			value:    domain.Answer{ID: "w0212", QuestionID: "1"},
-			expected: "The question already has an answer!",
+			value:    domain.Answer{ID: "w0212", QuestionID: "1212"},
+			expected: "No Question Found",
 		},
 		{
-			value:    domain.Answer{ID: "e312", QuestionID: "3"},
-			expected: "The question already has an answer!",
+			value:    domain.Answer{ID: "e312", QuestionID: "3212"},
+			expected: "No Question Found",
 		},
 	}
 
@@ -213,16 +213,16 @@ func TestFindByID_NotFound(t *testing.T) {
 func TestFindByUser_Success(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	mockRepo := mocks.NewMockRepository(mockCtrl)
-	mockRepo.EXPECT().FindByUser(ctx, "1").Return([]domain.QuestionInfo{{}, {}}, nil).Times(1)
-	mockRepo.EXPECT().FindByUser(ctx, "2").Return([]domain.QuestionInfo{{}}, nil).Times(1)
+	mockRepo.EXPECT().FindByUser(ctx, "1", gomock.Any()).Return(domain.QuestionPage{Questions: []domain.QuestionInfo{{}, {}}}, nil).Times(1)
+	mockRepo.EXPECT().FindByUser(ctx, "2", gomock.Any()).Return(domain.QuestionPage{Questions: []domain.QuestionInfo{{}}}, nil).Times(1)
 
 	for _, data := range findByUserDataSuccess {
 		userID := fmt.Sprintf("%v", data.value)
-		questions, err := mockRepo.FindByUser(ctx, userID)
+		page, err := mockRepo.FindByUser(ctx, userID, domain.Page{Limit: domain.DefaultPageLimit})
 		if err != nil {
 			t.Error(err.Error())
 		}
-		assert.Equal(t, data.expected, len(questions))
+		assert.Equal(t, data.expected, len(page.Questions))
 	}
 }
 
@@ -259,25 +259,25 @@ func TestCreateQuestion_IDConflict(t *testing.T) {
 func TestAddAnswer_Success(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	mockRepo := mocks.NewMockRepository(mockCtrl)
-	mockRepo.EXPECT().AddAnswer(ctx, gomock.Any()).Return(domain.QuestionInfo{Answer: domain.Answer{ID: "wq1212"}}, nil).Times(1)
-	mockRepo.EXPECT().AddAnswer(ctx, gomock.Any()).Return(domain.QuestionInfo{Answer: domain.Answer{ID: "wq1233"}}, nil).Times(1)
+	mockRepo.EXPECT().AddAnswer(ctx, gomock.Any()).Return(domain.QuestionInfo{Answers: []domain.Answer{{ID: "wq1212"}}}, nil).Times(1)
+	mockRepo.EXPECT().AddAnswer(ctx, gomock.Any()).Return(domain.QuestionInfo{Answers: []domain.Answer{{ID: "1"}, {ID: "wq1233"}}}, nil).Times(1)
 
 	for _, data := range addAnswerDataSuccess {
 		createdAnswer, err := mockRepo.AddAnswer(ctx, data.value.(domain.Answer))
 		if err != nil {
 			t.Error(err)
 		}
-		assert.Equal(t, data.expected, createdAnswer.Answer.ID)
+		assert.Equal(t, data.expected, createdAnswer.Answers[len(createdAnswer.Answers)-1].ID)
 	}
 }
 
-func TestAddAnswer_QuestionAnsweredConflict(t *testing.T) {
+func TestAddAnswer_QuestionNotFound(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	mockRepo := mocks.NewMockRepository(mockCtrl)
-	mockRepo.EXPECT().AddAnswer(ctx, gomock.Any()).Return(domain.QuestionInfo{}, errors.New("The question already has an answer!")).Times(1)
-	mockRepo.EXPECT().AddAnswer(ctx, gomock.Any()).Return(domain.QuestionInfo{}, errors.New("The question already has an answer!")).Times(1)
+	mockRepo.EXPECT().AddAnswer(ctx, gomock.Any()).Return(domain.QuestionInfo{}, errors.New("No Question Found")).Times(1)
+	mockRepo.EXPECT().AddAnswer(ctx, gomock.Any()).Return(domain.QuestionInfo{}, errors.New("No Question Found")).Times(1)
 
-	for _, data := range addAnswerDataQuestionAnsweredConflict {
+	for _, data := range addAnswerDataQuestionNotFound {
 		_, err := mockRepo.AddAnswer(ctx, data.value.(domain.Answer))
 		if err == nil {
 			t.Errorf("Error = [%v] expected", data.expected)
@@ -372,12 +372,12 @@ func TestUpdateQuestionInfo_NotFound(t *testing.T) {
 func TestDeleteQuestion_Success(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	mockRepo := mocks.NewMockRepository(mockCtrl)
-	mockRepo.EXPECT().Delete(ctx, "3").Return("Question Deleted Successfully!", nil).Times(1)
-	mockRepo.EXPECT().Delete(ctx, "2").Return("Question Deleted Successfully!", nil).Times(1)
+	mockRepo.EXPECT().Delete(ctx, "3", "1").Return("Question Deleted Successfully!", nil).Times(1)
+	mockRepo.EXPECT().Delete(ctx, "2", "1").Return("Question Deleted Successfully!", nil).Times(1)
 
 	for _, data := range deleteQuestionSuccess {
 		id := fmt.Sprintf("%v", data.value)
-		msg, err := mockRepo.Delete(ctx, id)
+		msg, err := mockRepo.Delete(ctx, id, "1")
 		if err != nil {
 			t.Error(err)
 		}
@@ -388,12 +388,12 @@ func TestDeleteQuestion_Success(t *testing.T) {
 func TestDeleteQuestion_NotFound(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	mockRepo := mocks.NewMockRepository(mockCtrl)
-	mockRepo.EXPECT().Delete(ctx, "333").Return("", errors.New("No Question Found")).Times(1)
-	mockRepo.EXPECT().Delete(ctx, "222").Return("", errors.New("No Question Found")).Times(1)
+	mockRepo.EXPECT().Delete(ctx, "333", "1").Return("", errors.New("No Question Found")).Times(1)
+	mockRepo.EXPECT().Delete(ctx, "222", "1").Return("", errors.New("No Question Found")).Times(1)
 
 	for _, data := range deleteQuestionNotFound {
 		id := fmt.Sprintf("%v", data.value)
-		_, err := mockRepo.Delete(ctx, id)
+		_, err := mockRepo.Delete(ctx, id, "1")
 		if err == nil {
 			t.Errorf("Error = [%v] expected", data.expected)
 		}
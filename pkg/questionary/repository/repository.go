@@ -12,24 +12,119 @@ import (
 //Each methods has its own validations and error handling.
 type Repository interface {
 
-	//Method that search all Questions in the database and returns the result.
-	FindAll(ctx context.Context) ([]domain.QuestionInfo, error)
+	//Method that search the Questions in the database that match the filter and returns a page of the result.
+	FindAll(ctx context.Context, filter domain.QuestionFilter, page domain.Page) (domain.QuestionPage, error)
 
 	//Method that search a Question in the database filter by its Unique ID
 	FindByID(ctx context.Context, id string) (domain.QuestionInfo, error)
 
-	//Method that find all Questions in the database filter by the User ID
-	FindByUser(ctx context.Context, userId string) ([]domain.QuestionInfo, error)
+	//Method that find the Questions in the database filter by the User ID and returns a page of the result
+	FindByUser(ctx context.Context, userId string, page domain.Page) (domain.QuestionPage, error)
+
+	//Method that find all Questions in the database that have the given tag
+	FindByTag(ctx context.Context, tag string) ([]domain.QuestionInfo, error)
+
+	//Method that search the Questions whose statement or answers contain the words of the query,
+	//the results that match the filter are sorted from the most to the least relevant
+	Search(ctx context.Context, query string, filter domain.QuestionFilter) ([]domain.SearchResult, error)
+
+	//Method that returns the tags used by the Questions and how many Questions use each one
+	FindTags(ctx context.Context) ([]domain.TagCount, error)
 
 	//Method that Save a new Question in the database
 	Create(ctx context.Context, question domain.Question) (domain.Question, error)
 
-	//Method that update the statement and/or answer of an existing Question in the database
+	//Method that update the statement, tags and/or answer of an existing Question in the database,
+	//every edit of the statement or the answer is recorded as a revision
 	Update(ctx context.Context, questionInfo domain.QuestionInfo) (domain.QuestionInfo, error)
 
-	//Method that delete a Question filter by its unique ID
-	Delete(ctx context.Context, id string) (string, error)
+	//Method that mark a Question as deleted by a user, the deleted Questions are excluded from every search
+	//but they are kept with its comments and revisions until they are purged
+	Delete(ctx context.Context, id string, userId string) (string, error)
+
+	//Method that restore a deleted Question filter by its unique ID
+	Restore(ctx context.Context, id string) (domain.QuestionInfo, error)
+
+	//Method that permanently delete the Questions deleted before the given unix time with its comments and revisions,
+	//returns the number of purged Questions
+	Purge(ctx context.Context, deletedBefore int64) (int64, error)
 
 	//Method that add a new answer to an existing Question
 	AddAnswer(ctx context.Context, answer domain.Answer) (domain.QuestionInfo, error)
+
+	//Method that search all the answers of a Question filter by the Question ID
+	FindAnswers(ctx context.Context, questionId string) ([]domain.Answer, error)
+
+	//Method that update the text of an existing answer of a Question and records the edit as a revision
+	UpdateAnswer(ctx context.Context, answer domain.Answer) (domain.Answer, error)
+
+	//Method that delete an answer of a Question filter by its unique ID
+	DeleteAnswer(ctx context.Context, questionId string, answerId string) (string, error)
+
+	//Method that mark an answer as the accepted answer of a Question
+	AcceptAnswer(ctx context.Context, questionId string, answerId string) (domain.QuestionInfo, error)
+
+	//Method that remove the accepted answer of a Question
+	UnacceptAnswer(ctx context.Context, questionId string) (domain.QuestionInfo, error)
+
+	//Method that register the vote of a user over a question or an answer and updates its score,
+	//a previous vote of the same user over the same target is replaced
+	Vote(ctx context.Context, vote domain.Vote) (domain.Score, error)
+
+	//Method that remove the vote of a user over a question or an answer and updates its score
+	RetractVote(ctx context.Context, targetType string, targetId string, userId string) (domain.Score, error)
+
+	//Method that save a new comment over a Question or an answer
+	AddComment(ctx context.Context, comment domain.Comment) (domain.Comment, error)
+
+	//Method that search all the comments of a Question or an answer filter by its type and ID
+	FindComments(ctx context.Context, parentType string, parentId string) ([]domain.Comment, error)
+
+	//Method that search a comment of a Question filter by its unique ID
+	FindComment(ctx context.Context, questionId string, commentId string) (domain.Comment, error)
+
+	//Method that update the text of an existing comment of a Question
+	UpdateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error)
+
+	//Method that delete a comment of a Question filter by its unique ID
+	DeleteComment(ctx context.Context, questionId string, commentId string) (string, error)
+
+	//Method that search all the revisions of a Question and its answers filter by the Question ID
+	FindRevisions(ctx context.Context, questionId string) ([]domain.Revision, error)
+
+	//Method that search a revision of a Question filter by its number
+	FindRevision(ctx context.Context, questionId string, number int64) (domain.Revision, error)
+
+	//Method that restore the text that the target of a revision had before its edit, the restore is recorded as a new revision
+	RestoreRevision(ctx context.Context, revision domain.Revision, userId string) (domain.QuestionInfo, error)
+
+	//Method that search the roles granted to a user, a user without roles has an empty list
+	FindRoles(ctx context.Context, userId string) (domain.UserRoles, error)
+
+	//Method that grant a role to a user, granting a role that the user already has does nothing
+	GrantRole(ctx context.Context, userId string, role string) (domain.UserRoles, error)
+
+	//Method that revoke a role granted to a user
+	RevokeRole(ctx context.Context, userId string, role string) (domain.UserRoles, error)
+
+	//Method that save a new API key with the hash of its key
+	CreateAPIKey(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error)
+
+	//Method that search all the API keys, including the revoked ones
+	FindAPIKeys(ctx context.Context) ([]domain.APIKey, error)
+
+	//Method that search an API key that has not been revoked filter by the hash of its key
+	FindAPIKeyByHash(ctx context.Context, hash string) (domain.APIKey, error)
+
+	//Method that revoke an API key by its unique ID, the revoked keys are kept for auditing
+	RevokeAPIKey(ctx context.Context, id string, revokedOn int64) (string, error)
+
+	//Method that record the last time that an API key was used
+	TouchAPIKey(ctx context.Context, id string, usedOn int64) error
+
+	//Method that checks that the database can be reached
+	Ping(ctx context.Context) error
+
+	//Method that close the connection with the database, the repository can not be used after it is closed
+	Close(ctx context.Context) error
 }
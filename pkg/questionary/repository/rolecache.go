@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+)
+
+//Number of users kept in the role cache before the expired roles are removed
+const roleCacheSweepSize = 1024
+
+//This is the cache of the roles of the users, the authorization of the endpoints reads the roles of the user on every request.
+//The roles are kept for the TTL and the grants and revokes made through the cache drop the roles of the user,
+//so the changes made by another server are seen once the TTL expires. The other calls go to the next repository.
+type roleCache struct {
+	Repository
+	ttl   time.Duration
+	now   func() time.Time
+	mu    sync.Mutex
+	roles map[string]cachedRoles
+	//Incremented on each grant or revoke, the roles read before a change are not stored
+	changes uint64
+}
+
+type cachedRoles struct {
+	roles     domain.UserRoles
+	expiresOn time.Time
+}
+
+//A TTL that is not positive disables the cache
+func NewRoleCache(ttl time.Duration) Middleware {
+	return func(next Repository) Repository {
+		if ttl <= 0 {
+			return next
+		}
+		return &roleCache{
+			Repository: next,
+			ttl:        ttl,
+			now:        time.Now,
+			roles:      map[string]cachedRoles{},
+		}
+	}
+}
+
+func (c *roleCache) FindRoles(ctx context.Context, userId string) (domain.UserRoles, error) {
+	c.mu.Lock()
+	cached, ok := c.roles[userId]
+	changes := c.changes
+	c.mu.Unlock()
+	if ok && c.now().Before(cached.expiresOn) {
+		return cached.roles, nil
+	}
+
+	userRoles, err := c.Repository.FindRoles(ctx, userId)
+	if err != nil {
+		return userRoles, err
+	}
+	c.store(userId, userRoles, changes)
+	return userRoles, nil
+}
+
+func (c *roleCache) GrantRole(ctx context.Context, userId string, role string) (domain.UserRoles, error) {
+	defer c.drop(userId)
+	return c.Repository.GrantRole(ctx, userId, role)
+}
+
+func (c *roleCache) RevokeRole(ctx context.Context, userId string, role string) (domain.UserRoles, error) {
+	defer c.drop(userId)
+	return c.Repository.RevokeRole(ctx, userId, role)
+}
+
+func (c *roleCache) store(userId string, userRoles domain.UserRoles, changes uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if changes != c.changes {
+		return
+	}
+	now := c.now()
+	if len(c.roles) >= roleCacheSweepSize {
+		for id, cached := range c.roles {
+			if !now.Before(cached.expiresOn) {
+				delete(c.roles, id)
+			}
+		}
+	}
+	c.roles[userId] = cachedRoles{roles: userRoles, expiresOn: now.Add(c.ttl)}
+}
+
+func (c *roleCache) drop(userId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.changes++
+	delete(c.roles, userId)
+}
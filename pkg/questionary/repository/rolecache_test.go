@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+//A repository that only stores the roles and counts the reads of the roles
+type rolesRepository struct {
+	Repository
+	roles map[string][]string
+	reads int
+}
+
+func (r *rolesRepository) FindRoles(ctx context.Context, userId string) (domain.UserRoles, error) {
+	r.reads++
+	return domain.UserRoles{UserID: userId, Roles: r.roles[userId]}, nil
+}
+
+func (r *rolesRepository) GrantRole(ctx context.Context, userId string, role string) (domain.UserRoles, error) {
+	r.roles[userId] = append(r.roles[userId], role)
+	return domain.UserRoles{UserID: userId, Roles: r.roles[userId]}, nil
+}
+
+func (r *rolesRepository) RevokeRole(ctx context.Context, userId string, role string) (domain.UserRoles, error) {
+	delete(r.roles, userId)
+	return domain.UserRoles{UserID: userId}, nil
+}
+
+func TestRoleCache(t *testing.T) {
+	ctx := context.Background()
+	next := &rolesRepository{roles: map[string][]string{"1": {domain.RoleModerator}}}
+	now := time.Now()
+	cache := NewRoleCache(time.Minute)(next).(*roleCache)
+	cache.now = func() time.Time { return now }
+
+	//The roles are read once until the TTL expires
+	for i := 0; i < 3; i++ {
+		userRoles, err := cache.FindRoles(ctx, "1")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{domain.RoleModerator}, userRoles.Roles)
+	}
+	assert.Equal(t, 1, next.reads)
+
+	now = now.Add(time.Minute)
+	_, err := cache.FindRoles(ctx, "1")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, next.reads)
+
+	//The grants and revokes are seen at once
+	_, err = cache.GrantRole(ctx, "1", domain.RoleAdmin)
+	assert.Nil(t, err)
+	userRoles, err := cache.FindRoles(ctx, "1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{domain.RoleModerator, domain.RoleAdmin}, userRoles.Roles)
+
+	_, err = cache.RevokeRole(ctx, "1", domain.RoleAdmin)
+	assert.Nil(t, err)
+	userRoles, err = cache.FindRoles(ctx, "1")
+	assert.Nil(t, err)
+	assert.Empty(t, userRoles.Roles)
+	assert.Equal(t, 4, next.reads)
+}
+
+func TestRoleCache_Disabled(t *testing.T) {
+	next := &rolesRepository{roles: map[string][]string{}}
+	assert.Equal(t, Repository(next), NewRoleCache(0)(next))
+}
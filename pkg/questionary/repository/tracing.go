@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+//This is the tracing layer of the database
+//Each call to the repository has its own span, the spans of the MongoDB commands that it runs are its children.
+type tracingMiddleware struct {
+	next   Repository
+	tracer trace.Tracer
+	prefix string
+}
+
+func NewTracingMiddleware(tracer trace.Tracer) Middleware {
+	return func(next Repository) Repository {
+		return &tracingMiddleware{
+			next:   next,
+			tracer: tracer,
+			prefix: "repository.",
+		}
+	}
+}
+
+func (mw *tracingMiddleware) FindAll(ctx context.Context, filter domain.QuestionFilter, page domain.Page) (result domain.QuestionPage, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindAll")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.FindAll(ctx, filter, page)
+}
+
+func (mw *tracingMiddleware) FindByID(ctx context.Context, id string) (result domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindByID")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.FindByID(ctx, id)
+}
+
+func (mw *tracingMiddleware) FindByUser(ctx context.Context, userId string, page domain.Page) (result domain.QuestionPage, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindByUser")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.FindByUser(ctx, userId, page)
+}
+
+func (mw *tracingMiddleware) FindByTag(ctx context.Context, tag string) (result []domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindByTag")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.FindByTag(ctx, tag)
+}
+
+func (mw *tracingMiddleware) Search(ctx context.Context, query string, filter domain.QuestionFilter) (result []domain.SearchResult, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Search")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.Search(ctx, query, filter)
+}
+
+func (mw *tracingMiddleware) FindTags(ctx context.Context) (result []domain.TagCount, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindTags")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.FindTags(ctx)
+}
+
+func (mw *tracingMiddleware) Create(ctx context.Context, question domain.Question) (result domain.Question, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Create")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.Create(ctx, question)
+}
+
+func (mw *tracingMiddleware) Update(ctx context.Context, questionInfo domain.QuestionInfo) (result domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Update")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.Update(ctx, questionInfo)
+}
+
+func (mw *tracingMiddleware) Delete(ctx context.Context, id string, userId string) (result string, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Delete")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.Delete(ctx, id, userId)
+}
+
+func (mw *tracingMiddleware) Restore(ctx context.Context, id string) (result domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Restore")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.Restore(ctx, id)
+}
+
+func (mw *tracingMiddleware) Purge(ctx context.Context, deletedBefore int64) (result int64, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Purge")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.Purge(ctx, deletedBefore)
+}
+
+func (mw *tracingMiddleware) AddAnswer(ctx context.Context, answer domain.Answer) (result domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"AddAnswer")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.AddAnswer(ctx, answer)
+}
+
+func (mw *tracingMiddleware) FindAnswers(ctx context.Context, questionId string) (result []domain.Answer, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindAnswers")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.FindAnswers(ctx, questionId)
+}
+
+func (mw *tracingMiddleware) UpdateAnswer(ctx context.Context, answer domain.Answer) (result domain.Answer, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"UpdateAnswer")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.UpdateAnswer(ctx, answer)
+}
+
+func (mw *tracingMiddleware) DeleteAnswer(ctx context.Context, questionId string, answerId string) (result string, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"DeleteAnswer")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.DeleteAnswer(ctx, questionId, answerId)
+}
+
+func (mw *tracingMiddleware) AcceptAnswer(ctx context.Context, questionId string, answerId string) (result domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"AcceptAnswer")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.AcceptAnswer(ctx, questionId, answerId)
+}
+
+func (mw *tracingMiddleware) UnacceptAnswer(ctx context.Context, questionId string) (result domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"UnacceptAnswer")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.UnacceptAnswer(ctx, questionId)
+}
+
+func (mw *tracingMiddleware) Vote(ctx context.Context, vote domain.Vote) (result domain.Score, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Vote")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.Vote(ctx, vote)
+}
+
+func (mw *tracingMiddleware) RetractVote(ctx context.Context, targetType string, targetId string, userId string) (result domain.Score, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"RetractVote")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.RetractVote(ctx, targetType, targetId, userId)
+}
+
+func (mw *tracingMiddleware) AddComment(ctx context.Context, comment domain.Comment) (result domain.Comment, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"AddComment")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.AddComment(ctx, comment)
+}
+
+func (mw *tracingMiddleware) FindComments(ctx context.Context, parentType string, parentId string) (result []domain.Comment, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindComments")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.FindComments(ctx, parentType, parentId)
+}
+
+func (mw *tracingMiddleware) FindComment(ctx context.Context, questionId string, commentId string) (result domain.Comment, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindComment")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.FindComment(ctx, questionId, commentId)
+}
+
+func (mw *tracingMiddleware) UpdateComment(ctx context.Context, comment domain.Comment) (result domain.Comment, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"UpdateComment")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.UpdateComment(ctx, comment)
+}
+
+func (mw *tracingMiddleware) DeleteComment(ctx context.Context, questionId string, commentId string) (result string, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"DeleteComment")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.DeleteComment(ctx, questionId, commentId)
+}
+
+func (mw *tracingMiddleware) FindRevisions(ctx context.Context, questionId string) (result []domain.Revision, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindRevisions")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.FindRevisions(ctx, questionId)
+}
+
+func (mw *tracingMiddleware) FindRevision(ctx context.Context, questionId string, number int64) (result domain.Revision, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindRevision")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.FindRevision(ctx, questionId, number)
+}
+
+func (mw *tracingMiddleware) RestoreRevision(ctx context.Context, revision domain.Revision, userId string) (result domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"RestoreRevision")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.RestoreRevision(ctx, revision, userId)
+}
+
+func (mw *tracingMiddleware) FindRoles(ctx context.Context, userId string) (result domain.UserRoles, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindRoles")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.FindRoles(ctx, userId)
+}
+
+func (mw *tracingMiddleware) GrantRole(ctx context.Context, userId string, role string) (result domain.UserRoles, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"GrantRole")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.GrantRole(ctx, userId, role)
+}
+
+func (mw *tracingMiddleware) RevokeRole(ctx context.Context, userId string, role string) (result domain.UserRoles, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"RevokeRole")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.RevokeRole(ctx, userId, role)
+}
+
+func (mw *tracingMiddleware) CreateAPIKey(ctx context.Context, apiKey domain.APIKey) (result domain.APIKey, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"CreateAPIKey")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.CreateAPIKey(ctx, apiKey)
+}
+
+func (mw *tracingMiddleware) FindAPIKeys(ctx context.Context) (result []domain.APIKey, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindAPIKeys")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.FindAPIKeys(ctx)
+}
+
+func (mw *tracingMiddleware) FindAPIKeyByHash(ctx context.Context, hash string) (result domain.APIKey, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindAPIKeyByHash")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.FindAPIKeyByHash(ctx, hash)
+}
+
+func (mw *tracingMiddleware) RevokeAPIKey(ctx context.Context, id string, revokedOn int64) (result string, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"RevokeAPIKey")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.RevokeAPIKey(ctx, id, revokedOn)
+}
+
+func (mw *tracingMiddleware) TouchAPIKey(ctx context.Context, id string, usedOn int64) (err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"TouchAPIKey")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.TouchAPIKey(ctx, id, usedOn)
+}
+
+func (mw *tracingMiddleware) Ping(ctx context.Context) (err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Ping")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.Ping(ctx)
+}
+
+func (mw *tracingMiddleware) Close(ctx context.Context) (err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Close")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.next.Close(ctx)
+}
@@ -0,0 +1,94 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/log"
+	"github.com/gofrs/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+//This is the request ID of the API
+//Each request has an ID, the one sent by the client on the X-Request-ID header (x-request-id metadata on gRPC)
+//or a new one, that is returned in the response and added to the logs and errors of the request.
+const (
+	Header      = "X-Request-ID"
+	MetadataKey = "x-request-id"
+)
+
+//The IDs sent by the clients longer than this are replaced by a new ID
+const maxLength = 128
+
+type contextKey int
+
+const requestIDKey contextKey = 0
+
+//Method that returns a copy of the context with the request ID
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+//Method that returns the request ID of the context, or an empty string when it has none
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+//Method that returns the logger with the request ID of the context
+func Logger(ctx context.Context, logger log.Logger) log.Logger {
+	if id := FromContext(ctx); id != "" {
+		return log.With(logger, "requestId", id)
+	}
+	return logger
+}
+
+//Method that returns the ID sent by the client when it is valid or a new ID
+func resolve(id string) string {
+	if valid(id) {
+		return id
+	}
+	newID, err := uuid.NewV4()
+	if err != nil {
+		return ""
+	}
+	return newID.String()
+}
+
+//The IDs can only have letters, digits and the characters "-", "_", "." and ":" so they are safe to log and to send back
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' || c == ':') {
+			return false
+		}
+	}
+	return true
+}
+
+//Method that returns a router middleware that sets the request ID of each HTTP request and its response
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := resolve(r.Header.Get(Header))
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(WithID(r.Context(), id)))
+	})
+}
+
+//Method that returns a gRPC interceptor that sets the request ID of each call and its response header
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var id string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(MetadataKey); len(values) > 0 {
+				id = values[0]
+			}
+		}
+		id = resolve(id)
+		grpc.SetHeader(ctx, metadata.Pairs(MetadataKey, id))
+		return handler(WithID(ctx, id), req)
+	}
+}
@@ -0,0 +1,67 @@
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	handler := HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(FromContext(r.Context())))
+	}))
+
+	serve := func(id string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/question", nil)
+		if id != "" {
+			r.Header.Set(Header, id)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve("client-id:1")
+	assert.Equal(t, "client-id:1", w.Header().Get(Header))
+	assert.Equal(t, "client-id:1", w.Body.String())
+
+	for _, id := range []string{"", "with spaces", "line\nbreak", strings.Repeat("a", maxLength+1)} {
+		w = serve(id)
+		assert.Len(t, w.Header().Get(Header), 36, id)
+		assert.Equal(t, w.Header().Get(Header), w.Body.String())
+	}
+	assert.NotEqual(t, serve("").Body.String(), serve("").Body.String())
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return FromContext(ctx), nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/QuestionaryService/FindAll"}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "client-id"))
+	id, err := UnaryServerInterceptor()(ctx, nil, info, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, "client-id", id)
+
+	id, err = UnaryServerInterceptor()(context.Background(), nil, info, handler)
+	assert.Nil(t, err)
+	assert.Len(t, id, 36)
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewLogfmtLogger(&buf)
+
+	Logger(context.Background(), logger).Log("msg", "without id")
+	Logger(WithID(context.Background(), "1"), logger).Log("msg", "with id")
+	assert.Equal(t, "msg=\"without id\"\nrequestId=1 msg=\"with id\"\n", buf.String())
+}
@@ -0,0 +1,66 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/logging"
+	httpError "github.com/ismaeljpv/qa-api/pkg/questionary/transport/http/error"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+//This is the admin HTTP Server, it runs on its own port so its routes are not exposed with the API
+//The metrics of the service are served on /metrics in the Prometheus text format,
+//and the log level can be read and changed on /loglevel.
+//The log level is only changed with the admin token as a bearer token, without a token it is only changed from the same host.
+func NewAdminServer(logLevel *logging.Level, token string) http.Handler {
+	router := mux.NewRouter()
+	router.Methods("GET").Path("/metrics").Handler(promhttp.Handler())
+	router.Methods("GET").Path("/loglevel").Handler(logging.LevelHandler(logLevel))
+	router.Methods("PUT").Path("/loglevel").Handler(requireAdmin(token, logging.LevelHandler(logLevel)))
+	return router
+}
+
+//Method that rejects the requests without the admin token, or the requests from another host when there is no token
+func requireAdmin(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token == "" && !fromLoopback(r) {
+			writeError(w, httpError.NewClientError(errors.New("Admin request from another host"),
+				http.StatusForbidden,
+				"The Admin Token Is Required To Change The Server From Another Host"))
+			return
+		}
+
+		bearer := "Bearer " + token
+		if token != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(bearer)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, httpError.NewClientError(errors.New("Invalid admin token"),
+				http.StatusUnauthorized,
+				"Invalid Admin Token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func fromLoopback(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func writeError(w http.ResponseWriter, err httpError.ClientError) {
+	status, headers := err.ResponseHeaders()
+	body, _ := err.ResponseBody()
+	for k, v := range headers {
+		w.Header().Set(k, v)
+	}
+	w.WriteHeader(status)
+	w.Write(body)
+}
@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ismaeljpv/qa-api/pkg/questionary/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func setLevel(handler http.Handler, remoteAddr string, authorization string, level string) int {
+	r := httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"`+level+`"}`))
+	r.RemoteAddr = remoteAddr
+	if authorization != "" {
+		r.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w.Code
+}
+
+func TestLogLevel_WithoutToken(t *testing.T) {
+	lvl, _ := logging.NewLevel("info")
+	handler := NewAdminServer(lvl, "")
+
+	//Another host can read the level but can not change it
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/loglevel", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusForbidden, setLevel(handler, "192.0.2.1:4000", "", "debug"))
+	assert.Equal(t, "info", lvl.String())
+
+	assert.Equal(t, http.StatusOK, setLevel(handler, "127.0.0.1:4000", "", "debug"))
+	assert.Equal(t, "debug", lvl.String())
+	assert.Equal(t, http.StatusOK, setLevel(handler, "[::1]:4000", "", "warn"))
+	assert.Equal(t, "warn", lvl.String())
+}
+
+func TestLogLevel_WithToken(t *testing.T) {
+	lvl, _ := logging.NewLevel("info")
+	handler := NewAdminServer(lvl, "s3cr3t")
+
+	assert.Equal(t, http.StatusUnauthorized, setLevel(handler, "192.0.2.1:4000", "", "debug"))
+	assert.Equal(t, http.StatusUnauthorized, setLevel(handler, "127.0.0.1:4000", "Bearer wrong", "debug"))
+	assert.Equal(t, "info", lvl.String())
+
+	assert.Equal(t, http.StatusOK, setLevel(handler, "192.0.2.1:4000", "Bearer s3cr3t", "debug"))
+	assert.Equal(t, "debug", lvl.String())
+}
@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"net/http"
+
+	pb "github.com/ismaeljpv/qa-api/pkg/questionary/transport/grpc/protobuff"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+//Method that returns the compiled descriptor set of the gRPC API, with the questionary.proto file and the files that it imports,
+//the generic gRPC tools can call the API with it (like "grpcurl -protoset questionary.protoset").
+func DescriptorSet() ([]byte, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+
+	//The imported files go before the files that import them
+	var add func(file protoreflect.FileDescriptor)
+	add = func(file protoreflect.FileDescriptor) {
+		if seen[file.Path()] {
+			return
+		}
+		seen[file.Path()] = true
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+	}
+	add(pb.File_pkg_questionary_transport_grpc_protobuff_questionary_proto)
+
+	return proto.Marshal(set)
+}
+
+//Method that returns the handler that serves the descriptor set of the gRPC API as a binary file
+func DescriptorHandler() http.Handler {
+	descriptor, err := DescriptorSet()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			http.Error(w, "There was an error procesing your request", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", `attachment; filename="questionary.protoset"`)
+		w.Write(descriptor)
+	})
+}
@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestDescriptorSet(t *testing.T) {
+	data, err := DescriptorSet()
+	assert.Nil(t, err)
+
+	var set descriptorpb.FileDescriptorSet
+	assert.Nil(t, proto.Unmarshal(data, &set))
+	assert.Len(t, set.File, 2)
+	assert.Equal(t, "google/protobuf/wrappers.proto", set.File[0].GetName())
+
+	//The set is self contained, so it can be loaded without the proto files
+	files, err := protodesc.NewFiles(&set)
+	assert.Nil(t, err)
+	service, err := files.FindDescriptorByName(ServiceName)
+	assert.Nil(t, err)
+	assert.NotNil(t, service)
+}
+
+func TestDescriptorHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	DescriptorHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/grpc/descriptor", nil))
+
+	expected, _ := DescriptorSet()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/octet-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, expected, w.Body.Bytes())
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/go-kit/kit/log"
 
 	"github.com/go-kit/kit/transport/grpc"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/ratelimit"
 	transport "github.com/ismaeljpv/qa-api/pkg/questionary/transport/grpc"
 	pb "github.com/ismaeljpv/qa-api/pkg/questionary/transport/grpc/protobuff"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -15,58 +16,244 @@ import (
 // This is the gRPC server configuration and initialization layer
 
 type gRPCServer struct {
-	findAll    grpc.Handler
-	findByID   grpc.Handler
-	findByUser grpc.Handler
-	create     grpc.Handler
-	addAnswer  grpc.Handler
-	update     grpc.Handler
-	delete     grpc.Handler
+	findAll          grpc.Handler
+	findByID         grpc.Handler
+	findByUser       grpc.Handler
+	findByTag        grpc.Handler
+	findTags         grpc.Handler
+	search           grpc.Handler
+	create           grpc.Handler
+	addAnswer        grpc.Handler
+	update           grpc.Handler
+	delete           grpc.Handler
+	restore          grpc.Handler
+	findAnswers      grpc.Handler
+	updateAnswer     grpc.Handler
+	deleteAnswer     grpc.Handler
+	acceptAnswer     grpc.Handler
+	unacceptAnswer   grpc.Handler
+	vote             grpc.Handler
+	retractVote      grpc.Handler
+	addComment       grpc.Handler
+	findComments     grpc.Handler
+	updateComment    grpc.Handler
+	deleteComment    grpc.Handler
+	listRevisions    grpc.Handler
+	rollbackRevision grpc.Handler
+	findRoles        grpc.Handler
+	grantRole        grpc.Handler
+	revokeRole       grpc.Handler
+	createAPIKey     grpc.Handler
+	listAPIKeys      grpc.Handler
+	revokeAPIKey     grpc.Handler
 	pb.UnimplementedQuestionaryServiceServer
 }
 
+//Methods that can be called without a bearer token, the rest of the methods require authentication
+var PublicMethods = []string{
+	"/QuestionaryService/FindAll",
+	"/QuestionaryService/FindByUser",
+	"/QuestionaryService/FindByTag",
+	"/QuestionaryService/FindTags",
+	"/QuestionaryService/Search",
+	"/QuestionaryService/FindByID",
+	"/QuestionaryService/FindAnswers",
+	"/QuestionaryService/FindComments",
+	"/QuestionaryService/ListRevisions",
+	"/grpc.health.v1.Health/Check",
+}
+
+//Name of the gRPC service, its health is reported with the standard health service
+const ServiceName = "QuestionaryService"
+
 func NewGRPCServer(endpoints transport.Endpoints, logger log.Logger) pb.QuestionaryServiceServer {
+	options := ratelimit.GRPCServerOptions()
 
 	return &gRPCServer{
 		findAll: grpc.NewServer(
 			endpoints.FindAllQuestions,
-			transport.DecodeRequest,
-			transport.EncodeGetQuestionsResponse,
+			transport.DecodeFindAllQuestionsRequest,
+			transport.EncodeQuestionPageResponse,
+			options...,
 		),
 		findByID: grpc.NewServer(
 			endpoints.FindQuestionById,
 			transport.DecodeIDParamRequest,
 			transport.EncodeQuestionInfoResponse,
+			options...,
 		),
 		findByUser: grpc.NewServer(
 			endpoints.FindQuestionsByUser,
 			transport.DecodeFindQuestionByUserRequest,
+			transport.EncodeQuestionPageResponse,
+			options...,
+		),
+		findByTag: grpc.NewServer(
+			endpoints.FindQuestionsByTag,
+			transport.DecodeFindQuestionsByTagRequest,
 			transport.EncodeGetQuestionsResponse,
+			options...,
+		),
+		findTags: grpc.NewServer(
+			endpoints.FindTags,
+			transport.DecodeRequest,
+			transport.EncodeTagsResponse,
+			options...,
+		),
+		search: grpc.NewServer(
+			endpoints.SearchQuestions,
+			transport.DecodeSearchQuestionsRequest,
+			transport.EncodeSearchResultsResponse,
+			options...,
 		),
 		create: grpc.NewServer(
 			endpoints.CreateQuestion,
 			transport.DecodeCreateQuestionRequest,
 			transport.EncodeQuestionResponse,
+			options...,
 		),
 		addAnswer: grpc.NewServer(
 			endpoints.AddAnswer,
 			transport.DecodeAddAnswerRequest,
 			transport.EncodeQuestionInfoResponse,
+			options...,
 		),
 		update: grpc.NewServer(
 			endpoints.UpdateQuestion,
 			transport.DecodeUpdateQuestionRequest,
 			transport.EncodeQuestionInfoResponse,
+			options...,
 		),
 		delete: grpc.NewServer(
 			endpoints.DeleteQuestion,
+			transport.DecodeDeleteQuestionRequest,
+			transport.EncodeGenericMessageResponse,
+			options...,
+		),
+		restore: grpc.NewServer(
+			endpoints.RestoreQuestion,
 			transport.DecodeIDParamRequest,
+			transport.EncodeQuestionInfoResponse,
+			options...,
+		),
+		findAnswers: grpc.NewServer(
+			endpoints.FindAnswers,
+			transport.DecodeIDParamRequest,
+			transport.EncodeAnswersResponse,
+			options...,
+		),
+		updateAnswer: grpc.NewServer(
+			endpoints.UpdateAnswer,
+			transport.DecodeUpdateAnswerRequest,
+			transport.EncodeAnswerResponse,
+			options...,
+		),
+		deleteAnswer: grpc.NewServer(
+			endpoints.DeleteAnswer,
+			transport.DecodeAnswerParamRequest,
+			transport.EncodeGenericMessageResponse,
+			options...,
+		),
+		acceptAnswer: grpc.NewServer(
+			endpoints.AcceptAnswer,
+			transport.DecodeAcceptAnswerRequest,
+			transport.EncodeQuestionInfoResponse,
+			options...,
+		),
+		unacceptAnswer: grpc.NewServer(
+			endpoints.UnacceptAnswer,
+			transport.DecodeUnacceptAnswerRequest,
+			transport.EncodeQuestionInfoResponse,
+			options...,
+		),
+		vote: grpc.NewServer(
+			endpoints.Vote,
+			transport.DecodeVoteRequest,
+			transport.EncodeScoreResponse,
+			options...,
+		),
+		retractVote: grpc.NewServer(
+			endpoints.RetractVote,
+			transport.DecodeVoteRequest,
+			transport.EncodeScoreResponse,
+			options...,
+		),
+		addComment: grpc.NewServer(
+			endpoints.AddComment,
+			transport.DecodeAddCommentRequest,
+			transport.EncodeCommentResponse,
+			options...,
+		),
+		findComments: grpc.NewServer(
+			endpoints.FindComments,
+			transport.DecodeFindCommentsRequest,
+			transport.EncodeCommentsResponse,
+			options...,
+		),
+		updateComment: grpc.NewServer(
+			endpoints.UpdateComment,
+			transport.DecodeUpdateCommentRequest,
+			transport.EncodeCommentResponse,
+			options...,
+		),
+		deleteComment: grpc.NewServer(
+			endpoints.DeleteComment,
+			transport.DecodeCommentParamRequest,
 			transport.EncodeGenericMessageResponse,
+			options...,
+		),
+		listRevisions: grpc.NewServer(
+			endpoints.FindRevisions,
+			transport.DecodeIDParamRequest,
+			transport.EncodeRevisionsResponse,
+			options...,
+		),
+		rollbackRevision: grpc.NewServer(
+			endpoints.RollbackRevision,
+			transport.DecodeRollbackRevisionRequest,
+			transport.EncodeQuestionInfoResponse,
+			options...,
+		),
+		findRoles: grpc.NewServer(
+			endpoints.FindRoles,
+			transport.DecodeFindRolesRequest,
+			transport.EncodeUserRolesResponse,
+			options...,
+		),
+		grantRole: grpc.NewServer(
+			endpoints.GrantRole,
+			transport.DecodeRoleRequest,
+			transport.EncodeUserRolesResponse,
+			options...,
+		),
+		revokeRole: grpc.NewServer(
+			endpoints.RevokeRole,
+			transport.DecodeRoleRequest,
+			transport.EncodeUserRolesResponse,
+			options...,
+		),
+		createAPIKey: grpc.NewServer(
+			endpoints.CreateAPIKey,
+			transport.DecodeCreateAPIKeyRequest,
+			transport.EncodeAPIKeyResponse,
+			options...,
+		),
+		listAPIKeys: grpc.NewServer(
+			endpoints.FindAPIKeys,
+			transport.DecodeRequest,
+			transport.EncodeAPIKeysResponse,
+			options...,
+		),
+		revokeAPIKey: grpc.NewServer(
+			endpoints.RevokeAPIKey,
+			transport.DecodeIDParamRequest,
+			transport.EncodeGenericMessageResponse,
+			options...,
 		),
 	}
 }
 
-func (server *gRPCServer) FindAll(ctx context.Context, msg *pb.EmptyMessage) (*pb.Questions, error) {
+func (server *gRPCServer) FindAll(ctx context.Context, msg *pb.FindAllRequest) (*pb.Questions, error) {
 	_, resp, err := server.findAll.ServeGRPC(ctx, msg)
 	if err != nil {
 		return &pb.Questions{}, err
@@ -92,8 +279,8 @@ func (server *gRPCServer) FindByID(ctx context.Context, id *wrapperspb.StringVal
 	return questionInfo, nil
 }
 
-func (server *gRPCServer) FindByUser(ctx context.Context, id *wrapperspb.StringValue) (*pb.Questions, error) {
-	_, resp, err := server.findByUser.ServeGRPC(ctx, id)
+func (server *gRPCServer) FindByUser(ctx context.Context, msg *pb.UserPageRequest) (*pb.Questions, error) {
+	_, resp, err := server.findByUser.ServeGRPC(ctx, msg)
 	if err != nil {
 		return &pb.Questions{}, err
 	}
@@ -105,6 +292,45 @@ func (server *gRPCServer) FindByUser(ctx context.Context, id *wrapperspb.StringV
 	return questions, nil
 }
 
+func (server *gRPCServer) FindByTag(ctx context.Context, tag *wrapperspb.StringValue) (*pb.Questions, error) {
+	_, resp, err := server.findByTag.ServeGRPC(ctx, tag)
+	if err != nil {
+		return &pb.Questions{}, err
+	}
+
+	questions, ok := resp.(*pb.Questions)
+	if !ok {
+		return &pb.Questions{}, errors.New("Error parsing the response for FindByTag() method")
+	}
+	return questions, nil
+}
+
+func (server *gRPCServer) FindTags(ctx context.Context, msg *pb.EmptyMessage) (*pb.Tags, error) {
+	_, resp, err := server.findTags.ServeGRPC(ctx, msg)
+	if err != nil {
+		return &pb.Tags{}, err
+	}
+
+	tags, ok := resp.(*pb.Tags)
+	if !ok {
+		return &pb.Tags{}, errors.New("Error parsing the response for FindTags() method")
+	}
+	return tags, nil
+}
+
+func (server *gRPCServer) Search(ctx context.Context, msg *pb.SearchRequest) (*pb.SearchResults, error) {
+	_, resp, err := server.search.ServeGRPC(ctx, msg)
+	if err != nil {
+		return &pb.SearchResults{}, err
+	}
+
+	results, ok := resp.(*pb.SearchResults)
+	if !ok {
+		return &pb.SearchResults{}, errors.New("Error parsing the response for Search() method")
+	}
+	return results, nil
+}
+
 func (server *gRPCServer) Create(ctx context.Context, question *pb.Question) (*pb.Question, error) {
 	_, resp, err := server.create.ServeGRPC(ctx, question)
 	if err != nil {
@@ -144,8 +370,8 @@ func (server *gRPCServer) Update(ctx context.Context, questionInfo *pb.QuestionU
 	return updatedInfo, nil
 }
 
-func (server *gRPCServer) Delete(ctx context.Context, id *wrapperspb.StringValue) (*pb.GenericMessage, error) {
-	_, resp, err := server.delete.ServeGRPC(ctx, id)
+func (server *gRPCServer) Delete(ctx context.Context, msg *pb.DeleteRequest) (*pb.GenericMessage, error) {
+	_, resp, err := server.delete.ServeGRPC(ctx, msg)
 	if err != nil {
 		return &pb.GenericMessage{}, err
 	}
@@ -156,3 +382,263 @@ func (server *gRPCServer) Delete(ctx context.Context, id *wrapperspb.StringValue
 	}
 	return message, nil
 }
+
+func (server *gRPCServer) Restore(ctx context.Context, id *wrapperspb.StringValue) (*pb.QuestionInfo, error) {
+	_, resp, err := server.restore.ServeGRPC(ctx, id)
+	if err != nil {
+		return &pb.QuestionInfo{}, err
+	}
+
+	questionInfo, ok := resp.(*pb.QuestionInfo)
+	if !ok {
+		return &pb.QuestionInfo{}, errors.New("Error parsing the response for Restore() method")
+	}
+	return questionInfo, nil
+}
+
+func (server *gRPCServer) FindAnswers(ctx context.Context, id *wrapperspb.StringValue) (*pb.Answers, error) {
+	_, resp, err := server.findAnswers.ServeGRPC(ctx, id)
+	if err != nil {
+		return &pb.Answers{}, err
+	}
+
+	answers, ok := resp.(*pb.Answers)
+	if !ok {
+		return &pb.Answers{}, errors.New("Error parsing the response for FindAnswers() method")
+	}
+	return answers, nil
+}
+
+func (server *gRPCServer) UpdateAnswer(ctx context.Context, answer *pb.Answer) (*pb.Answer, error) {
+	_, resp, err := server.updateAnswer.ServeGRPC(ctx, answer)
+	if err != nil {
+		return &pb.Answer{}, err
+	}
+
+	updatedAnswer, ok := resp.(*pb.Answer)
+	if !ok {
+		return &pb.Answer{}, errors.New("Error parsing the response for UpdateAnswer() method")
+	}
+	return updatedAnswer, nil
+}
+
+func (server *gRPCServer) DeleteAnswer(ctx context.Context, id *pb.AnswerID) (*pb.GenericMessage, error) {
+	_, resp, err := server.deleteAnswer.ServeGRPC(ctx, id)
+	if err != nil {
+		return &pb.GenericMessage{}, err
+	}
+
+	message, ok := resp.(*pb.GenericMessage)
+	if !ok {
+		return &pb.GenericMessage{}, errors.New("Error parsing the response for DeleteAnswer() method")
+	}
+	return message, nil
+}
+
+func (server *gRPCServer) AcceptAnswer(ctx context.Context, req *pb.AcceptAnswerRequest) (*pb.QuestionInfo, error) {
+	_, resp, err := server.acceptAnswer.ServeGRPC(ctx, req)
+	if err != nil {
+		return &pb.QuestionInfo{}, err
+	}
+
+	questionInfo, ok := resp.(*pb.QuestionInfo)
+	if !ok {
+		return &pb.QuestionInfo{}, errors.New("Error parsing the response for AcceptAnswer() method")
+	}
+	return questionInfo, nil
+}
+
+func (server *gRPCServer) UnacceptAnswer(ctx context.Context, req *pb.AcceptAnswerRequest) (*pb.QuestionInfo, error) {
+	_, resp, err := server.unacceptAnswer.ServeGRPC(ctx, req)
+	if err != nil {
+		return &pb.QuestionInfo{}, err
+	}
+
+	questionInfo, ok := resp.(*pb.QuestionInfo)
+	if !ok {
+		return &pb.QuestionInfo{}, errors.New("Error parsing the response for UnacceptAnswer() method")
+	}
+	return questionInfo, nil
+}
+
+func (server *gRPCServer) Vote(ctx context.Context, req *pb.VoteRequest) (*pb.Score, error) {
+	_, resp, err := server.vote.ServeGRPC(ctx, req)
+	if err != nil {
+		return &pb.Score{}, err
+	}
+
+	score, ok := resp.(*pb.Score)
+	if !ok {
+		return &pb.Score{}, errors.New("Error parsing the response for Vote() method")
+	}
+	return score, nil
+}
+
+func (server *gRPCServer) RetractVote(ctx context.Context, req *pb.VoteRequest) (*pb.Score, error) {
+	_, resp, err := server.retractVote.ServeGRPC(ctx, req)
+	if err != nil {
+		return &pb.Score{}, err
+	}
+
+	score, ok := resp.(*pb.Score)
+	if !ok {
+		return &pb.Score{}, errors.New("Error parsing the response for RetractVote() method")
+	}
+	return score, nil
+}
+
+func (server *gRPCServer) AddComment(ctx context.Context, req *pb.Comment) (*pb.Comment, error) {
+	_, resp, err := server.addComment.ServeGRPC(ctx, req)
+	if err != nil {
+		return &pb.Comment{}, err
+	}
+
+	comment, ok := resp.(*pb.Comment)
+	if !ok {
+		return &pb.Comment{}, errors.New("Error parsing the response for AddComment() method")
+	}
+	return comment, nil
+}
+
+func (server *gRPCServer) FindComments(ctx context.Context, req *pb.CommentParent) (*pb.Comments, error) {
+	_, resp, err := server.findComments.ServeGRPC(ctx, req)
+	if err != nil {
+		return &pb.Comments{}, err
+	}
+
+	comments, ok := resp.(*pb.Comments)
+	if !ok {
+		return &pb.Comments{}, errors.New("Error parsing the response for FindComments() method")
+	}
+	return comments, nil
+}
+
+func (server *gRPCServer) UpdateComment(ctx context.Context, req *pb.Comment) (*pb.Comment, error) {
+	_, resp, err := server.updateComment.ServeGRPC(ctx, req)
+	if err != nil {
+		return &pb.Comment{}, err
+	}
+
+	comment, ok := resp.(*pb.Comment)
+	if !ok {
+		return &pb.Comment{}, errors.New("Error parsing the response for UpdateComment() method")
+	}
+	return comment, nil
+}
+
+func (server *gRPCServer) DeleteComment(ctx context.Context, req *pb.CommentID) (*pb.GenericMessage, error) {
+	_, resp, err := server.deleteComment.ServeGRPC(ctx, req)
+	if err != nil {
+		return &pb.GenericMessage{}, err
+	}
+
+	message, ok := resp.(*pb.GenericMessage)
+	if !ok {
+		return &pb.GenericMessage{}, errors.New("Error parsing the response for DeleteComment() method")
+	}
+	return message, nil
+}
+
+func (server *gRPCServer) ListRevisions(ctx context.Context, id *wrapperspb.StringValue) (*pb.Revisions, error) {
+	_, resp, err := server.listRevisions.ServeGRPC(ctx, id)
+	if err != nil {
+		return &pb.Revisions{}, err
+	}
+
+	revisions, ok := resp.(*pb.Revisions)
+	if !ok {
+		return &pb.Revisions{}, errors.New("Error parsing the response for ListRevisions() method")
+	}
+	return revisions, nil
+}
+
+func (server *gRPCServer) RollbackRevision(ctx context.Context, req *pb.RollbackRevisionRequest) (*pb.QuestionInfo, error) {
+	_, resp, err := server.rollbackRevision.ServeGRPC(ctx, req)
+	if err != nil {
+		return &pb.QuestionInfo{}, err
+	}
+
+	questionInfo, ok := resp.(*pb.QuestionInfo)
+	if !ok {
+		return &pb.QuestionInfo{}, errors.New("Error parsing the response for RollbackRevision() method")
+	}
+	return questionInfo, nil
+}
+
+func (server *gRPCServer) FindRoles(ctx context.Context, userId *wrapperspb.StringValue) (*pb.UserRoles, error) {
+	_, resp, err := server.findRoles.ServeGRPC(ctx, userId)
+	if err != nil {
+		return &pb.UserRoles{}, err
+	}
+
+	roles, ok := resp.(*pb.UserRoles)
+	if !ok {
+		return &pb.UserRoles{}, errors.New("Error parsing the response for FindRoles() method")
+	}
+	return roles, nil
+}
+
+func (server *gRPCServer) GrantRole(ctx context.Context, req *pb.RoleRequest) (*pb.UserRoles, error) {
+	_, resp, err := server.grantRole.ServeGRPC(ctx, req)
+	if err != nil {
+		return &pb.UserRoles{}, err
+	}
+
+	roles, ok := resp.(*pb.UserRoles)
+	if !ok {
+		return &pb.UserRoles{}, errors.New("Error parsing the response for GrantRole() method")
+	}
+	return roles, nil
+}
+
+func (server *gRPCServer) RevokeRole(ctx context.Context, req *pb.RoleRequest) (*pb.UserRoles, error) {
+	_, resp, err := server.revokeRole.ServeGRPC(ctx, req)
+	if err != nil {
+		return &pb.UserRoles{}, err
+	}
+
+	roles, ok := resp.(*pb.UserRoles)
+	if !ok {
+		return &pb.UserRoles{}, errors.New("Error parsing the response for RevokeRole() method")
+	}
+	return roles, nil
+}
+
+func (server *gRPCServer) CreateAPIKey(ctx context.Context, req *pb.APIKey) (*pb.APIKey, error) {
+	_, resp, err := server.createAPIKey.ServeGRPC(ctx, req)
+	if err != nil {
+		return &pb.APIKey{}, err
+	}
+
+	apiKey, ok := resp.(*pb.APIKey)
+	if !ok {
+		return &pb.APIKey{}, errors.New("Error parsing the response for CreateAPIKey() method")
+	}
+	return apiKey, nil
+}
+
+func (server *gRPCServer) ListAPIKeys(ctx context.Context, msg *pb.EmptyMessage) (*pb.APIKeys, error) {
+	_, resp, err := server.listAPIKeys.ServeGRPC(ctx, msg)
+	if err != nil {
+		return &pb.APIKeys{}, err
+	}
+
+	apiKeys, ok := resp.(*pb.APIKeys)
+	if !ok {
+		return &pb.APIKeys{}, errors.New("Error parsing the response for ListAPIKeys() method")
+	}
+	return apiKeys, nil
+}
+
+func (server *gRPCServer) RevokeAPIKey(ctx context.Context, id *wrapperspb.StringValue) (*pb.GenericMessage, error) {
+	_, resp, err := server.revokeAPIKey.ServeGRPC(ctx, id)
+	if err != nil {
+		return &pb.GenericMessage{}, err
+	}
+
+	message, ok := resp.(*pb.GenericMessage)
+	if !ok {
+		return &pb.GenericMessage{}, errors.New("Error parsing the response for RevokeAPIKey() method")
+	}
+	return message, nil
+}
@@ -6,26 +6,67 @@ import (
 
 	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/gorilla/mux"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/auth"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/health"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/ratelimit"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/requestid"
+	grpcserver "github.com/ismaeljpv/qa-api/pkg/questionary/server/grpc"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/tracing"
 	transport "github.com/ismaeljpv/qa-api/pkg/questionary/transport/http"
 )
 
 //This is the HTTP Server that will handle all avaliable operations of the API
 //The endpoints configuration is used to instantiate all routes.
-func NewHTTPServer(ctx context.Context, endpoints transport.Endpoints) http.Handler {
+//The GET routes are public, the rest of the routes require a valid bearer token.
+//The responses of the rate limited routes have the quota of the client in its headers.
+//The liveness and readiness of the server are reported on /healthz and /readyz,
+//and the descriptor set of the gRPC API is served on /grpc/descriptor.
+func NewHTTPServer(ctx context.Context, endpoints transport.Endpoints, verifier *auth.Verifier, checker *health.Checker) http.Handler {
 
 	router := mux.NewRouter()
+	router.Use(requestid.HTTPMiddleware)
+	router.Use(tracing.HTTPMiddleware)
 	router.Use(commonMiddleware)
-	serverOpts := []httptransport.ServerOption{
-		httptransport.ServerErrorEncoder(transport.HTTPErrorHandler),
-	}
+	router.Use(auth.HTTPMiddleware(verifier, func(r *http.Request) bool {
+		return r.Method == http.MethodGet
+	}))
+	serverOpts := ratelimit.HTTPServerOptions(transport.HTTPErrorHandler)
+	pageOpts := append([]httptransport.ServerOption{
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+	}, serverOpts...)
+
+	router.Methods("GET").Path("/healthz").Handler(health.LivenessHandler())
+	router.Methods("GET").Path("/readyz").Handler(health.ReadinessHandler(checker))
+	router.Methods("GET").Path("/grpc/descriptor").Handler(grpcserver.DescriptorHandler())
 
 	router.Methods("GET").Path("/question").Handler(httptransport.NewServer(
 		endpoints.FindAllQuestions,
+		transport.DecodeFindAllQuestionsRequest,
+		transport.EncodeQuestionPageResponse,
+		pageOpts...,
+	))
+
+	router.Methods("GET").Path("/question/search").Handler(httptransport.NewServer(
+		endpoints.SearchQuestions,
+		transport.DecodeSearchQuestionsRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("GET").Path("/question/tags").Handler(httptransport.NewServer(
+		endpoints.FindTags,
 		transport.DecodeRequest,
 		transport.EncodeResponse,
 		serverOpts...,
 	))
 
+	router.Methods("GET").Path("/question/tag/{tag}").Handler(httptransport.NewServer(
+		endpoints.FindQuestionsByTag,
+		transport.DecodeFindQuestionsByTagRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
 	router.Methods("GET").Path("/question/{id}").Handler(httptransport.NewServer(
 		endpoints.FindQuestionById,
 		transport.DecodeIDParamRequest,
@@ -36,8 +77,8 @@ func NewHTTPServer(ctx context.Context, endpoints transport.Endpoints) http.Hand
 	router.Methods("GET").Path("/question/user/{userId}").Handler(httptransport.NewServer(
 		endpoints.FindQuestionsByUser,
 		transport.DecodeFindQuestionByUserRequest,
-		transport.EncodeResponse,
-		serverOpts...,
+		transport.EncodeQuestionPageResponse,
+		pageOpts...,
 	))
 
 	router.Methods("POST").Path("/question").Handler(httptransport.NewServer(
@@ -54,6 +95,139 @@ func NewHTTPServer(ctx context.Context, endpoints transport.Endpoints) http.Hand
 		serverOpts...,
 	))
 
+	router.Methods("GET").Path("/question/{id}/answers").Handler(httptransport.NewServer(
+		endpoints.FindAnswers,
+		transport.DecodeIDParamRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("POST").Path("/question/{id}/answers").Handler(httptransport.NewServer(
+		endpoints.AddAnswer,
+		transport.DecodeAddQuestionAnswerRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("PUT").Path("/question/{id}/answers/{answerId}").Handler(httptransport.NewServer(
+		endpoints.UpdateAnswer,
+		transport.DecodeUpdateAnswerRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("DELETE").Path("/question/{id}/answers/{answerId}").Handler(httptransport.NewServer(
+		endpoints.DeleteAnswer,
+		transport.DecodeAnswerParamRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("POST").Path("/question/{id}/answers/{answerId}/accept").Handler(httptransport.NewServer(
+		endpoints.AcceptAnswer,
+		transport.DecodeAcceptAnswerRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("POST").Path("/question/{id}/unaccept").Handler(httptransport.NewServer(
+		endpoints.UnacceptAnswer,
+		transport.DecodeUnacceptAnswerRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("POST").Path("/question/{id}/vote").Handler(httptransport.NewServer(
+		endpoints.Vote,
+		transport.DecodeVoteRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("DELETE").Path("/question/{id}/vote").Handler(httptransport.NewServer(
+		endpoints.RetractVote,
+		transport.DecodeVoteRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("POST").Path("/question/{id}/answers/{answerId}/vote").Handler(httptransport.NewServer(
+		endpoints.Vote,
+		transport.DecodeVoteRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("DELETE").Path("/question/{id}/answers/{answerId}/vote").Handler(httptransport.NewServer(
+		endpoints.RetractVote,
+		transport.DecodeVoteRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("GET").Path("/question/{id}/comments").Handler(httptransport.NewServer(
+		endpoints.FindComments,
+		transport.DecodeFindCommentsRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("POST").Path("/question/{id}/comments").Handler(httptransport.NewServer(
+		endpoints.AddComment,
+		transport.DecodeAddCommentRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("PUT").Path("/question/{id}/comments/{commentId}").Handler(httptransport.NewServer(
+		endpoints.UpdateComment,
+		transport.DecodeUpdateCommentRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("DELETE").Path("/question/{id}/comments/{commentId}").Handler(httptransport.NewServer(
+		endpoints.DeleteComment,
+		transport.DecodeCommentParamRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("GET").Path("/question/{id}/answers/{answerId}/comments").Handler(httptransport.NewServer(
+		endpoints.FindComments,
+		transport.DecodeFindCommentsRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("POST").Path("/question/{id}/answers/{answerId}/comments").Handler(httptransport.NewServer(
+		endpoints.AddComment,
+		transport.DecodeAddCommentRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("GET").Path("/question/{id}/revisions").Handler(httptransport.NewServer(
+		endpoints.FindRevisions,
+		transport.DecodeIDParamRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("POST").Path("/question/{id}/revisions/{number}/rollback").Handler(httptransport.NewServer(
+		endpoints.RollbackRevision,
+		transport.DecodeRollbackRevisionRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("POST").Path("/question/{id}/restore").Handler(httptransport.NewServer(
+		endpoints.RestoreQuestion,
+		transport.DecodeIDParamRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
 	router.Methods("PUT").Path("/question/{id}").Handler(httptransport.NewServer(
 		endpoints.UpdateQuestion,
 		transport.DecodeUpdateQuestionRequest,
@@ -63,6 +237,48 @@ func NewHTTPServer(ctx context.Context, endpoints transport.Endpoints) http.Hand
 
 	router.Methods("DELETE").Path("/question/{id}").Handler(httptransport.NewServer(
 		endpoints.DeleteQuestion,
+		transport.DecodeDeleteQuestionRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("GET").Path("/admin/users/{userId}/roles").Handler(httptransport.NewServer(
+		endpoints.FindRoles,
+		transport.DecodeRoleRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("PUT").Path("/admin/users/{userId}/roles/{role}").Handler(httptransport.NewServer(
+		endpoints.GrantRole,
+		transport.DecodeRoleRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("DELETE").Path("/admin/users/{userId}/roles/{role}").Handler(httptransport.NewServer(
+		endpoints.RevokeRole,
+		transport.DecodeRoleRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("POST").Path("/admin/apikeys").Handler(httptransport.NewServer(
+		endpoints.CreateAPIKey,
+		transport.DecodeCreateAPIKeyRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("GET").Path("/admin/apikeys").Handler(httptransport.NewServer(
+		endpoints.FindAPIKeys,
+		transport.DecodeRequest,
+		transport.EncodeResponse,
+		serverOpts...,
+	))
+
+	router.Methods("DELETE").Path("/admin/apikeys/{id}").Handler(httptransport.NewServer(
+		endpoints.RevokeAPIKey,
 		transport.DecodeIDParamRequest,
 		transport.EncodeResponse,
 		serverOpts...,
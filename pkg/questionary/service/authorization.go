@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/auth"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/requestid"
+)
+
+//A Middleware decorates a Service with cross cutting behavior,
+//the methods that are not overridden are passed to the next Service.
+type Middleware func(Service) Service
+
+//The comments are not a target of votes or revisions, the type is only used by the authorization errors
+const targetComment = "comment"
+
+//This is the authorization layer of the Questionary API
+//Only the author of a Question can change its statement and tags, delete it or roll it back to a revision,
+//only the author of an answer can edit its text, delete it or roll it back, and only the author of a comment
+//can edit or delete it, unless the user is a moderator or an admin.
+//The user of each request and its roles are the ones resolved by the transport layer,
+//the methods without a user in its arguments use the subject of the request.
+type authorizationMiddleware struct {
+	Service
+	logger log.Logger
+}
+
+func NewAuthorizationMiddleware(logger log.Logger) Middleware {
+	return func(next Service) Service {
+		return &authorizationMiddleware{
+			Service: next,
+			logger:  logger,
+		}
+	}
+}
+
+func (mw *authorizationMiddleware) Update(ctx context.Context, questionInfo domain.QuestionInfo, id string) (domain.QuestionInfo, error) {
+	current, err := mw.Service.FindByID(ctx, id)
+	if err != nil {
+		return domain.QuestionInfo{}, err
+	}
+
+	tagsChanged := questionInfo.Question.Tags != nil &&
+		strings.Join(current.Question.Tags, ",") != strings.Join(questionInfo.Question.Tags, ",")
+	if current.Question.Statement != questionInfo.Question.Statement || tagsChanged {
+		if err := mw.checkOwner(ctx, current.Question.UserID, questionInfo.Question.UserID, domain.TargetQuestion, id); err != nil {
+			return domain.QuestionInfo{}, err
+		}
+	}
+
+	current.NormalizeAnswers()
+	for _, answer := range current.Answers {
+		if answer.ID == questionInfo.Answer.ID && answer.Answer != questionInfo.Answer.Answer {
+			if err := mw.checkOwner(ctx, answer.UserID, questionInfo.Answer.UserID, domain.TargetAnswer, answer.ID); err != nil {
+				return domain.QuestionInfo{}, err
+			}
+		}
+	}
+	return mw.Service.Update(ctx, questionInfo, id)
+}
+
+func (mw *authorizationMiddleware) Delete(ctx context.Context, id string, userId string) (string, error) {
+	current, err := mw.Service.FindByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	if err := mw.checkOwner(ctx, current.Question.UserID, userId, domain.TargetQuestion, id); err != nil {
+		return "", err
+	}
+	return mw.Service.Delete(ctx, id, userId)
+}
+
+func (mw *authorizationMiddleware) UpdateAnswer(ctx context.Context, answer domain.Answer) (domain.Answer, error) {
+	answers, err := mw.Service.FindAnswers(ctx, answer.QuestionID)
+	if err != nil {
+		return domain.Answer{}, err
+	}
+
+	for _, current := range answers {
+		if current.ID == answer.ID {
+			if err := mw.checkOwner(ctx, current.UserID, answer.UserID, domain.TargetAnswer, answer.ID); err != nil {
+				return domain.Answer{}, err
+			}
+		}
+	}
+	return mw.Service.UpdateAnswer(ctx, answer)
+}
+
+func (mw *authorizationMiddleware) DeleteAnswer(ctx context.Context, questionId string, answerId string) (string, error) {
+	answers, err := mw.Service.FindAnswers(ctx, questionId)
+	if err != nil {
+		return "", err
+	}
+
+	userId, _ := auth.Subject(ctx)
+	for _, current := range answers {
+		if current.ID == answerId {
+			if err := mw.checkOwner(ctx, current.UserID, userId, domain.TargetAnswer, answerId); err != nil {
+				return "", err
+			}
+		}
+	}
+	return mw.Service.DeleteAnswer(ctx, questionId, answerId)
+}
+
+func (mw *authorizationMiddleware) UpdateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	current, err := mw.Service.FindComment(ctx, comment.QuestionID, comment.ID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	if err := mw.checkOwner(ctx, current.UserID, comment.UserID, targetComment, comment.ID); err != nil {
+		return domain.Comment{}, err
+	}
+	return mw.Service.UpdateComment(ctx, comment)
+}
+
+func (mw *authorizationMiddleware) DeleteComment(ctx context.Context, questionId string, commentId string) (string, error) {
+	current, err := mw.Service.FindComment(ctx, questionId, commentId)
+	if err != nil {
+		return "", err
+	}
+
+	userId, _ := auth.Subject(ctx)
+	if err := mw.checkOwner(ctx, current.UserID, userId, targetComment, commentId); err != nil {
+		return "", err
+	}
+	return mw.Service.DeleteComment(ctx, questionId, commentId)
+}
+
+//The rollback of a revision changes the text of its target, so only the author of the target can do it
+func (mw *authorizationMiddleware) RollbackRevision(ctx context.Context, questionId string, number int64, userId string) (domain.QuestionInfo, error) {
+	revisions, err := mw.Service.FindRevisions(ctx, questionId)
+	if err != nil {
+		return domain.QuestionInfo{}, err
+	}
+
+	for _, revision := range revisions {
+		if revision.Number != number {
+			continue
+		}
+		ownerId, err := mw.authorOf(ctx, questionId, revision.TargetType, revision.TargetID)
+		if err != nil {
+			return domain.QuestionInfo{}, err
+		}
+		if err := mw.checkOwner(ctx, ownerId, userId, revision.TargetType, revision.TargetID); err != nil {
+			return domain.QuestionInfo{}, err
+		}
+	}
+	return mw.Service.RollbackRevision(ctx, questionId, number, userId)
+}
+
+//Method that returns the author of a Question or of one of its answers
+func (mw *authorizationMiddleware) authorOf(ctx context.Context, questionId string, targetType string, targetId string) (string, error) {
+	current, err := mw.Service.FindByID(ctx, questionId)
+	if err != nil {
+		return "", err
+	}
+	if targetType == domain.TargetQuestion {
+		return current.Question.UserID, nil
+	}
+
+	current.NormalizeAnswers()
+	for _, answer := range current.Answers {
+		if answer.ID == targetId {
+			return answer.UserID, nil
+		}
+	}
+	return "", nil
+}
+
+func (mw *authorizationMiddleware) checkOwner(ctx context.Context, ownerId string, userId string, targetType string, targetId string) error {
+	if auth.HasRole(ctx, ModeratorRoles...) {
+		return nil
+	}
+	if userId == "" || ownerId != userId {
+		level.Warn(requestid.Logger(ctx, mw.logger)).Log("msg", fmt.Sprintf("User [%v] is not the author of the %v [%v], method checkOwner", userId, targetType, targetId))
+		msg := "Only The Author Of The Question Can Modify It"
+		switch targetType {
+		case domain.TargetAnswer:
+			msg = "Only The Author Of The Answer Can Modify It"
+		case targetComment:
+			msg = "Only The Author Of The Comment Can Modify It"
+		}
+		return apperror.New(errors.New("Forbidden"), apperror.Forbidden, msg)
+	}
+	return nil
+}
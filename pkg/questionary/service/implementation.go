@@ -2,17 +2,22 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	"github.com/gofrs/uuid"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/repository"
-	httpError "github.com/ismaeljpv/qa-api/pkg/questionary/transport/http/error"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/requestid"
 )
 
 //This is the business logic implementation of the Questionary API
@@ -30,10 +35,19 @@ func NewService(rep repository.Repository, logger log.Logger) Service {
 	}
 }
 
-func (s *service) FindAll(ctx context.Context) ([]domain.QuestionInfo, error) {
-	questions, err := s.repository.FindAll(ctx)
+//The logs of a request have its request ID
+func (s *service) log(ctx context.Context) log.Logger {
+	return requestid.Logger(ctx, s.logger)
+}
+
+func (s *service) FindAll(ctx context.Context, filter domain.QuestionFilter, page domain.Page) (domain.QuestionPage, error) {
+	if err := validateListing(filter, page); err != nil {
+		return domain.QuestionPage{Questions: []domain.QuestionInfo{}}, err
+	}
+	page.Normalize()
+	questions, err := s.repository.FindAll(ctx, filter, page)
 	if err != nil {
-		return []domain.QuestionInfo{}, err
+		return domain.QuestionPage{Questions: []domain.QuestionInfo{}}, err
 	}
 	return questions, nil
 }
@@ -46,23 +60,77 @@ func (s *service) FindByID(ctx context.Context, id string) (domain.QuestionInfo,
 	return questionInfo, nil
 }
 
-func (s *service) FindByUser(ctx context.Context, userId string) ([]domain.QuestionInfo, error) {
-	userQuestions, err := s.repository.FindByUser(ctx, userId)
+func (s *service) FindByUser(ctx context.Context, userId string, page domain.Page) (domain.QuestionPage, error) {
+	if err := validateListing(domain.QuestionFilter{}, page); err != nil {
+		return domain.QuestionPage{Questions: []domain.QuestionInfo{}}, err
+	}
+	page.Normalize()
+	userQuestions, err := s.repository.FindByUser(ctx, userId, page)
 	if err != nil {
-		return []domain.QuestionInfo{}, err
+		return domain.QuestionPage{Questions: []domain.QuestionInfo{}}, err
 	}
 	return userQuestions, nil
 }
 
+func validateListing(filter domain.QuestionFilter, page domain.Page) error {
+	if !page.ValidSort() {
+		return apperror.New(errors.New("Invalid sort"),
+			apperror.Invalid,
+			"The sort must be newest, oldest or score")
+	}
+	if filter.CreatedFrom > 0 && filter.CreatedTo > 0 && filter.CreatedFrom > filter.CreatedTo {
+		return apperror.New(errors.New("Invalid createdOn range"),
+			apperror.Invalid,
+			"The createdOn range is not valid")
+	}
+	return nil
+}
+
+func (s *service) FindByTag(ctx context.Context, tag string) ([]domain.QuestionInfo, error) {
+	tagQuestions, err := s.repository.FindByTag(ctx, domain.NormalizeTag(tag))
+	if err != nil {
+		return []domain.QuestionInfo{}, err
+	}
+	return tagQuestions, nil
+}
+
+func (s *service) Search(ctx context.Context, query string, filter domain.QuestionFilter) ([]domain.SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []domain.SearchResult{}, apperror.New(errors.New("Empty search query"),
+			apperror.Invalid,
+			"The search query is required")
+	}
+
+	if err := validateListing(filter, domain.Page{}); err != nil {
+		return []domain.SearchResult{}, err
+	}
+
+	results, err := s.repository.Search(ctx, query, filter)
+	if err != nil {
+		return []domain.SearchResult{}, err
+	}
+	return results, nil
+}
+
+func (s *service) FindTags(ctx context.Context) ([]domain.TagCount, error) {
+	tags, err := s.repository.FindTags(ctx)
+	if err != nil {
+		return []domain.TagCount{}, err
+	}
+	return tags, nil
+}
+
 func (s *service) Create(ctx context.Context, question domain.Question) (domain.Question, error) {
 	uuid, idErr := uuid.NewV4()
 	if idErr != nil {
-		level.Warn(s.logger).Log("msg", "Error creating uuid for Question, method Create")
-		return domain.Question{}, httpError.NewServerError(idErr, "Internal Server Error! There was a problem processing your request.")
+		level.Warn(s.log(ctx)).Log("msg", "Error creating uuid for Question, method Create")
+		return domain.Question{}, apperror.New(idErr, apperror.Internal, "Internal Server Error! There was a problem processing your request.")
 	}
 
 	question.ID = uuid.String()
 	question.CreatedOn = time.Now().Unix()
+	question.NormalizeTags()
 	createdQuestion, err := s.repository.Create(ctx, question)
 	if err != nil {
 		return createdQuestion, err
@@ -72,19 +140,20 @@ func (s *service) Create(ctx context.Context, question domain.Question) (domain.
 
 func (s *service) Update(ctx context.Context, questionInfo domain.QuestionInfo, id string) (domain.QuestionInfo, error) {
 	if questionInfo.Question.ID != id {
-		level.Warn(s.logger).Log("msg", fmt.Sprintf("The Path Param ID doesnt match with the body ID [%v!=%v], method update", questionInfo.Question.ID, id))
-		return domain.QuestionInfo{}, httpError.NewClientError(errors.New("Invalid Request"),
-			http.StatusBadRequest,
+		level.Warn(s.log(ctx)).Log("msg", fmt.Sprintf("The Path Param ID doesnt match with the body ID [%v!=%v], method update", questionInfo.Question.ID, id))
+		return domain.QuestionInfo{}, apperror.New(errors.New("Invalid Request"),
+			apperror.Invalid,
 			"There is a inconsistency with the information of the request")
 	}
 
-	if questionInfo.Answer.ID == "" {
-		level.Warn(s.logger).Log("msg", "The answer provided in the request doesnt have an ID, method update")
-		return domain.QuestionInfo{}, httpError.NewClientError(errors.New("Invalid Request"),
-			http.StatusBadRequest,
+	if questionInfo.HasAnswerUpdate() && questionInfo.Answer.ID == "" {
+		level.Warn(s.log(ctx)).Log("msg", "The answer provided in the request doesnt have an ID, method update")
+		return domain.QuestionInfo{}, apperror.New(errors.New("Invalid Request"),
+			apperror.Invalid,
 			"The answer passed to update is not valid")
 	}
 
+	questionInfo.Question.NormalizeTags()
 	updatedQuestion, err := s.repository.Update(ctx, questionInfo)
 	if err != nil {
 		return updatedQuestion, err
@@ -93,19 +162,41 @@ func (s *service) Update(ctx context.Context, questionInfo domain.QuestionInfo,
 	return updatedQuestion, nil
 }
 
-func (s *service) Delete(ctx context.Context, id string) (string, error) {
-	msg, err := s.repository.Delete(ctx, id)
+func (s *service) Delete(ctx context.Context, id string, userId string) (string, error) {
+	if userId == "" {
+		return "", apperror.New(errors.New("Invalid Request"),
+			apperror.Invalid,
+			"The user that deletes the question is required")
+	}
+
+	msg, err := s.repository.Delete(ctx, id, userId)
 	if err != nil {
 		return "", err
 	}
 	return msg, nil
 }
 
+func (s *service) Restore(ctx context.Context, id string) (domain.QuestionInfo, error) {
+	questionInfo, err := s.repository.Restore(ctx, id)
+	if err != nil {
+		return domain.QuestionInfo{}, err
+	}
+	return questionInfo, nil
+}
+
+func (s *service) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	purged, err := s.repository.Purge(ctx, time.Now().Add(-retention).Unix())
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
 func (s *service) AddAnswer(ctx context.Context, answer domain.Answer) (domain.QuestionInfo, error) {
 	uuid, idErr := uuid.NewV4()
 	if idErr != nil {
-		level.Warn(s.logger).Log("msg", "Error creating uuid for Answer, method AddAnswer")
-		return domain.QuestionInfo{}, httpError.NewServerError(idErr, "Internal Server Error! There was a problem processing your request.")
+		level.Warn(s.log(ctx)).Log("msg", "Error creating uuid for Answer, method AddAnswer")
+		return domain.QuestionInfo{}, apperror.New(idErr, apperror.Internal, "Internal Server Error! There was a problem processing your request.")
 	}
 
 	answer.ID = uuid.String()
@@ -116,3 +207,374 @@ func (s *service) AddAnswer(ctx context.Context, answer domain.Answer) (domain.Q
 	}
 	return questionInfo, nil
 }
+
+func (s *service) FindAnswers(ctx context.Context, questionId string) ([]domain.Answer, error) {
+	answers, err := s.repository.FindAnswers(ctx, questionId)
+	if err != nil {
+		return []domain.Answer{}, err
+	}
+	return answers, nil
+}
+
+func (s *service) UpdateAnswer(ctx context.Context, answer domain.Answer) (domain.Answer, error) {
+	if answer.ID == "" || answer.QuestionID == "" {
+		level.Warn(s.log(ctx)).Log("msg", "The answer provided in the request doesnt have an ID, method UpdateAnswer")
+		return domain.Answer{}, apperror.New(errors.New("Invalid Request"),
+			apperror.Invalid,
+			"The answer passed to update is not valid")
+	}
+
+	updatedAnswer, err := s.repository.UpdateAnswer(ctx, answer)
+	if err != nil {
+		return updatedAnswer, err
+	}
+	return updatedAnswer, nil
+}
+
+func (s *service) DeleteAnswer(ctx context.Context, questionId string, answerId string) (string, error) {
+	msg, err := s.repository.DeleteAnswer(ctx, questionId, answerId)
+	if err != nil {
+		return "", err
+	}
+	return msg, nil
+}
+
+func (s *service) AcceptAnswer(ctx context.Context, questionId string, answerId string, userId string) (domain.QuestionInfo, error) {
+	if err := s.checkQuestionAuthor(ctx, questionId, userId); err != nil {
+		return domain.QuestionInfo{}, err
+	}
+
+	questionInfo, err := s.repository.AcceptAnswer(ctx, questionId, answerId)
+	if err != nil {
+		return domain.QuestionInfo{}, err
+	}
+	return questionInfo, nil
+}
+
+func (s *service) UnacceptAnswer(ctx context.Context, questionId string, userId string) (domain.QuestionInfo, error) {
+	if err := s.checkQuestionAuthor(ctx, questionId, userId); err != nil {
+		return domain.QuestionInfo{}, err
+	}
+
+	questionInfo, err := s.repository.UnacceptAnswer(ctx, questionId)
+	if err != nil {
+		return domain.QuestionInfo{}, err
+	}
+	return questionInfo, nil
+}
+
+//Only the user that asked the question can choose its accepted answer
+func (s *service) checkQuestionAuthor(ctx context.Context, questionId string, userId string) error {
+	questionInfo, err := s.repository.FindByID(ctx, questionId)
+	if err != nil {
+		return err
+	}
+
+	if userId == "" || questionInfo.Question.UserID != userId {
+		level.Warn(s.log(ctx)).Log("msg", fmt.Sprintf("User [%v] is not the author of Question [%v], method checkQuestionAuthor", userId, questionId))
+		return apperror.New(errors.New("Forbidden"),
+			apperror.Forbidden,
+			"Only The Author Of The Question Can Choose The Accepted Answer")
+	}
+	return nil
+}
+
+func (s *service) Vote(ctx context.Context, targetType string, targetId string, userId string, value int) (domain.Score, error) {
+	if err := validateVoteTarget(targetType, targetId, userId); err != nil {
+		return domain.Score{}, err
+	}
+
+	if value != 1 && value != -1 {
+		return domain.Score{}, apperror.New(errors.New(fmt.Sprintf("Invalid vote value %v", value)),
+			apperror.Invalid,
+			"The value of the vote must be 1 or -1")
+	}
+
+	vote := domain.Vote{
+		TargetType: targetType,
+		TargetID:   targetId,
+		UserID:     userId,
+		Value:      value,
+		CreatedOn:  time.Now().Unix(),
+	}
+	score, err := s.repository.Vote(ctx, vote)
+	if err != nil {
+		return domain.Score{}, err
+	}
+	return score, nil
+}
+
+func (s *service) RetractVote(ctx context.Context, targetType string, targetId string, userId string) (domain.Score, error) {
+	if err := validateVoteTarget(targetType, targetId, userId); err != nil {
+		return domain.Score{}, err
+	}
+
+	score, err := s.repository.RetractVote(ctx, targetType, targetId, userId)
+	if err != nil {
+		return domain.Score{}, err
+	}
+	return score, nil
+}
+
+//Only questions and answers can be voted and every vote must belong to a user
+func validateVoteTarget(targetType string, targetId string, userId string) error {
+	if targetType != domain.TargetQuestion && targetType != domain.TargetAnswer {
+		return apperror.New(errors.New(fmt.Sprintf("Invalid vote target type %v", targetType)),
+			apperror.Invalid,
+			"Only questions and answers can be voted")
+	}
+
+	if targetId == "" || userId == "" {
+		return apperror.New(errors.New("Invalid vote"),
+			apperror.Invalid,
+			"The vote passed is not valid")
+	}
+	return nil
+}
+
+func (s *service) AddComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	if comment.ParentType == "" {
+		comment.ParentType = domain.TargetQuestion
+	}
+	if comment.ParentType == domain.TargetQuestion && comment.ParentID == "" {
+		comment.ParentID = comment.QuestionID
+	}
+
+	if err := validateCommentParent(comment.ParentType, comment.ParentID); err != nil {
+		return domain.Comment{}, err
+	}
+
+	if comment.ParentType == domain.TargetQuestion && comment.ParentID != comment.QuestionID {
+		level.Warn(s.log(ctx)).Log("msg", fmt.Sprintf("The Parent ID doesnt match with the Question ID [%v!=%v], method AddComment", comment.ParentID, comment.QuestionID))
+		return domain.Comment{}, apperror.New(errors.New("Invalid Request"),
+			apperror.Invalid,
+			"There is a inconsistency with the information of the request")
+	}
+
+	uuid, idErr := uuid.NewV4()
+	if idErr != nil {
+		level.Warn(s.log(ctx)).Log("msg", "Error creating uuid for Comment, method AddComment")
+		return domain.Comment{}, apperror.New(idErr, apperror.Internal, "Internal Server Error! There was a problem processing your request.")
+	}
+
+	comment.ID = uuid.String()
+	comment.CreatedOn = time.Now().Unix()
+	createdComment, err := s.repository.AddComment(ctx, comment)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return createdComment, nil
+}
+
+func (s *service) FindComments(ctx context.Context, parentType string, parentId string) ([]domain.Comment, error) {
+	if err := validateCommentParent(parentType, parentId); err != nil {
+		return []domain.Comment{}, err
+	}
+
+	comments, err := s.repository.FindComments(ctx, parentType, parentId)
+	if err != nil {
+		return []domain.Comment{}, err
+	}
+	return comments, nil
+}
+
+func (s *service) FindComment(ctx context.Context, questionId string, commentId string) (domain.Comment, error) {
+	comment, err := s.repository.FindComment(ctx, questionId, commentId)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return comment, nil
+}
+
+func (s *service) UpdateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	if comment.ID == "" || comment.QuestionID == "" {
+		level.Warn(s.log(ctx)).Log("msg", "The comment provided in the request doesnt have an ID, method UpdateComment")
+		return domain.Comment{}, apperror.New(errors.New("Invalid Request"),
+			apperror.Invalid,
+			"The comment passed to update is not valid")
+	}
+
+	updatedComment, err := s.repository.UpdateComment(ctx, comment)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return updatedComment, nil
+}
+
+func (s *service) DeleteComment(ctx context.Context, questionId string, commentId string) (string, error) {
+	msg, err := s.repository.DeleteComment(ctx, questionId, commentId)
+	if err != nil {
+		return "", err
+	}
+	return msg, nil
+}
+
+//Comments can only be attached to an existing question or answer
+func validateCommentParent(parentType string, parentId string) error {
+	if parentType != domain.TargetQuestion && parentType != domain.TargetAnswer {
+		return apperror.New(errors.New(fmt.Sprintf("Invalid comment parent type %v", parentType)),
+			apperror.Invalid,
+			"Only questions and answers can be commented")
+	}
+
+	if parentId == "" {
+		return apperror.New(errors.New("Invalid comment"),
+			apperror.Invalid,
+			"The comment passed is not valid")
+	}
+	return nil
+}
+
+func (s *service) FindRevisions(ctx context.Context, questionId string) ([]domain.Revision, error) {
+	revisions, err := s.repository.FindRevisions(ctx, questionId)
+	if err != nil {
+		return []domain.Revision{}, err
+	}
+	return revisions, nil
+}
+
+func (s *service) RollbackRevision(ctx context.Context, questionId string, number int64, userId string) (domain.QuestionInfo, error) {
+	if number <= 0 || userId == "" {
+		level.Warn(s.log(ctx)).Log("msg", fmt.Sprintf("Invalid rollback of revision [%v] by user [%v], method RollbackRevision", number, userId))
+		return domain.QuestionInfo{}, apperror.New(errors.New("Invalid Request"),
+			apperror.Invalid,
+			"The revision passed to rollback is not valid")
+	}
+
+	revision, err := s.repository.FindRevision(ctx, questionId, number)
+	if err != nil {
+		return domain.QuestionInfo{}, err
+	}
+
+	questionInfo, err := s.repository.RestoreRevision(ctx, revision, userId)
+	if err != nil {
+		return domain.QuestionInfo{}, err
+	}
+	return questionInfo, nil
+}
+
+func (s *service) FindRoles(ctx context.Context, userId string) (domain.UserRoles, error) {
+	if userId == "" {
+		return domain.UserRoles{}, apperror.New(errors.New("Invalid Request"),
+			apperror.Invalid,
+			"The user is required")
+	}
+
+	roles, err := s.repository.FindRoles(ctx, userId)
+	if err != nil {
+		return domain.UserRoles{}, err
+	}
+	return roles, nil
+}
+
+func (s *service) GrantRole(ctx context.Context, userId string, role string) (domain.UserRoles, error) {
+	if err := s.validateRole(ctx, userId, role); err != nil {
+		return domain.UserRoles{}, err
+	}
+
+	roles, err := s.repository.GrantRole(ctx, userId, role)
+	if err != nil {
+		return domain.UserRoles{}, err
+	}
+	return roles, nil
+}
+
+func (s *service) RevokeRole(ctx context.Context, userId string, role string) (domain.UserRoles, error) {
+	if err := s.validateRole(ctx, userId, role); err != nil {
+		return domain.UserRoles{}, err
+	}
+
+	roles, err := s.repository.RevokeRole(ctx, userId, role)
+	if err != nil {
+		return domain.UserRoles{}, err
+	}
+	return roles, nil
+}
+
+func (s *service) validateRole(ctx context.Context, userId string, role string) error {
+	if userId == "" || !domain.ValidRole(role) {
+		level.Warn(s.log(ctx)).Log("msg", fmt.Sprintf("Invalid role [%v] for user [%v], method validateRole", role, userId))
+		return apperror.New(errors.New("Invalid Request"),
+			apperror.Invalid,
+			"The role must be admin or moderator")
+	}
+	return nil
+}
+
+//The keys are prefixed to be recognized in the configuration of the clients
+const apiKeyPrefix = "qak_"
+
+func (s *service) CreateAPIKey(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error) {
+	now := time.Now().Unix()
+	if apiKey.ExpiresOn != 0 && apiKey.ExpiresOn <= now {
+		return domain.APIKey{}, apperror.New(errors.New("Invalid Request"),
+			apperror.Invalid,
+			"The expiration of the API key must be in the future")
+	}
+
+	uuid, idErr := uuid.NewV4()
+	secret := make([]byte, 32)
+	_, keyErr := rand.Read(secret)
+	if idErr != nil || keyErr != nil {
+		level.Warn(s.log(ctx)).Log("msg", "Error creating the key of the API key, method CreateAPIKey")
+		return domain.APIKey{}, apperror.New(errors.New("API key generation failed"), apperror.Internal, "Internal Server Error! There was a problem processing your request.")
+	}
+
+	apiKey.ID = uuid.String()
+	apiKey.Key = apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	apiKey.Hash = hashAPIKey(apiKey.Key)
+	apiKey.CreatedOn = now
+	apiKey.LastUsedOn = 0
+	apiKey.RevokedOn = 0
+	createdKey, err := s.repository.CreateAPIKey(ctx, apiKey)
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+	return createdKey, nil
+}
+
+func (s *service) FindAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	apiKeys, err := s.repository.FindAPIKeys(ctx)
+	if err != nil {
+		return []domain.APIKey{}, err
+	}
+	return apiKeys, nil
+}
+
+func (s *service) RevokeAPIKey(ctx context.Context, id string) (string, error) {
+	msg, err := s.repository.RevokeAPIKey(ctx, id, time.Now().Unix())
+	if err != nil {
+		return "", err
+	}
+	return msg, nil
+}
+
+func (s *service) AuthenticateAPIKey(ctx context.Context, key string) (domain.APIKey, error) {
+	invalidKey := apperror.New(errors.New("Invalid API Key"), apperror.Unauthenticated, "Invalid API Key")
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return domain.APIKey{}, invalidKey
+	}
+
+	apiKey, err := s.repository.FindAPIKeyByHash(ctx, hashAPIKey(key))
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+
+	now := time.Now().Unix()
+	if apiKey.ExpiresOn != 0 && apiKey.ExpiresOn <= now {
+		level.Warn(s.log(ctx)).Log("msg", fmt.Sprintf("The API key [%v] expired, method AuthenticateAPIKey", apiKey.ID))
+		return domain.APIKey{}, invalidKey
+	}
+
+	if err := s.repository.TouchAPIKey(ctx, apiKey.ID, now); err != nil {
+		return domain.APIKey{}, err
+	}
+	apiKey.LastUsedOn = now
+	return apiKey, nil
+}
+
+//The keys have enough entropy to be stored with a fast hash, the hash is also used to search them
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
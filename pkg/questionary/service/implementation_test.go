@@ -4,13 +4,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics"
+	"github.com/gorilla/mux"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/auth"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/repository"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/service"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/transport"
+	grpcTransport "github.com/ismaeljpv/qa-api/pkg/questionary/transport/grpc"
+	pb "github.com/ismaeljpv/qa-api/pkg/questionary/transport/grpc/protobuff"
+	httpTransport "github.com/ismaeljpv/qa-api/pkg/questionary/transport/http"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -64,14 +76,14 @@ var (
 		},
 	}
 
-	addAnswerDataQuestionAnsweredConflict = []testBody{
+	addAnswerDataQuestionNotFound = []testBody{
 		{
-			value:    domain.Answer{ID: "w0212", QuestionID: "1"},
-			expected: "The question already has an answer!",
+			value:    domain.Answer{ID: "w0212", QuestionID: "1212"},
+			expected: "No Question Found",
 		},
 		{
-			value:    domain.Answer{ID: "e312", QuestionID: "3"},
-			expected: "The question already has an answer!",
+			value:    domain.Answer{ID: "e312", QuestionID: "3212"},
+			expected: "No Question Found",
 		},
 	}
 
@@ -227,15 +239,83 @@ var (
 		{value: "331", expected: "No Question Found"},
 		{value: "221", expected: "No Question Found"},
 	}
+
+	findAnswersDataSuccess = []testBody{
+		{value: "1", expected: 2},
+		{value: "3", expected: 1},
+	}
+
+	updateAnswerDataSuccess = []testBody{
+		{
+			value:    domain.Answer{ID: "1", Answer: "Yes! Always!", UserID: "2", QuestionID: "1"},
+			expected: "Yes! Always!",
+		},
+	}
+
+	updateAnswerDataNoID = []testBody{
+		{
+			value:    domain.Answer{Answer: "Yes! Always!", UserID: "2", QuestionID: "1"},
+			expected: "The answer passed to update is not valid",
+		},
+		{
+			value:    domain.Answer{ID: "1", Answer: "Yes! Always!", UserID: "2"},
+			expected: "The answer passed to update is not valid",
+		},
+	}
+
+	deleteAnswerDataSuccess = []testBody{
+		{value: domain.Answer{ID: "1", QuestionID: "1"}, expected: "Answer Deleted Successfully!"},
+	}
+
+	deleteAnswerDataNotFound = []testBody{
+		{value: domain.Answer{ID: "331", QuestionID: "1"}, expected: "No Answer Found"},
+	}
+
+	acceptAnswerDataSuccess = []testBody{
+		{value: transport.AcceptAnswerRequest{QuestionID: "1", AnswerID: "1", UserID: "1"}, expected: "1"},
+	}
+
+	findByTagDataSuccess = []testBody{
+		{value: "go", expected: 2},
+		{value: " GO ", expected: 2},
+	}
+
+	addCommentDataBadRequest = []testBody{
+		{value: domain.Comment{ParentType: "vote", ParentID: "1", QuestionID: "1", Comment: "Why?", UserID: "2"}, expected: "Only questions and answers can be commented"},
+		{value: domain.Comment{ParentType: "answer", QuestionID: "1", Comment: "Why?", UserID: "2"}, expected: "The comment passed is not valid"},
+		{value: domain.Comment{ParentType: "question", ParentID: "2", QuestionID: "1", Comment: "Why?", UserID: "2"}, expected: "There is a inconsistency with the information of the request"},
+	}
+
+	rollbackDataBadRequest = []testBody{
+		{value: transport.RollbackRevisionRequest{QuestionID: "1", Number: 0, UserID: "1"}, expected: "The revision passed to rollback is not valid"},
+		{value: transport.RollbackRevisionRequest{QuestionID: "1", Number: 1}, expected: "The revision passed to rollback is not valid"},
+	}
+
+	voteDataBadRequest = []testBody{
+		{value: transport.VoteRequest{TargetType: "question", TargetID: "1", UserID: "2", Value: 2}, expected: "The value of the vote must be 1 or -1"},
+		{value: transport.VoteRequest{TargetType: "user", TargetID: "1", UserID: "2", Value: 1}, expected: "Only questions and answers can be voted"},
+		{value: transport.VoteRequest{TargetType: "answer", TargetID: "1", Value: -1}, expected: "The vote passed is not valid"},
+	}
+
+	acceptAnswerDataForbidden = []testBody{
+		{
+			value:    transport.AcceptAnswerRequest{QuestionID: "1", AnswerID: "1", UserID: "2"},
+			expected: "Only The Author Of The Question Can Choose The Accepted Answer",
+		},
+		{
+			value:    transport.AcceptAnswerRequest{QuestionID: "1", AnswerID: "1"},
+			expected: "Only The Author Of The Question Can Choose The Accepted Answer",
+		},
+	}
 )
 
 //
 // Interface methods of the mock repository
 //
-func (m *mockRepository) FindAll(ctx context.Context) ([]domain.QuestionInfo, error) {
-	args := m.Called(ctx)
+func (m *mockRepository) FindAll(ctx context.Context, filter domain.QuestionFilter, page domain.Page) (domain.QuestionPage, error) {
+	args := m.Called(ctx, filter, page)
 	result := args.Get(0)
-	return result.([]domain.QuestionInfo), args.Error(1)
+	return result.(domain.QuestionPage), args.Error(1)
 }
 
 func (m *mockRepository) FindByID(ctx context.Context, id string) (domain.QuestionInfo, error) {
@@ -244,10 +324,10 @@ func (m *mockRepository) FindByID(ctx context.Context, id string) (domain.Questi
 	return result.(domain.QuestionInfo), args.Error(1)
 }
 
-func (m *mockRepository) FindByUser(ctx context.Context, userId string) ([]domain.QuestionInfo, error) {
-	args := m.Called(ctx, userId)
+func (m *mockRepository) FindByUser(ctx context.Context, userId string, page domain.Page) (domain.QuestionPage, error) {
+	args := m.Called(ctx, userId, page)
 	result := args.Get(0)
-	return result.([]domain.QuestionInfo), args.Error(1)
+	return result.(domain.QuestionPage), args.Error(1)
 }
 
 func (m *mockRepository) Create(ctx context.Context, question domain.Question) (domain.Question, error) {
@@ -262,18 +342,197 @@ func (m *mockRepository) Update(ctx context.Context, questionInfo domain.Questio
 	return result.(domain.QuestionInfo), args.Error(1)
 }
 
-func (m *mockRepository) Delete(ctx context.Context, id string) (string, error) {
-	args := m.Called(ctx, id)
+func (m *mockRepository) Delete(ctx context.Context, id string, userId string) (string, error) {
+	args := m.Called(ctx, id, userId)
 	result := args.Get(0)
 	return result.(string), args.Error(1)
 }
 
+func (m *mockRepository) Restore(ctx context.Context, id string) (domain.QuestionInfo, error) {
+	args := m.Called(ctx, id)
+	result := args.Get(0)
+	return result.(domain.QuestionInfo), args.Error(1)
+}
+
+func (m *mockRepository) Purge(ctx context.Context, deletedBefore int64) (int64, error) {
+	args := m.Called(ctx, deletedBefore)
+	result := args.Get(0)
+	return result.(int64), args.Error(1)
+}
+
 func (m *mockRepository) AddAnswer(ctx context.Context, answer domain.Answer) (domain.QuestionInfo, error) {
 	args := m.Called(ctx, answer)
 	result := args.Get(0)
 	return result.(domain.QuestionInfo), args.Error(1)
 }
 
+func (m *mockRepository) FindAnswers(ctx context.Context, questionId string) ([]domain.Answer, error) {
+	args := m.Called(ctx, questionId)
+	result := args.Get(0)
+	return result.([]domain.Answer), args.Error(1)
+}
+
+func (m *mockRepository) UpdateAnswer(ctx context.Context, answer domain.Answer) (domain.Answer, error) {
+	args := m.Called(ctx, answer)
+	result := args.Get(0)
+	return result.(domain.Answer), args.Error(1)
+}
+
+func (m *mockRepository) DeleteAnswer(ctx context.Context, questionId string, answerId string) (string, error) {
+	args := m.Called(ctx, questionId, answerId)
+	result := args.Get(0)
+	return result.(string), args.Error(1)
+}
+
+func (m *mockRepository) AcceptAnswer(ctx context.Context, questionId string, answerId string) (domain.QuestionInfo, error) {
+	args := m.Called(ctx, questionId, answerId)
+	result := args.Get(0)
+	return result.(domain.QuestionInfo), args.Error(1)
+}
+
+func (m *mockRepository) UnacceptAnswer(ctx context.Context, questionId string) (domain.QuestionInfo, error) {
+	args := m.Called(ctx, questionId)
+	result := args.Get(0)
+	return result.(domain.QuestionInfo), args.Error(1)
+}
+
+func (m *mockRepository) Vote(ctx context.Context, vote domain.Vote) (domain.Score, error) {
+	args := m.Called(ctx, vote)
+	result := args.Get(0)
+	return result.(domain.Score), args.Error(1)
+}
+
+func (m *mockRepository) RetractVote(ctx context.Context, targetType string, targetId string, userId string) (domain.Score, error) {
+	args := m.Called(ctx, targetType, targetId, userId)
+	result := args.Get(0)
+	return result.(domain.Score), args.Error(1)
+}
+
+func (m *mockRepository) FindByTag(ctx context.Context, tag string) ([]domain.QuestionInfo, error) {
+	args := m.Called(ctx, tag)
+	result := args.Get(0)
+	return result.([]domain.QuestionInfo), args.Error(1)
+}
+
+func (m *mockRepository) Search(ctx context.Context, query string, filter domain.QuestionFilter) ([]domain.SearchResult, error) {
+	args := m.Called(ctx, query, filter)
+	result := args.Get(0)
+	return result.([]domain.SearchResult), args.Error(1)
+}
+
+func (m *mockRepository) FindTags(ctx context.Context) ([]domain.TagCount, error) {
+	args := m.Called(ctx)
+	result := args.Get(0)
+	return result.([]domain.TagCount), args.Error(1)
+}
+
+func (m *mockRepository) AddComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	args := m.Called(ctx, comment)
+	result := args.Get(0)
+	return result.(domain.Comment), args.Error(1)
+}
+
+func (m *mockRepository) FindComments(ctx context.Context, parentType string, parentId string) ([]domain.Comment, error) {
+	args := m.Called(ctx, parentType, parentId)
+	result := args.Get(0)
+	return result.([]domain.Comment), args.Error(1)
+}
+
+func (m *mockRepository) FindComment(ctx context.Context, questionId string, commentId string) (domain.Comment, error) {
+	args := m.Called(ctx, questionId, commentId)
+	result := args.Get(0)
+	return result.(domain.Comment), args.Error(1)
+}
+
+func (m *mockRepository) UpdateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	args := m.Called(ctx, comment)
+	result := args.Get(0)
+	return result.(domain.Comment), args.Error(1)
+}
+
+func (m *mockRepository) DeleteComment(ctx context.Context, questionId string, commentId string) (string, error) {
+	args := m.Called(ctx, questionId, commentId)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockRepository) FindRevisions(ctx context.Context, questionId string) ([]domain.Revision, error) {
+	args := m.Called(ctx, questionId)
+	result := args.Get(0)
+	return result.([]domain.Revision), args.Error(1)
+}
+
+func (m *mockRepository) FindRevision(ctx context.Context, questionId string, number int64) (domain.Revision, error) {
+	args := m.Called(ctx, questionId, number)
+	result := args.Get(0)
+	return result.(domain.Revision), args.Error(1)
+}
+
+func (m *mockRepository) RestoreRevision(ctx context.Context, revision domain.Revision, userId string) (domain.QuestionInfo, error) {
+	args := m.Called(ctx, revision, userId)
+	result := args.Get(0)
+	return result.(domain.QuestionInfo), args.Error(1)
+}
+
+func (m *mockRepository) FindRoles(ctx context.Context, userId string) (domain.UserRoles, error) {
+	args := m.Called(ctx, userId)
+	result := args.Get(0)
+	return result.(domain.UserRoles), args.Error(1)
+}
+
+func (m *mockRepository) GrantRole(ctx context.Context, userId string, role string) (domain.UserRoles, error) {
+	args := m.Called(ctx, userId, role)
+	result := args.Get(0)
+	return result.(domain.UserRoles), args.Error(1)
+}
+
+func (m *mockRepository) RevokeRole(ctx context.Context, userId string, role string) (domain.UserRoles, error) {
+	args := m.Called(ctx, userId, role)
+	result := args.Get(0)
+	return result.(domain.UserRoles), args.Error(1)
+}
+
+func (m *mockRepository) CreateAPIKey(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error) {
+	args := m.Called(ctx, apiKey)
+	result := args.Get(0)
+	if stored, ok := result.(func(context.Context, domain.APIKey) domain.APIKey); ok {
+		return stored(ctx, apiKey), args.Error(1)
+	}
+	return result.(domain.APIKey), args.Error(1)
+}
+
+func (m *mockRepository) FindAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	args := m.Called(ctx)
+	result := args.Get(0)
+	return result.([]domain.APIKey), args.Error(1)
+}
+
+func (m *mockRepository) FindAPIKeyByHash(ctx context.Context, hash string) (domain.APIKey, error) {
+	args := m.Called(ctx, hash)
+	result := args.Get(0)
+	return result.(domain.APIKey), args.Error(1)
+}
+
+func (m *mockRepository) RevokeAPIKey(ctx context.Context, id string, revokedOn int64) (string, error) {
+	args := m.Called(ctx, id, revokedOn)
+	result := args.Get(0)
+	return result.(string), args.Error(1)
+}
+
+func (m *mockRepository) TouchAPIKey(ctx context.Context, id string, usedOn int64) error {
+	args := m.Called(ctx, id, usedOn)
+	return args.Error(0)
+}
+
+func (m *mockRepository) Ping(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *mockRepository) Close(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
 func NewMockService(repo repository.Repository, logger log.Logger) service.Service {
 	logger = log.NewLogfmtLogger(os.Stderr)
 	logger = log.NewSyncLogger(logger)
@@ -325,18 +584,33 @@ func TestFindByID_NotFound(t *testing.T) {
 
 func TestFindByUser_Success(t *testing.T) {
 	mockRepo := new(mockRepository)
-	mockRepo.On("FindByUser", ctx, "1").Return([]domain.QuestionInfo{{}, {}}, nil).Once()
-	mockRepo.On("FindByUser", ctx, "2").Return([]domain.QuestionInfo{{}}, nil).Once()
+	defaultPage := domain.Page{Limit: domain.DefaultPageLimit, Sort: domain.SortOldest}
+	mockRepo.On("FindByUser", ctx, "1", defaultPage).Return(domain.QuestionPage{Questions: []domain.QuestionInfo{{}, {}}}, nil).Once()
+	mockRepo.On("FindByUser", ctx, "2", defaultPage).Return(domain.QuestionPage{Questions: []domain.QuestionInfo{{}}}, nil).Once()
 
 	srv := NewMockService(mockRepo, logger)
 	for _, data := range findByUserDataSuccess {
 		userID := fmt.Sprintf("%v", data.value)
-		questions, err := srv.FindByUser(ctx, userID)
+		page, err := srv.FindByUser(ctx, userID, domain.Page{})
 		if err != nil {
 			t.Error(err.Error())
 		}
-		assert.Equal(t, data.expected, len(questions))
+		assert.Equal(t, data.expected, len(page.Questions))
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFindAll_PageLimit(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindAll", ctx, domain.QuestionFilter{}, domain.Page{Limit: domain.MaxPageLimit, Cursor: "abc", Sort: domain.SortOldest}).
+		Return(domain.QuestionPage{Questions: []domain.QuestionInfo{{}}, NextCursor: "def"}, nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	page, err := srv.FindAll(ctx, domain.QuestionFilter{}, domain.Page{Limit: 1000, Cursor: "abc"})
+	if err != nil {
+		t.Error(err.Error())
 	}
+	assert.Equal(t, "def", page.NextCursor)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -359,7 +633,7 @@ func TestCreateQuestion_Success(t *testing.T) {
 
 func TestAddAnswer_Success(t *testing.T) {
 	mockRepo := new(mockRepository)
-	mockRepo.On("AddAnswer", ctx, mock.Anything).Return(domain.QuestionInfo{Answer: domain.Answer{Answer: "This is an anwser", UserID: "2", QuestionID: "2"}}, nil).Once()
+	mockRepo.On("AddAnswer", ctx, mock.Anything).Return(domain.QuestionInfo{Answers: []domain.Answer{{Answer: "This is an anwser", UserID: "2", QuestionID: "2"}}}, nil).Once()
 
 	srv := NewMockService(mockRepo, logger)
 	for _, data := range addAnswerDataSuccess {
@@ -367,18 +641,18 @@ func TestAddAnswer_Success(t *testing.T) {
 		if err != nil {
 			t.Error(err)
 		}
-		assert.Equal(t, data.expected, createdAnswer.Answer.Answer)
+		assert.Equal(t, data.expected, createdAnswer.Answers[len(createdAnswer.Answers)-1].Answer)
 	}
 	mockRepo.AssertExpectations(t)
 }
 
-func TestAddAnswer_QuestionAnsweredConflict(t *testing.T) {
+func TestAddAnswer_QuestionNotFound(t *testing.T) {
 	mockRepo := new(mockRepository)
-	mockRepo.On("AddAnswer", ctx, mock.Anything).Return(domain.QuestionInfo{}, errors.New("The question already has an answer!")).Once()
-	mockRepo.On("AddAnswer", ctx, mock.Anything).Return(domain.QuestionInfo{}, errors.New("The question already has an answer!")).Once()
+	mockRepo.On("AddAnswer", ctx, mock.Anything).Return(domain.QuestionInfo{}, errors.New("No Question Found")).Once()
+	mockRepo.On("AddAnswer", ctx, mock.Anything).Return(domain.QuestionInfo{}, errors.New("No Question Found")).Once()
 
 	srv := NewMockService(mockRepo, logger)
-	for _, data := range addAnswerDataQuestionAnsweredConflict {
+	for _, data := range addAnswerDataQuestionNotFound {
 		_, err := srv.AddAnswer(ctx, data.value.(domain.Answer))
 		if err == nil {
 			t.Errorf("Error = [%v] expected", data.expected)
@@ -457,6 +731,21 @@ func TestUpdateQuestionInfo_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+//A question without answers can be edited with an update that has no answer
+func TestUpdateQuestion_WithoutAnswer(t *testing.T) {
+	update := domain.QuestionInfo{Question: domain.Question{ID: "2", Statement: "Where are all the gophers now?", UserID: "1"}}
+	mockRepo := new(mockRepository)
+	mockRepo.On("Update", ctx, update).Return(update, nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	updatedInfo, err := srv.Update(ctx, update, "2")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, update.Question.Statement, updatedInfo.Question.Statement)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestUpdateQuestionNoID(t *testing.T) {
 	mockRepo := new(mockRepository)
 	srv := NewMockService(mockRepo, logger)
@@ -499,13 +788,13 @@ func TestUpdateQuestionInfo_NotFound(t *testing.T) {
 
 func TestDeleteQuestion_Success(t *testing.T) {
 	mockRepo := new(mockRepository)
-	mockRepo.On("Delete", ctx, "3").Return("Question Deleted Successfully!", nil).Once()
-	mockRepo.On("Delete", ctx, "2").Return("Question Deleted Successfully!", nil).Once()
+	mockRepo.On("Delete", ctx, "3", "1").Return("Question Deleted Successfully!", nil).Once()
+	mockRepo.On("Delete", ctx, "2", "1").Return("Question Deleted Successfully!", nil).Once()
 
 	srv := NewMockService(mockRepo, logger)
 	for _, data := range deleteQuestionSuccess {
 		id := fmt.Sprintf("%v", data.value)
-		msg, err := srv.Delete(ctx, id)
+		msg, err := srv.Delete(ctx, id, "1")
 		if err != nil {
 			t.Error(err)
 		}
@@ -516,13 +805,13 @@ func TestDeleteQuestion_Success(t *testing.T) {
 
 func TestDeleteQuestion_NotFound(t *testing.T) {
 	mockRepo := new(mockRepository)
-	mockRepo.On("Delete", ctx, mock.Anything).Return("", errors.New("No Question Found")).Once()
-	mockRepo.On("Delete", ctx, mock.Anything).Return("", errors.New("No Question Found")).Once()
+	mockRepo.On("Delete", ctx, mock.Anything, "1").Return("", errors.New("No Question Found")).Once()
+	mockRepo.On("Delete", ctx, mock.Anything, "1").Return("", errors.New("No Question Found")).Once()
 
 	srv := NewMockService(mockRepo, logger)
 	for _, data := range deleteQuestionNotFound {
 		id := fmt.Sprintf("%v", data.value)
-		_, err := srv.Delete(ctx, id)
+		_, err := srv.Delete(ctx, id, "1")
 		fmt.Println(err)
 		if err == nil {
 			t.Errorf("Error = [%v] expected", data.expected)
@@ -531,3 +820,764 @@ func TestDeleteQuestion_NotFound(t *testing.T) {
 	}
 	mockRepo.AssertExpectations(t)
 }
+
+func TestFindAnswers_Success(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindAnswers", ctx, "1").Return([]domain.Answer{{ID: "1"}, {ID: "3"}}, nil).Once()
+	mockRepo.On("FindAnswers", ctx, "3").Return([]domain.Answer{{ID: "2"}}, nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	for _, data := range findAnswersDataSuccess {
+		questionID := fmt.Sprintf("%v", data.value)
+		answers, err := srv.FindAnswers(ctx, questionID)
+		if err != nil {
+			t.Error(err.Error())
+		}
+		assert.Equal(t, data.expected, len(answers))
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateAnswer_Success(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("UpdateAnswer", ctx, mock.Anything).Return(domain.Answer{ID: "1", Answer: "Yes! Always!", QuestionID: "1"}, nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	for _, data := range updateAnswerDataSuccess {
+		updatedAnswer, err := srv.UpdateAnswer(ctx, data.value.(domain.Answer))
+		if err != nil {
+			t.Error(err)
+		}
+		assert.Equal(t, data.expected, updatedAnswer.Answer)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateAnswerNoID(t *testing.T) {
+	mockRepo := new(mockRepository)
+	srv := NewMockService(mockRepo, logger)
+	for _, data := range updateAnswerDataNoID {
+		_, err := srv.UpdateAnswer(ctx, data.value.(domain.Answer))
+		if err == nil {
+			t.Errorf("Error = [%v] expected", data.expected)
+		}
+		assert.Equal(t, data.expected, err.Error())
+	}
+}
+
+func TestDeleteAnswer_Success(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("DeleteAnswer", ctx, "1", "1").Return("Answer Deleted Successfully!", nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	for _, data := range deleteAnswerDataSuccess {
+		answer := data.value.(domain.Answer)
+		msg, err := srv.DeleteAnswer(ctx, answer.QuestionID, answer.ID)
+		if err != nil {
+			t.Error(err)
+		}
+		assert.Equal(t, data.expected, msg)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeleteAnswer_NotFound(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("DeleteAnswer", ctx, "1", "331").Return("", errors.New("No Answer Found")).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	for _, data := range deleteAnswerDataNotFound {
+		answer := data.value.(domain.Answer)
+		_, err := srv.DeleteAnswer(ctx, answer.QuestionID, answer.ID)
+		if err == nil {
+			t.Errorf("Error = [%v] expected", data.expected)
+		}
+		assert.Equal(t, data.expected, err.Error())
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAcceptAnswer_Success(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindByID", ctx, "1").Return(domain.QuestionInfo{Question: domain.Question{ID: "1", UserID: "1"}}, nil).Once()
+	mockRepo.On("AcceptAnswer", ctx, "1", "1").Return(domain.QuestionInfo{Question: domain.Question{ID: "1", UserID: "1"}, AcceptedAnswerID: "1"}, nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	for _, data := range acceptAnswerDataSuccess {
+		req := data.value.(transport.AcceptAnswerRequest)
+		questionInfo, err := srv.AcceptAnswer(ctx, req.QuestionID, req.AnswerID, req.UserID)
+		if err != nil {
+			t.Error(err)
+		}
+		assert.Equal(t, data.expected, questionInfo.AcceptedAnswerID)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAcceptAnswer_Forbidden(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindByID", ctx, "1").Return(domain.QuestionInfo{Question: domain.Question{ID: "1", UserID: "1"}}, nil).Twice()
+
+	srv := NewMockService(mockRepo, logger)
+	for _, data := range acceptAnswerDataForbidden {
+		req := data.value.(transport.AcceptAnswerRequest)
+		_, err := srv.AcceptAnswer(ctx, req.QuestionID, req.AnswerID, req.UserID)
+		if err == nil {
+			t.Errorf("Error = [%v] expected", data.expected)
+		}
+		assert.Equal(t, data.expected, err.Error())
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+//The body of the request has the ID of the author of the question, but the user is the subject of the token
+func TestAcceptAnswer_SpoofedAuthorForbidden(t *testing.T) {
+	tokenCtx := auth.WithSubject(ctx, "2")
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindByID", tokenCtx, "1").Return(domain.QuestionInfo{Question: domain.Question{ID: "1", UserID: "1"}}, nil).Twice()
+	srv := NewMockService(mockRepo, logger)
+
+	r := httptest.NewRequest(http.MethodPost, "/question/1/answer/1/accept", strings.NewReader(`{"userId": "1"}`))
+	decoded, err := httpTransport.DecodeAcceptAnswerRequest(tokenCtx, mux.SetURLVars(r, map[string]string{"id": "1", "answerId": "1"}))
+	assert.Nil(t, err)
+	req := decoded.(transport.AcceptAnswerRequest)
+	_, err = srv.AcceptAnswer(tokenCtx, req.QuestionID, req.AnswerID, req.UserID)
+	assert.Equal(t, apperror.Forbidden, apperror.KindOf(err))
+
+	decoded, err = grpcTransport.DecodeUnacceptAnswerRequest(tokenCtx, &pb.AcceptAnswerRequest{QuestionID: "1", UserID: "1"})
+	assert.Nil(t, err)
+	req = decoded.(transport.AcceptAnswerRequest)
+	_, err = srv.UnacceptAnswer(tokenCtx, req.QuestionID, req.UserID)
+	assert.Equal(t, apperror.Forbidden, apperror.KindOf(err))
+	mockRepo.AssertNotCalled(t, "AcceptAnswer", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "UnacceptAnswer", mock.Anything, mock.Anything)
+}
+
+func TestUnacceptAnswer_Success(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindByID", ctx, "1").Return(domain.QuestionInfo{Question: domain.Question{ID: "1", UserID: "1"}, AcceptedAnswerID: "1"}, nil).Once()
+	mockRepo.On("UnacceptAnswer", ctx, "1").Return(domain.QuestionInfo{Question: domain.Question{ID: "1", UserID: "1"}}, nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	questionInfo, err := srv.UnacceptAnswer(ctx, "1", "1")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "", questionInfo.AcceptedAnswerID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVote_Success(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("Vote", ctx, mock.AnythingOfType("domain.Vote")).Return(domain.Score{TargetType: "answer", TargetID: "1", Score: 1}, nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	score, err := srv.Vote(ctx, "answer", "1", "2", 1)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, int64(1), score.Score)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVote_BadRequest(t *testing.T) {
+	mockRepo := new(mockRepository)
+	srv := NewMockService(mockRepo, logger)
+	for _, data := range voteDataBadRequest {
+		req := data.value.(transport.VoteRequest)
+		_, err := srv.Vote(ctx, req.TargetType, req.TargetID, req.UserID, req.Value)
+		if err == nil {
+			t.Errorf("Error = [%v] expected", data.expected)
+		}
+		assert.Equal(t, data.expected, err.Error())
+	}
+	mockRepo.AssertNotCalled(t, "Vote", mock.Anything, mock.Anything)
+}
+
+func TestRetractVote_NotFound(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("RetractVote", ctx, "question", "1", "2").Return(domain.Score{}, errors.New("No Vote Found")).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	_, err := srv.RetractVote(ctx, "question", "1", "2")
+	if err == nil {
+		t.Errorf("Error = [%v] expected", "No Vote Found")
+	}
+	assert.Equal(t, "No Vote Found", err.Error())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFindByTag_Success(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindByTag", ctx, "go").Return([]domain.QuestionInfo{{}, {}}, nil).Twice()
+
+	srv := NewMockService(mockRepo, logger)
+	for _, data := range findByTagDataSuccess {
+		questions, err := srv.FindByTag(ctx, data.value.(string))
+		if err != nil {
+			t.Error(err.Error())
+		}
+		assert.Equal(t, data.expected, len(questions))
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateQuestion_NormalizeTags(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(question domain.Question) bool {
+		return assert.ObjectsAreEqual([]string{"go", "channels"}, question.Tags)
+	})).Return(domain.Question{Statement: "How do channels work?", UserID: "9", Tags: []string{"go", "channels"}}, nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	question := domain.Question{Statement: "How do channels work?", UserID: "9", Tags: []string{" Go", "channels", "go", ""}}
+	createdQuestion, err := srv.Create(ctx, question)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	assert.Equal(t, []string{"go", "channels"}, createdQuestion.Tags)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddComment_Success(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("AddComment", ctx, mock.MatchedBy(func(comment domain.Comment) bool {
+		return comment.ParentType == domain.TargetQuestion && comment.ParentID == "1" && comment.ID != ""
+	})).Return(domain.Comment{ID: "c1", ParentType: "question", ParentID: "1", QuestionID: "1", Comment: "What do you mean?", UserID: "2"}, nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	comment, err := srv.AddComment(ctx, domain.Comment{QuestionID: "1", Comment: "What do you mean?", UserID: "2"})
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "What do you mean?", comment.Comment)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddComment_BadRequest(t *testing.T) {
+	mockRepo := new(mockRepository)
+	srv := NewMockService(mockRepo, logger)
+	for _, data := range addCommentDataBadRequest {
+		_, err := srv.AddComment(ctx, data.value.(domain.Comment))
+		if err == nil {
+			t.Errorf("Error = [%v] expected", data.expected)
+		}
+		assert.Equal(t, data.expected, err.Error())
+	}
+	mockRepo.AssertNotCalled(t, "AddComment", mock.Anything, mock.Anything)
+}
+
+//The comments of a missing question are not an empty list, the error of the repository reaches the client
+func TestFindComments_QuestionNotFound(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindComments", ctx, domain.TargetQuestion, "333").Return([]domain.Comment{}, apperror.New(errors.New("mongo: no documents in result"), apperror.NotFound, "Question Not Found")).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	_, err := srv.FindComments(ctx, domain.TargetQuestion, "333")
+	assert.Equal(t, apperror.NotFound, apperror.KindOf(err))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateComment_BadRequest(t *testing.T) {
+	mockRepo := new(mockRepository)
+	srv := NewMockService(mockRepo, logger)
+	_, err := srv.UpdateComment(ctx, domain.Comment{QuestionID: "1", Comment: "Edited", UserID: "2"})
+	if err == nil {
+		t.Errorf("Error = [%v] expected", "The comment passed to update is not valid")
+	}
+	assert.Equal(t, "The comment passed to update is not valid", err.Error())
+	mockRepo.AssertNotCalled(t, "UpdateComment", mock.Anything, mock.Anything)
+}
+
+func TestRollbackRevision_Success(t *testing.T) {
+	revision := domain.Revision{QuestionID: "1", Number: 2, TargetType: "question", TargetID: "1", Text: "Do You Think That GO Rocks?", UserID: "1"}
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindRevision", ctx, "1", int64(2)).Return(revision, nil).Once()
+	mockRepo.On("RestoreRevision", ctx, revision, "3").Return(domain.QuestionInfo{Question: domain.Question{ID: "1", Statement: revision.Text}, RevisionCount: 3}, nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	questionInfo, err := srv.RollbackRevision(ctx, "1", 2, "3")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, revision.Text, questionInfo.Question.Statement)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRollbackRevision_BadRequest(t *testing.T) {
+	mockRepo := new(mockRepository)
+	srv := NewMockService(mockRepo, logger)
+	for _, data := range rollbackDataBadRequest {
+		req := data.value.(transport.RollbackRevisionRequest)
+		_, err := srv.RollbackRevision(ctx, req.QuestionID, req.Number, req.UserID)
+		if err == nil {
+			t.Errorf("Error = [%v] expected", data.expected)
+		}
+		assert.Equal(t, data.expected, err.Error())
+	}
+	mockRepo.AssertNotCalled(t, "FindRevision", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRollbackRevision_NotFound(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindRevision", ctx, "1", int64(9)).Return(domain.Revision{}, errors.New("No Revision Found")).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	_, err := srv.RollbackRevision(ctx, "1", 9, "3")
+	if err == nil {
+		t.Errorf("Error = [%v] expected", "No Revision Found")
+	}
+	assert.Equal(t, "No Revision Found", err.Error())
+	mockRepo.AssertNotCalled(t, "RestoreRevision", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFindAll_InvalidListing(t *testing.T) {
+	mockRepo := new(mockRepository)
+	srv := NewMockService(mockRepo, logger)
+
+	_, err := srv.FindAll(ctx, domain.QuestionFilter{}, domain.Page{Sort: "popular"})
+	if err == nil {
+		t.Fatal("Error expected for an invalid sort")
+	}
+	assert.Equal(t, "The sort must be newest, oldest or score", err.Error())
+
+	_, err = srv.FindAll(ctx, domain.QuestionFilter{CreatedFrom: 200, CreatedTo: 100}, domain.Page{})
+	if err == nil {
+		t.Fatal("Error expected for an invalid createdOn range")
+	}
+	assert.Equal(t, "The createdOn range is not valid", err.Error())
+	mockRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSearch_Success(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("Search", ctx, "gophers", domain.QuestionFilter{Unresolved: true}).
+		Return([]domain.SearchResult{{QuestionInfo: domain.QuestionInfo{Question: domain.Question{ID: "2"}}, Relevance: 0.75}}, nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	results, err := srv.Search(ctx, "  gophers ", domain.QuestionFilter{Unresolved: true})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, "2", results[0].QuestionInfo.Question.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearch_EmptyQuery(t *testing.T) {
+	mockRepo := new(mockRepository)
+	srv := NewMockService(mockRepo, logger)
+	_, err := srv.Search(ctx, "   ", domain.QuestionFilter{})
+	if err == nil {
+		t.Fatal("Error expected for an empty query")
+	}
+	assert.Equal(t, "The search query is required", err.Error())
+	mockRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteQuestion_UserRequired(t *testing.T) {
+	mockRepo := new(mockRepository)
+	srv := NewMockService(mockRepo, logger)
+	_, err := srv.Delete(ctx, "1", "")
+	if err == nil {
+		t.Fatal("Error expected for a delete without user")
+	}
+	assert.Equal(t, "The user that deletes the question is required", err.Error())
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPurge_Retention(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("Purge", ctx, mock.MatchedBy(func(deletedBefore int64) bool {
+		expected := time.Now().Add(-48 * time.Hour).Unix()
+		return deletedBefore <= expected && deletedBefore >= expected-5
+	})).Return(int64(2), nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	purged, err := srv.Purge(ctx, 48*time.Hour)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, int64(2), purged)
+	mockRepo.AssertExpectations(t)
+}
+
+func NewAuthorizedMockService(repo repository.Repository, logger log.Logger) service.Service {
+	return service.NewAuthorizationMiddleware(log.NewNopLogger())(NewMockService(repo, logger))
+}
+
+var ownedQuestion = domain.QuestionInfo{
+	Question: domain.Question{ID: "1", Statement: "Who owns this?", UserID: "1"},
+	Answers:  []domain.Answer{{ID: "10", Answer: "The author", UserID: "2", QuestionID: "1"}},
+}
+
+func TestDeleteQuestion_Forbidden(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindByID", ctx, "1").Return(ownedQuestion, nil).Once()
+
+	srv := NewAuthorizedMockService(mockRepo, logger)
+	_, err := srv.Delete(ctx, "1", "2")
+	if err == nil {
+		t.Fatal("Error expected for a delete by a user that is not the author")
+	}
+	assert.Equal(t, "Only The Author Of The Question Can Modify It", err.Error())
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteQuestion_Author(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindByID", ctx, "1").Return(ownedQuestion, nil).Once()
+	mockRepo.On("Delete", ctx, "1", "1").Return("Question Deleted Successfully", nil).Once()
+
+	srv := NewAuthorizedMockService(mockRepo, logger)
+	_, err := srv.Delete(ctx, "1", "1")
+	if err != nil {
+		t.Error(err)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateQuestion_Forbidden(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindByID", ctx, "1").Return(ownedQuestion, nil).Twice()
+
+	srv := NewAuthorizedMockService(mockRepo, logger)
+	statementEdit := domain.QuestionInfo{
+		Question: domain.Question{ID: "1", Statement: "Who owns this now?", UserID: "2"},
+		Answer:   domain.Answer{ID: "10", Answer: "The author", UserID: "2"},
+	}
+	_, err := srv.Update(ctx, statementEdit, "1")
+	if err == nil {
+		t.Fatal("Error expected for a statement edit by a user that is not the author")
+	}
+	assert.Equal(t, "Only The Author Of The Question Can Modify It", err.Error())
+
+	answerEdit := domain.QuestionInfo{
+		Question: domain.Question{ID: "1", Statement: "Who owns this?", UserID: "1"},
+		Answer:   domain.Answer{ID: "10", Answer: "The question author", UserID: "1"},
+	}
+	_, err = srv.Update(ctx, answerEdit, "1")
+	if err == nil {
+		t.Fatal("Error expected for an answer edit by a user that is not the author")
+	}
+	assert.Equal(t, "Only The Author Of The Answer Can Modify It", err.Error())
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUpdateQuestion_AnswerAuthor(t *testing.T) {
+	answerEdit := domain.QuestionInfo{
+		Question: domain.Question{ID: "1", Statement: "Who owns this?", UserID: "2"},
+		Answer:   domain.Answer{ID: "10", Answer: "The author of each part", UserID: "2"},
+	}
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindByID", ctx, "1").Return(ownedQuestion, nil).Once()
+	mockRepo.On("Update", ctx, answerEdit).Return(answerEdit, nil).Once()
+
+	srv := NewAuthorizedMockService(mockRepo, logger)
+	_, err := srv.Update(ctx, answerEdit, "1")
+	if err != nil {
+		t.Error(err)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateAnswer_Forbidden(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindAnswers", ctx, "1").Return(ownedQuestion.Answers, nil).Once()
+
+	srv := NewAuthorizedMockService(mockRepo, logger)
+	_, err := srv.UpdateAnswer(ctx, domain.Answer{ID: "10", QuestionID: "1", Answer: "Not mine", UserID: "1"})
+	if err == nil {
+		t.Fatal("Error expected for an answer edit by a user that is not the author")
+	}
+	assert.Equal(t, "Only The Author Of The Answer Can Modify It", err.Error())
+	mockRepo.AssertNotCalled(t, "UpdateAnswer", mock.Anything, mock.Anything)
+}
+
+func TestDeleteAnswer_Forbidden(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindAnswers", mock.Anything, "1").Return(ownedQuestion.Answers, nil).Once()
+
+	srv := NewAuthorizedMockService(mockRepo, logger)
+	_, err := srv.DeleteAnswer(auth.WithSubject(ctx, "1"), "1", "10")
+	if err == nil {
+		t.Fatal("Error expected for an answer delete by a user that is not the author")
+	}
+	assert.Equal(t, "Only The Author Of The Answer Can Modify It", err.Error())
+	mockRepo.AssertNotCalled(t, "DeleteAnswer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteAnswer_Author(t *testing.T) {
+	authorCtx := auth.WithSubject(ctx, "2")
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindAnswers", authorCtx, "1").Return(ownedQuestion.Answers, nil).Once()
+	mockRepo.On("DeleteAnswer", authorCtx, "1", "10").Return("Answer Deleted Successfully", nil).Once()
+
+	srv := NewAuthorizedMockService(mockRepo, logger)
+	_, err := srv.DeleteAnswer(authorCtx, "1", "10")
+	if err != nil {
+		t.Error(err)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+var ownedComment = domain.Comment{ID: "20", ParentType: domain.TargetQuestion, ParentID: "1", QuestionID: "1", Comment: "Nice question", UserID: "2"}
+
+func TestUpdateComment_Forbidden(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindComment", ctx, "1", "20").Return(ownedComment, nil).Once()
+
+	srv := NewAuthorizedMockService(mockRepo, logger)
+	_, err := srv.UpdateComment(ctx, domain.Comment{ID: "20", QuestionID: "1", Comment: "Not mine", UserID: "1"})
+	if err == nil {
+		t.Fatal("Error expected for a comment edit by a user that is not the author")
+	}
+	assert.Equal(t, "Only The Author Of The Comment Can Modify It", err.Error())
+	mockRepo.AssertNotCalled(t, "UpdateComment", mock.Anything, mock.Anything)
+}
+
+func TestDeleteComment_Forbidden(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindComment", mock.Anything, "1", "20").Return(ownedComment, nil).Once()
+
+	srv := NewAuthorizedMockService(mockRepo, logger)
+	_, err := srv.DeleteComment(auth.WithSubject(ctx, "1"), "1", "20")
+	if err == nil {
+		t.Fatal("Error expected for a comment delete by a user that is not the author")
+	}
+	assert.Equal(t, "Only The Author Of The Comment Can Modify It", err.Error())
+	mockRepo.AssertNotCalled(t, "DeleteComment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteComment_Moderator(t *testing.T) {
+	moderatorCtx := auth.WithRoles(auth.WithSubject(ctx, "3"), []string{domain.RoleModerator})
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindComment", moderatorCtx, "1", "20").Return(ownedComment, nil).Once()
+	mockRepo.On("DeleteComment", moderatorCtx, "1", "20").Return("Comment Deleted Successfully", nil).Once()
+
+	srv := NewAuthorizedMockService(mockRepo, logger)
+	_, err := srv.DeleteComment(moderatorCtx, "1", "20")
+	if err != nil {
+		t.Error(err)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRollbackRevision_Forbidden(t *testing.T) {
+	revisions := []domain.Revision{
+		{QuestionID: "1", Number: 1, TargetType: domain.TargetQuestion, TargetID: "1", Text: "Who owns it?", UserID: "1"},
+		{QuestionID: "1", Number: 2, TargetType: domain.TargetAnswer, TargetID: "10", Text: "Someone", UserID: "2"},
+	}
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindByID", ctx, "1").Return(ownedQuestion, nil)
+	mockRepo.On("FindRevisions", ctx, "1").Return(revisions, nil)
+
+	srv := NewAuthorizedMockService(mockRepo, logger)
+	_, err := srv.RollbackRevision(ctx, "1", 1, "2")
+	if err == nil {
+		t.Fatal("Error expected for a rollback of the statement by a user that is not the author")
+	}
+	assert.Equal(t, "Only The Author Of The Question Can Modify It", err.Error())
+
+	_, err = srv.RollbackRevision(ctx, "1", 2, "1")
+	if err == nil {
+		t.Fatal("Error expected for a rollback of an answer by a user that is not the author")
+	}
+	assert.Equal(t, "Only The Author Of The Answer Can Modify It", err.Error())
+	mockRepo.AssertNotCalled(t, "RestoreRevision", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteQuestion_Moderator(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindByID", mock.Anything, "1").Return(ownedQuestion, nil).Once()
+	mockRepo.On("Delete", mock.Anything, "1", "3").Return("Question Deleted Successfully", nil).Once()
+
+	srv := NewAuthorizedMockService(mockRepo, logger)
+	moderatorCtx := auth.WithRoles(ctx, []string{domain.RoleModerator})
+	_, err := srv.Delete(moderatorCtx, "1", "3")
+	if err != nil {
+		t.Error(err)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGrantRole_Success(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("GrantRole", ctx, "7", domain.RoleModerator).Return(domain.UserRoles{UserID: "7", Roles: []string{domain.RoleModerator}}, nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	roles, err := srv.GrantRole(ctx, "7", domain.RoleModerator)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, []string{domain.RoleModerator}, roles.Roles)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGrantRole_InvalidRole(t *testing.T) {
+	mockRepo := new(mockRepository)
+	srv := NewMockService(mockRepo, logger)
+	for _, role := range []string{"", "owner", "Admin"} {
+		_, err := srv.GrantRole(ctx, "7", role)
+		if err == nil {
+			t.Fatalf("Error expected for the role %q", role)
+		}
+		assert.Equal(t, "The role must be admin or moderator", err.Error())
+	}
+	mockRepo.AssertNotCalled(t, "GrantRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateAPIKey_StoresHash(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("CreateAPIKey", ctx, mock.MatchedBy(func(apiKey domain.APIKey) bool {
+		return apiKey.ID != "" && apiKey.Hash != "" && apiKey.Hash != apiKey.Key && strings.HasPrefix(apiKey.Key, "qak_")
+	})).Return(func(ctx context.Context, apiKey domain.APIKey) domain.APIKey { return apiKey }, nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	apiKey, err := srv.CreateAPIKey(ctx, domain.APIKey{Name: "job", UserID: "job", Scopes: []string{domain.ScopeRead}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEmpty(t, apiKey.Key)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateAPIKey_ExpiredBadRequest(t *testing.T) {
+	mockRepo := new(mockRepository)
+	srv := NewMockService(mockRepo, logger)
+	expired := domain.APIKey{Name: "job", UserID: "job", Scopes: []string{domain.ScopeRead}, ExpiresOn: time.Now().Add(-time.Hour).Unix()}
+	_, err := srv.CreateAPIKey(ctx, expired)
+	if err == nil {
+		t.Fatal("Error expected for an API key that is already expired")
+	}
+	assert.Equal(t, "The expiration of the API key must be in the future", err.Error())
+	mockRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything)
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	mockRepo := new(mockRepository)
+	var stored domain.APIKey
+	mockRepo.On("CreateAPIKey", ctx, mock.Anything).Return(func(ctx context.Context, apiKey domain.APIKey) domain.APIKey {
+		stored = apiKey
+		return apiKey
+	}, nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	created, err := srv.CreateAPIKey(ctx, domain.APIKey{Name: "job", UserID: "job", Scopes: []string{domain.ScopeWrite}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mockRepo.On("FindAPIKeyByHash", ctx, stored.Hash).Return(stored, nil).Once()
+	mockRepo.On("TouchAPIKey", ctx, stored.ID, mock.Anything).Return(nil).Once()
+	apiKey, err := srv.AuthenticateAPIKey(ctx, created.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "job", apiKey.UserID)
+	assert.NotZero(t, apiKey.LastUsedOn)
+
+	_, err = srv.AuthenticateAPIKey(ctx, "not-an-api-key")
+	if err == nil {
+		t.Fatal("Error expected for a key without the API key prefix")
+	}
+	assert.Equal(t, "Invalid API Key", err.Error())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthenticateAPIKey_Expired(t *testing.T) {
+	mockRepo := new(mockRepository)
+	expired := domain.APIKey{ID: "1", UserID: "job", ExpiresOn: time.Now().Add(-time.Minute).Unix()}
+	mockRepo.On("FindAPIKeyByHash", ctx, mock.Anything).Return(expired, nil).Once()
+
+	srv := NewMockService(mockRepo, logger)
+	_, err := srv.AuthenticateAPIKey(ctx, "qak_expired")
+	if err == nil {
+		t.Fatal("Error expected for an expired API key")
+	}
+	assert.Equal(t, "Invalid API Key", err.Error())
+	mockRepo.AssertNotCalled(t, "TouchAPIKey", mock.Anything, mock.Anything, mock.Anything)
+}
+
+//The fake metrics record the values by its label values
+type fakeMetric struct {
+	values map[string]float64
+	lvs    []string
+}
+
+func newFakeMetric() *fakeMetric {
+	return &fakeMetric{values: make(map[string]float64)}
+}
+
+func (m *fakeMetric) With(labelValues ...string) metrics.Counter {
+	return &fakeMetric{values: m.values, lvs: append(append([]string{}, m.lvs...), labelValues...)}
+}
+
+func (m *fakeMetric) Add(delta float64) {
+	m.values[strings.Join(m.lvs, ",")] += delta
+}
+
+func (m *fakeMetric) Observe(value float64) {
+	m.values[strings.Join(m.lvs, ",")]++
+}
+
+type fakeHistogram struct {
+	*fakeMetric
+}
+
+func (h fakeHistogram) With(labelValues ...string) metrics.Histogram {
+	return fakeHistogram{h.fakeMetric.With(labelValues...).(*fakeMetric)}
+}
+
+func TestInstrumentingMiddleware(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindByID", ctx, "1").Return(domain.QuestionInfo{Question: domain.Question{ID: "1"}}, nil).Once()
+	mockRepo.On("FindByID", ctx, "2").Return(domain.QuestionInfo{}, apperror.New(errors.New("Not Found"), apperror.NotFound, "No Question Found")).Once()
+
+	requestCount, errorCount, latency := newFakeMetric(), newFakeMetric(), fakeHistogram{newFakeMetric()}
+	srv := service.NewInstrumentingMiddleware(requestCount, errorCount, latency)(NewMockService(mockRepo, logger))
+	srv.FindByID(ctx, "1")
+	srv.FindByID(ctx, "2")
+
+	assert.Equal(t, map[string]float64{"method,FindByID,outcome,success": 1, "method,FindByID,outcome,error": 1}, requestCount.values)
+	assert.Equal(t, map[string]float64{"method,FindByID,kind,not_found": 1}, errorCount.values)
+	assert.Equal(t, map[string]float64{"method,FindByID,outcome,success": 1, "method,FindByID,outcome,error": 1}, latency.values)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("FindByID", ctx, "1").Return(domain.QuestionInfo{Question: domain.Question{ID: "1"}}, nil).Once()
+	mockRepo.On("FindByID", ctx, "2").Return(domain.QuestionInfo{}, apperror.New(errors.New("Not Found"), apperror.NotFound, "No Question Found")).Once()
+
+	var buf strings.Builder
+	srv := service.NewLoggingMiddleware(log.NewLogfmtLogger(&buf))(NewMockService(mockRepo, logger))
+	srv.FindByID(ctx, "1")
+	srv.FindByID(ctx, "2")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "level=info method=FindByID id=1 took="), lines[0])
+	assert.True(t, strings.HasSuffix(lines[0], "err=null"), lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "level=warn method=FindByID id=2 took="), lines[1])
+	assert.True(t, strings.HasSuffix(lines[1], "err=\"No Question Found\""), lines[1])
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRunPurgeJob_StopsWhenContextIsDone(t *testing.T) {
+	mockRepo := new(mockRepository)
+	mockRepo.On("Purge", mock.Anything, mock.Anything).Return(int64(0), nil)
+
+	jobCtx, stop := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		service.RunPurgeJob(jobCtx, NewMockService(mockRepo, logger), logger, time.Hour, time.Hour)
+		close(done)
+	}()
+	stop()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("The purge job did not stop after its context was done")
+	}
+}
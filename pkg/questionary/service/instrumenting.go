@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+)
+
+//This is the instrumenting layer of the Questionary API
+//Each call to the service is counted and timed by its method and outcome ("success" or "error"),
+//and the failed calls are also counted by the kind of its error.
+type instrumentingMiddleware struct {
+	Service
+	requestCount   metrics.Counter
+	errorCount     metrics.Counter
+	requestLatency metrics.Histogram
+}
+
+//The request count and latency use the "method" and "outcome" labels, the error count uses the "method" and "kind" labels
+func NewInstrumentingMiddleware(requestCount metrics.Counter, errorCount metrics.Counter, requestLatency metrics.Histogram) Middleware {
+	return func(next Service) Service {
+		return &instrumentingMiddleware{
+			Service:        next,
+			requestCount:   requestCount,
+			errorCount:     errorCount,
+			requestLatency: requestLatency,
+		}
+	}
+}
+
+func (mw *instrumentingMiddleware) instrument(method string, begin time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+		mw.errorCount.With("method", method, "kind", apperror.KindOf(err).String()).Add(1)
+	}
+	mw.requestCount.With("method", method, "outcome", outcome).Add(1)
+	mw.requestLatency.With("method", method, "outcome", outcome).Observe(time.Since(begin).Seconds())
+}
+
+func (mw *instrumentingMiddleware) FindAll(ctx context.Context, filter domain.QuestionFilter, page domain.Page) (result domain.QuestionPage, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("FindAll", begin, err)
+	}(time.Now())
+	return mw.Service.FindAll(ctx, filter, page)
+}
+
+func (mw *instrumentingMiddleware) FindByID(ctx context.Context, id string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("FindByID", begin, err)
+	}(time.Now())
+	return mw.Service.FindByID(ctx, id)
+}
+
+func (mw *instrumentingMiddleware) FindByUser(ctx context.Context, userId string, page domain.Page) (result domain.QuestionPage, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("FindByUser", begin, err)
+	}(time.Now())
+	return mw.Service.FindByUser(ctx, userId, page)
+}
+
+func (mw *instrumentingMiddleware) FindByTag(ctx context.Context, tag string) (result []domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("FindByTag", begin, err)
+	}(time.Now())
+	return mw.Service.FindByTag(ctx, tag)
+}
+
+func (mw *instrumentingMiddleware) Search(ctx context.Context, query string, filter domain.QuestionFilter) (result []domain.SearchResult, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("Search", begin, err)
+	}(time.Now())
+	return mw.Service.Search(ctx, query, filter)
+}
+
+func (mw *instrumentingMiddleware) FindTags(ctx context.Context) (result []domain.TagCount, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("FindTags", begin, err)
+	}(time.Now())
+	return mw.Service.FindTags(ctx)
+}
+
+func (mw *instrumentingMiddleware) Create(ctx context.Context, question domain.Question) (result domain.Question, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("Create", begin, err)
+	}(time.Now())
+	return mw.Service.Create(ctx, question)
+}
+
+func (mw *instrumentingMiddleware) Update(ctx context.Context, questionInfo domain.QuestionInfo, id string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("Update", begin, err)
+	}(time.Now())
+	return mw.Service.Update(ctx, questionInfo, id)
+}
+
+func (mw *instrumentingMiddleware) Delete(ctx context.Context, id string, userId string) (result string, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("Delete", begin, err)
+	}(time.Now())
+	return mw.Service.Delete(ctx, id, userId)
+}
+
+func (mw *instrumentingMiddleware) Restore(ctx context.Context, id string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("Restore", begin, err)
+	}(time.Now())
+	return mw.Service.Restore(ctx, id)
+}
+
+func (mw *instrumentingMiddleware) Purge(ctx context.Context, retention time.Duration) (result int64, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("Purge", begin, err)
+	}(time.Now())
+	return mw.Service.Purge(ctx, retention)
+}
+
+func (mw *instrumentingMiddleware) AddAnswer(ctx context.Context, answer domain.Answer) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("AddAnswer", begin, err)
+	}(time.Now())
+	return mw.Service.AddAnswer(ctx, answer)
+}
+
+func (mw *instrumentingMiddleware) FindAnswers(ctx context.Context, questionId string) (result []domain.Answer, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("FindAnswers", begin, err)
+	}(time.Now())
+	return mw.Service.FindAnswers(ctx, questionId)
+}
+
+func (mw *instrumentingMiddleware) UpdateAnswer(ctx context.Context, answer domain.Answer) (result domain.Answer, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("UpdateAnswer", begin, err)
+	}(time.Now())
+	return mw.Service.UpdateAnswer(ctx, answer)
+}
+
+func (mw *instrumentingMiddleware) DeleteAnswer(ctx context.Context, questionId string, answerId string) (result string, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("DeleteAnswer", begin, err)
+	}(time.Now())
+	return mw.Service.DeleteAnswer(ctx, questionId, answerId)
+}
+
+func (mw *instrumentingMiddleware) AcceptAnswer(ctx context.Context, questionId string, answerId string, userId string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("AcceptAnswer", begin, err)
+	}(time.Now())
+	return mw.Service.AcceptAnswer(ctx, questionId, answerId, userId)
+}
+
+func (mw *instrumentingMiddleware) UnacceptAnswer(ctx context.Context, questionId string, userId string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("UnacceptAnswer", begin, err)
+	}(time.Now())
+	return mw.Service.UnacceptAnswer(ctx, questionId, userId)
+}
+
+func (mw *instrumentingMiddleware) Vote(ctx context.Context, targetType string, targetId string, userId string, value int) (result domain.Score, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("Vote", begin, err)
+	}(time.Now())
+	return mw.Service.Vote(ctx, targetType, targetId, userId, value)
+}
+
+func (mw *instrumentingMiddleware) RetractVote(ctx context.Context, targetType string, targetId string, userId string) (result domain.Score, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("RetractVote", begin, err)
+	}(time.Now())
+	return mw.Service.RetractVote(ctx, targetType, targetId, userId)
+}
+
+func (mw *instrumentingMiddleware) AddComment(ctx context.Context, comment domain.Comment) (result domain.Comment, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("AddComment", begin, err)
+	}(time.Now())
+	return mw.Service.AddComment(ctx, comment)
+}
+
+func (mw *instrumentingMiddleware) FindComments(ctx context.Context, parentType string, parentId string) (result []domain.Comment, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("FindComments", begin, err)
+	}(time.Now())
+	return mw.Service.FindComments(ctx, parentType, parentId)
+}
+
+func (mw *instrumentingMiddleware) FindComment(ctx context.Context, questionId string, commentId string) (result domain.Comment, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("FindComment", begin, err)
+	}(time.Now())
+	return mw.Service.FindComment(ctx, questionId, commentId)
+}
+
+func (mw *instrumentingMiddleware) UpdateComment(ctx context.Context, comment domain.Comment) (result domain.Comment, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("UpdateComment", begin, err)
+	}(time.Now())
+	return mw.Service.UpdateComment(ctx, comment)
+}
+
+func (mw *instrumentingMiddleware) DeleteComment(ctx context.Context, questionId string, commentId string) (result string, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("DeleteComment", begin, err)
+	}(time.Now())
+	return mw.Service.DeleteComment(ctx, questionId, commentId)
+}
+
+func (mw *instrumentingMiddleware) FindRevisions(ctx context.Context, questionId string) (result []domain.Revision, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("FindRevisions", begin, err)
+	}(time.Now())
+	return mw.Service.FindRevisions(ctx, questionId)
+}
+
+func (mw *instrumentingMiddleware) RollbackRevision(ctx context.Context, questionId string, number int64, userId string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("RollbackRevision", begin, err)
+	}(time.Now())
+	return mw.Service.RollbackRevision(ctx, questionId, number, userId)
+}
+
+func (mw *instrumentingMiddleware) FindRoles(ctx context.Context, userId string) (result domain.UserRoles, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("FindRoles", begin, err)
+	}(time.Now())
+	return mw.Service.FindRoles(ctx, userId)
+}
+
+func (mw *instrumentingMiddleware) GrantRole(ctx context.Context, userId string, role string) (result domain.UserRoles, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("GrantRole", begin, err)
+	}(time.Now())
+	return mw.Service.GrantRole(ctx, userId, role)
+}
+
+func (mw *instrumentingMiddleware) RevokeRole(ctx context.Context, userId string, role string) (result domain.UserRoles, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("RevokeRole", begin, err)
+	}(time.Now())
+	return mw.Service.RevokeRole(ctx, userId, role)
+}
+
+func (mw *instrumentingMiddleware) CreateAPIKey(ctx context.Context, apiKey domain.APIKey) (result domain.APIKey, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("CreateAPIKey", begin, err)
+	}(time.Now())
+	return mw.Service.CreateAPIKey(ctx, apiKey)
+}
+
+func (mw *instrumentingMiddleware) FindAPIKeys(ctx context.Context) (result []domain.APIKey, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("FindAPIKeys", begin, err)
+	}(time.Now())
+	return mw.Service.FindAPIKeys(ctx)
+}
+
+func (mw *instrumentingMiddleware) RevokeAPIKey(ctx context.Context, id string) (result string, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("RevokeAPIKey", begin, err)
+	}(time.Now())
+	return mw.Service.RevokeAPIKey(ctx, id)
+}
+
+func (mw *instrumentingMiddleware) AuthenticateAPIKey(ctx context.Context, key string) (result domain.APIKey, err error) {
+	defer func(begin time.Time) {
+		mw.instrument("AuthenticateAPIKey", begin, err)
+	}(time.Now())
+	return mw.Service.AuthenticateAPIKey(ctx, key)
+}
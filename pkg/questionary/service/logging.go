@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/requestid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+//This is the logging layer of the Questionary API
+//Each call to the service is logged with its method, its IDs and user, its duration, its error and the request ID.
+//The successful calls are logged as info, the client errors as warnings and the rest of the errors as errors,
+//the API keys and the text of the questions, answers and comments are never logged.
+type loggingMiddleware struct {
+	Service
+	logger log.Logger
+}
+
+func NewLoggingMiddleware(logger log.Logger) Middleware {
+	return func(next Service) Service {
+		return &loggingMiddleware{
+			Service: next,
+			logger:  logger,
+		}
+	}
+}
+
+func (mw *loggingMiddleware) log(ctx context.Context, begin time.Time, err error, keyvals ...interface{}) {
+	keyvals = append(keyvals, "took", time.Since(begin), "err", err)
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.HasTraceID() {
+		keyvals = append(keyvals, "traceId", spanCtx.TraceID().String())
+	}
+
+	base := requestid.Logger(ctx, mw.logger)
+	logger := level.Info(base)
+	if err != nil {
+		logger = level.Error(base)
+		if apperror.KindOf(err).IsClientError() {
+			logger = level.Warn(base)
+		}
+	}
+	logger.Log(keyvals...)
+}
+
+func (mw *loggingMiddleware) FindAll(ctx context.Context, filter domain.QuestionFilter, page domain.Page) (result domain.QuestionPage, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "FindAll")
+	}(time.Now())
+	return mw.Service.FindAll(ctx, filter, page)
+}
+
+func (mw *loggingMiddleware) FindByID(ctx context.Context, id string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "FindByID", "id", id)
+	}(time.Now())
+	return mw.Service.FindByID(ctx, id)
+}
+
+func (mw *loggingMiddleware) FindByUser(ctx context.Context, userId string, page domain.Page) (result domain.QuestionPage, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "FindByUser", "userId", userId)
+	}(time.Now())
+	return mw.Service.FindByUser(ctx, userId, page)
+}
+
+func (mw *loggingMiddleware) FindByTag(ctx context.Context, tag string) (result []domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "FindByTag", "tag", tag)
+	}(time.Now())
+	return mw.Service.FindByTag(ctx, tag)
+}
+
+func (mw *loggingMiddleware) Search(ctx context.Context, query string, filter domain.QuestionFilter) (result []domain.SearchResult, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "Search", "query", query)
+	}(time.Now())
+	return mw.Service.Search(ctx, query, filter)
+}
+
+func (mw *loggingMiddleware) FindTags(ctx context.Context) (result []domain.TagCount, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "FindTags")
+	}(time.Now())
+	return mw.Service.FindTags(ctx)
+}
+
+func (mw *loggingMiddleware) Create(ctx context.Context, question domain.Question) (result domain.Question, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "Create", "id", result.ID, "userId", question.UserID)
+	}(time.Now())
+	return mw.Service.Create(ctx, question)
+}
+
+func (mw *loggingMiddleware) Update(ctx context.Context, questionInfo domain.QuestionInfo, id string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "Update", "id", id, "userId", questionInfo.Question.UserID, "answerId", questionInfo.Answer.ID)
+	}(time.Now())
+	return mw.Service.Update(ctx, questionInfo, id)
+}
+
+func (mw *loggingMiddleware) Delete(ctx context.Context, id string, userId string) (result string, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "Delete", "id", id, "userId", userId)
+	}(time.Now())
+	return mw.Service.Delete(ctx, id, userId)
+}
+
+func (mw *loggingMiddleware) Restore(ctx context.Context, id string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "Restore", "id", id)
+	}(time.Now())
+	return mw.Service.Restore(ctx, id)
+}
+
+func (mw *loggingMiddleware) Purge(ctx context.Context, retention time.Duration) (result int64, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "Purge", "retention", retention)
+	}(time.Now())
+	return mw.Service.Purge(ctx, retention)
+}
+
+func (mw *loggingMiddleware) AddAnswer(ctx context.Context, answer domain.Answer) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "AddAnswer", "questionId", answer.QuestionID, "answerId", answer.ID, "userId", answer.UserID)
+	}(time.Now())
+	return mw.Service.AddAnswer(ctx, answer)
+}
+
+func (mw *loggingMiddleware) FindAnswers(ctx context.Context, questionId string) (result []domain.Answer, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "FindAnswers", "questionId", questionId)
+	}(time.Now())
+	return mw.Service.FindAnswers(ctx, questionId)
+}
+
+func (mw *loggingMiddleware) UpdateAnswer(ctx context.Context, answer domain.Answer) (result domain.Answer, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "UpdateAnswer", "questionId", answer.QuestionID, "answerId", answer.ID, "userId", answer.UserID)
+	}(time.Now())
+	return mw.Service.UpdateAnswer(ctx, answer)
+}
+
+func (mw *loggingMiddleware) DeleteAnswer(ctx context.Context, questionId string, answerId string) (result string, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "DeleteAnswer", "questionId", questionId, "answerId", answerId)
+	}(time.Now())
+	return mw.Service.DeleteAnswer(ctx, questionId, answerId)
+}
+
+func (mw *loggingMiddleware) AcceptAnswer(ctx context.Context, questionId string, answerId string, userId string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "AcceptAnswer", "questionId", questionId, "answerId", answerId, "userId", userId)
+	}(time.Now())
+	return mw.Service.AcceptAnswer(ctx, questionId, answerId, userId)
+}
+
+func (mw *loggingMiddleware) UnacceptAnswer(ctx context.Context, questionId string, userId string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "UnacceptAnswer", "questionId", questionId, "userId", userId)
+	}(time.Now())
+	return mw.Service.UnacceptAnswer(ctx, questionId, userId)
+}
+
+func (mw *loggingMiddleware) Vote(ctx context.Context, targetType string, targetId string, userId string, value int) (result domain.Score, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "Vote", "targetType", targetType, "targetId", targetId, "userId", userId, "value", value)
+	}(time.Now())
+	return mw.Service.Vote(ctx, targetType, targetId, userId, value)
+}
+
+func (mw *loggingMiddleware) RetractVote(ctx context.Context, targetType string, targetId string, userId string) (result domain.Score, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "RetractVote", "targetType", targetType, "targetId", targetId, "userId", userId)
+	}(time.Now())
+	return mw.Service.RetractVote(ctx, targetType, targetId, userId)
+}
+
+func (mw *loggingMiddleware) AddComment(ctx context.Context, comment domain.Comment) (result domain.Comment, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "AddComment", "questionId", comment.QuestionID, "commentId", result.ID, "userId", comment.UserID)
+	}(time.Now())
+	return mw.Service.AddComment(ctx, comment)
+}
+
+func (mw *loggingMiddleware) FindComments(ctx context.Context, parentType string, parentId string) (result []domain.Comment, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "FindComments", "parentType", parentType, "parentId", parentId)
+	}(time.Now())
+	return mw.Service.FindComments(ctx, parentType, parentId)
+}
+
+func (mw *loggingMiddleware) FindComment(ctx context.Context, questionId string, commentId string) (result domain.Comment, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "FindComment", "questionId", questionId, "commentId", commentId)
+	}(time.Now())
+	return mw.Service.FindComment(ctx, questionId, commentId)
+}
+
+func (mw *loggingMiddleware) UpdateComment(ctx context.Context, comment domain.Comment) (result domain.Comment, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "UpdateComment", "questionId", comment.QuestionID, "commentId", comment.ID, "userId", comment.UserID)
+	}(time.Now())
+	return mw.Service.UpdateComment(ctx, comment)
+}
+
+func (mw *loggingMiddleware) DeleteComment(ctx context.Context, questionId string, commentId string) (result string, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "DeleteComment", "questionId", questionId, "commentId", commentId)
+	}(time.Now())
+	return mw.Service.DeleteComment(ctx, questionId, commentId)
+}
+
+func (mw *loggingMiddleware) FindRevisions(ctx context.Context, questionId string) (result []domain.Revision, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "FindRevisions", "questionId", questionId)
+	}(time.Now())
+	return mw.Service.FindRevisions(ctx, questionId)
+}
+
+func (mw *loggingMiddleware) RollbackRevision(ctx context.Context, questionId string, number int64, userId string) (result domain.QuestionInfo, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "RollbackRevision", "questionId", questionId, "number", number, "userId", userId)
+	}(time.Now())
+	return mw.Service.RollbackRevision(ctx, questionId, number, userId)
+}
+
+func (mw *loggingMiddleware) FindRoles(ctx context.Context, userId string) (result domain.UserRoles, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "FindRoles", "userId", userId)
+	}(time.Now())
+	return mw.Service.FindRoles(ctx, userId)
+}
+
+func (mw *loggingMiddleware) GrantRole(ctx context.Context, userId string, role string) (result domain.UserRoles, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "GrantRole", "userId", userId, "role", role)
+	}(time.Now())
+	return mw.Service.GrantRole(ctx, userId, role)
+}
+
+func (mw *loggingMiddleware) RevokeRole(ctx context.Context, userId string, role string) (result domain.UserRoles, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "RevokeRole", "userId", userId, "role", role)
+	}(time.Now())
+	return mw.Service.RevokeRole(ctx, userId, role)
+}
+
+func (mw *loggingMiddleware) CreateAPIKey(ctx context.Context, apiKey domain.APIKey) (result domain.APIKey, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "CreateAPIKey", "id", result.ID, "name", apiKey.Name, "userId", apiKey.UserID)
+	}(time.Now())
+	return mw.Service.CreateAPIKey(ctx, apiKey)
+}
+
+func (mw *loggingMiddleware) FindAPIKeys(ctx context.Context) (result []domain.APIKey, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "FindAPIKeys")
+	}(time.Now())
+	return mw.Service.FindAPIKeys(ctx)
+}
+
+func (mw *loggingMiddleware) RevokeAPIKey(ctx context.Context, id string) (result string, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "RevokeAPIKey", "id", id)
+	}(time.Now())
+	return mw.Service.RevokeAPIKey(ctx, id)
+}
+
+func (mw *loggingMiddleware) AuthenticateAPIKey(ctx context.Context, key string) (result domain.APIKey, err error) {
+	defer func(begin time.Time) {
+		mw.log(ctx, begin, err, "method", "AuthenticateAPIKey", "id", result.ID)
+	}(time.Now())
+	return mw.Service.AuthenticateAPIKey(ctx, key)
+}
@@ -0,0 +1,36 @@
+package service
+
+import "github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+
+//This is the role policy of the Questionary API
+//It maps the methods of the Service to the roles that can call them, a user needs at least one of the roles of a method.
+//The methods that are not listed can be called by any user, the moderators and admins can also edit or delete the content of any user.
+var Policies = map[string][]string{
+	"Restore":    {domain.RoleModerator, domain.RoleAdmin},
+	"Purge":      {domain.RoleAdmin},
+	"FindRoles":  {domain.RoleAdmin},
+	"GrantRole":  {domain.RoleAdmin},
+	"RevokeRole": {domain.RoleAdmin},
+
+	"CreateAPIKey": {domain.RoleAdmin},
+	"FindAPIKeys":  {domain.RoleAdmin},
+	"RevokeAPIKey": {domain.RoleAdmin},
+}
+
+//Methods that do not modify the content, they are the only methods that the API keys without the write scope can call
+var ReadOnlyMethods = map[string]bool{
+	"FindAll":       true,
+	"FindByID":      true,
+	"FindByUser":    true,
+	"FindByTag":     true,
+	"FindTags":      true,
+	"Search":        true,
+	"FindAnswers":   true,
+	"FindComments":  true,
+	"FindRevisions": true,
+	"FindRoles":     true,
+	"FindAPIKeys":   true,
+}
+
+//Roles that can edit or delete the content of any user
+var ModeratorRoles = []string{domain.RoleModerator, domain.RoleAdmin}
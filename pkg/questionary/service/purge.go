@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+)
+
+//Default retention period of the deleted questions and interval between purges
+const (
+	DefaultPurgeRetention = 30 * 24 * time.Hour
+	DefaultPurgeInterval  = time.Hour
+)
+
+//This is the job that permanently deletes the questions that were deleted before the retention period.
+//The purge runs when the job starts and then once every interval until the context is done.
+func RunPurgeJob(ctx context.Context, s Service, logger log.Logger, retention time.Duration, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := s.Purge(ctx, retention)
+		if err != nil {
+			level.Error(logger).Log("msg", fmt.Sprintf("Error purging the deleted questions => %v", err.Error()))
+		} else if purged > 0 {
+			level.Info(logger).Log("msg", fmt.Sprintf("%v deleted questions purged", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
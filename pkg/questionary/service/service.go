@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
 )
@@ -11,14 +12,23 @@ import (
 //Each methods has its own validations.
 type Service interface {
 
-	//Method that returns all question avaliables in the database.
-	FindAll(ctx context.Context) ([]domain.QuestionInfo, error)
+	//Method that returns a page of the questions avaliables in the database that match the filter.
+	FindAll(ctx context.Context, filter domain.QuestionFilter, page domain.Page) (domain.QuestionPage, error)
 
 	//Method that find and return a question with its anwers by its unique ID
 	FindByID(ctx context.Context, id string) (domain.QuestionInfo, error)
 
-	//Method that finds all questions asked by a user related by the User ID
-	FindByUser(ctx context.Context, userId string) ([]domain.QuestionInfo, error)
+	//Method that finds a page of the questions asked by a user related by the User ID
+	FindByUser(ctx context.Context, userId string, page domain.Page) (domain.QuestionPage, error)
+
+	//Method that finds all questions that have the given tag
+	FindByTag(ctx context.Context, tag string) ([]domain.QuestionInfo, error)
+
+	//Method that finds the questions whose statement or answers match the words of the query, sorted by relevance
+	Search(ctx context.Context, query string, filter domain.QuestionFilter) ([]domain.SearchResult, error)
+
+	//Method that returns all the tags used by the questions with its usage count
+	FindTags(ctx context.Context) ([]domain.TagCount, error)
 
 	//Method that create a new Question
 	Create(ctx context.Context, question domain.Question) (domain.Question, error)
@@ -26,9 +36,79 @@ type Service interface {
 	//Method that Update a Question and/or its anwser
 	Update(ctx context.Context, questionInfo domain.QuestionInfo, id string) (domain.QuestionInfo, error)
 
-	//Method that delete a Question by its unique ID
-	Delete(ctx context.Context, id string) (string, error)
+	//Method that delete a Question by its unique ID, the Question can be restored until it is purged
+	Delete(ctx context.Context, id string, userId string) (string, error)
+
+	//Method that restore a deleted Question by its unique ID
+	Restore(ctx context.Context, id string) (domain.QuestionInfo, error)
+
+	//Method that permanently delete the Questions that were deleted before the retention period,
+	//returns the number of purged Questions
+	Purge(ctx context.Context, retention time.Duration) (int64, error)
 
 	//Method that adds an anwer to a existing Question
 	AddAnswer(ctx context.Context, answer domain.Answer) (domain.QuestionInfo, error)
+
+	//Method that returns all the answers of a Question
+	FindAnswers(ctx context.Context, questionId string) ([]domain.Answer, error)
+
+	//Method that Update the text of an answer of a Question
+	UpdateAnswer(ctx context.Context, answer domain.Answer) (domain.Answer, error)
+
+	//Method that delete an answer of a Question by its unique ID
+	DeleteAnswer(ctx context.Context, questionId string, answerId string) (string, error)
+
+	//Method that let the author of a Question mark one of its answers as accepted
+	AcceptAnswer(ctx context.Context, questionId string, answerId string, userId string) (domain.QuestionInfo, error)
+
+	//Method that let the author of a Question remove its accepted answer
+	UnacceptAnswer(ctx context.Context, questionId string, userId string) (domain.QuestionInfo, error)
+
+	//Method that register an up (+1) or down (-1) vote of a user over a Question or an Answer
+	Vote(ctx context.Context, targetType string, targetId string, userId string, value int) (domain.Score, error)
+
+	//Method that remove the vote of a user over a Question or an Answer
+	RetractVote(ctx context.Context, targetType string, targetId string, userId string) (domain.Score, error)
+
+	//Method that adds a comment to a Question or to one of its answers
+	AddComment(ctx context.Context, comment domain.Comment) (domain.Comment, error)
+
+	//Method that returns all the comments of a Question or an answer
+	FindComments(ctx context.Context, parentType string, parentId string) ([]domain.Comment, error)
+
+	//Method that search a comment of a Question by its unique ID
+	FindComment(ctx context.Context, questionId string, commentId string) (domain.Comment, error)
+
+	//Method that Update the text of a comment of a Question
+	UpdateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error)
+
+	//Method that delete a comment of a Question by its unique ID
+	DeleteComment(ctx context.Context, questionId string, commentId string) (string, error)
+
+	//Method that returns the edit history of a Question and its answers
+	FindRevisions(ctx context.Context, questionId string) ([]domain.Revision, error)
+
+	//Method that restore the text that a Question or an answer had before the edit of the revision N
+	RollbackRevision(ctx context.Context, questionId string, number int64, userId string) (domain.QuestionInfo, error)
+
+	//Method that returns the roles granted to a user
+	FindRoles(ctx context.Context, userId string) (domain.UserRoles, error)
+
+	//Method that grant the admin or moderator role to a user
+	GrantRole(ctx context.Context, userId string, role string) (domain.UserRoles, error)
+
+	//Method that revoke the admin or moderator role of a user
+	RevokeRole(ctx context.Context, userId string, role string) (domain.UserRoles, error)
+
+	//Method that creates an API key for a service client, the key is only returned by this method
+	CreateAPIKey(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error)
+
+	//Method that returns all the API keys without its key
+	FindAPIKeys(ctx context.Context) ([]domain.APIKey, error)
+
+	//Method that revoke an API key by its unique ID
+	RevokeAPIKey(ctx context.Context, id string) (string, error)
+
+	//Method that returns the API key of a key that has not expired or been revoked and records its use
+	AuthenticateAPIKey(ctx context.Context, key string) (domain.APIKey, error)
 }
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+//This is the tracing layer of the Questionary API
+//Each call to the service has its own span, child of the span of the HTTP or gRPC request.
+type tracingMiddleware struct {
+	Service
+	tracer trace.Tracer
+	prefix string
+}
+
+func NewTracingMiddleware(tracer trace.Tracer) Middleware {
+	return func(next Service) Service {
+		return &tracingMiddleware{
+			Service: next,
+			tracer:  tracer,
+			prefix:  "service.",
+		}
+	}
+}
+
+func (mw *tracingMiddleware) FindAll(ctx context.Context, filter domain.QuestionFilter, page domain.Page) (result domain.QuestionPage, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindAll")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.FindAll(ctx, filter, page)
+}
+
+func (mw *tracingMiddleware) FindByID(ctx context.Context, id string) (result domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindByID")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.FindByID(ctx, id)
+}
+
+func (mw *tracingMiddleware) FindByUser(ctx context.Context, userId string, page domain.Page) (result domain.QuestionPage, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindByUser")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.FindByUser(ctx, userId, page)
+}
+
+func (mw *tracingMiddleware) FindByTag(ctx context.Context, tag string) (result []domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindByTag")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.FindByTag(ctx, tag)
+}
+
+func (mw *tracingMiddleware) Search(ctx context.Context, query string, filter domain.QuestionFilter) (result []domain.SearchResult, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Search")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.Search(ctx, query, filter)
+}
+
+func (mw *tracingMiddleware) FindTags(ctx context.Context) (result []domain.TagCount, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindTags")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.FindTags(ctx)
+}
+
+func (mw *tracingMiddleware) Create(ctx context.Context, question domain.Question) (result domain.Question, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Create")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.Create(ctx, question)
+}
+
+func (mw *tracingMiddleware) Update(ctx context.Context, questionInfo domain.QuestionInfo, id string) (result domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Update")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.Update(ctx, questionInfo, id)
+}
+
+func (mw *tracingMiddleware) Delete(ctx context.Context, id string, userId string) (result string, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Delete")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.Delete(ctx, id, userId)
+}
+
+func (mw *tracingMiddleware) Restore(ctx context.Context, id string) (result domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Restore")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.Restore(ctx, id)
+}
+
+func (mw *tracingMiddleware) Purge(ctx context.Context, retention time.Duration) (result int64, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Purge")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.Purge(ctx, retention)
+}
+
+func (mw *tracingMiddleware) AddAnswer(ctx context.Context, answer domain.Answer) (result domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"AddAnswer")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.AddAnswer(ctx, answer)
+}
+
+func (mw *tracingMiddleware) FindAnswers(ctx context.Context, questionId string) (result []domain.Answer, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindAnswers")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.FindAnswers(ctx, questionId)
+}
+
+func (mw *tracingMiddleware) UpdateAnswer(ctx context.Context, answer domain.Answer) (result domain.Answer, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"UpdateAnswer")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.UpdateAnswer(ctx, answer)
+}
+
+func (mw *tracingMiddleware) DeleteAnswer(ctx context.Context, questionId string, answerId string) (result string, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"DeleteAnswer")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.DeleteAnswer(ctx, questionId, answerId)
+}
+
+func (mw *tracingMiddleware) AcceptAnswer(ctx context.Context, questionId string, answerId string, userId string) (result domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"AcceptAnswer")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.AcceptAnswer(ctx, questionId, answerId, userId)
+}
+
+func (mw *tracingMiddleware) UnacceptAnswer(ctx context.Context, questionId string, userId string) (result domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"UnacceptAnswer")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.UnacceptAnswer(ctx, questionId, userId)
+}
+
+func (mw *tracingMiddleware) Vote(ctx context.Context, targetType string, targetId string, userId string, value int) (result domain.Score, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"Vote")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.Vote(ctx, targetType, targetId, userId, value)
+}
+
+func (mw *tracingMiddleware) RetractVote(ctx context.Context, targetType string, targetId string, userId string) (result domain.Score, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"RetractVote")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.RetractVote(ctx, targetType, targetId, userId)
+}
+
+func (mw *tracingMiddleware) AddComment(ctx context.Context, comment domain.Comment) (result domain.Comment, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"AddComment")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.AddComment(ctx, comment)
+}
+
+func (mw *tracingMiddleware) FindComments(ctx context.Context, parentType string, parentId string) (result []domain.Comment, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindComments")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.FindComments(ctx, parentType, parentId)
+}
+
+func (mw *tracingMiddleware) FindComment(ctx context.Context, questionId string, commentId string) (result domain.Comment, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindComment")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.FindComment(ctx, questionId, commentId)
+}
+
+func (mw *tracingMiddleware) UpdateComment(ctx context.Context, comment domain.Comment) (result domain.Comment, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"UpdateComment")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.UpdateComment(ctx, comment)
+}
+
+func (mw *tracingMiddleware) DeleteComment(ctx context.Context, questionId string, commentId string) (result string, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"DeleteComment")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.DeleteComment(ctx, questionId, commentId)
+}
+
+func (mw *tracingMiddleware) FindRevisions(ctx context.Context, questionId string) (result []domain.Revision, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindRevisions")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.FindRevisions(ctx, questionId)
+}
+
+func (mw *tracingMiddleware) RollbackRevision(ctx context.Context, questionId string, number int64, userId string) (result domain.QuestionInfo, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"RollbackRevision")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.RollbackRevision(ctx, questionId, number, userId)
+}
+
+func (mw *tracingMiddleware) FindRoles(ctx context.Context, userId string) (result domain.UserRoles, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindRoles")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.FindRoles(ctx, userId)
+}
+
+func (mw *tracingMiddleware) GrantRole(ctx context.Context, userId string, role string) (result domain.UserRoles, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"GrantRole")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.GrantRole(ctx, userId, role)
+}
+
+func (mw *tracingMiddleware) RevokeRole(ctx context.Context, userId string, role string) (result domain.UserRoles, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"RevokeRole")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.RevokeRole(ctx, userId, role)
+}
+
+func (mw *tracingMiddleware) CreateAPIKey(ctx context.Context, apiKey domain.APIKey) (result domain.APIKey, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"CreateAPIKey")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.CreateAPIKey(ctx, apiKey)
+}
+
+func (mw *tracingMiddleware) FindAPIKeys(ctx context.Context) (result []domain.APIKey, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"FindAPIKeys")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.FindAPIKeys(ctx)
+}
+
+func (mw *tracingMiddleware) RevokeAPIKey(ctx context.Context, id string) (result string, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"RevokeAPIKey")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.RevokeAPIKey(ctx, id)
+}
+
+func (mw *tracingMiddleware) AuthenticateAPIKey(ctx context.Context, key string) (result domain.APIKey, err error) {
+	ctx, span := mw.tracer.Start(ctx, mw.prefix+"AuthenticateAPIKey")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	return mw.Service.AuthenticateAPIKey(ctx, key)
+}
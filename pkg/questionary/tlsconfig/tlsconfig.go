@@ -0,0 +1,166 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+)
+
+//This is the TLS of the HTTP and gRPC listeners
+//The certificate of the server and the CA bundle of the clients are read from files and can be reloaded
+//while the server is running, the connections made after a reload use the new files.
+
+//Verification of the client certificates (mutual TLS)
+const (
+	ClientAuthNone     = "none"
+	ClientAuthOptional = "optional"
+	ClientAuthRequire  = "require"
+)
+
+//The certificate and key are PEM files, the client CA file is a PEM bundle with the CAs that sign the client certificates.
+//The client auth is "none", "optional" to verify the certificates of the clients that send one,
+//or "require" to reject the clients without a valid certificate. It is "require" when it is empty and there is a client CA file.
+type Config struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+	ClientAuth   string
+}
+
+//Method that checks if the configuration enables TLS
+func (c Config) Enabled() bool {
+	return c.CertFile != ""
+}
+
+//Method that returns the client auth of the configuration with its default
+func (c Config) ClientAuthMode() string {
+	switch {
+	case c.ClientAuth != "":
+		return c.ClientAuth
+	case c.ClientCAFile != "":
+		return ClientAuthRequire
+	}
+	return ClientAuthNone
+}
+
+//Method that returns the verification of the client certificates of the configuration
+func (c Config) clientAuth() (tls.ClientAuthType, error) {
+	mode := c.ClientAuthMode()
+	switch mode {
+	case ClientAuthNone:
+		return tls.NoClientCert, nil
+	case ClientAuthOptional, ClientAuthRequire:
+		if c.ClientCAFile == "" {
+			return tls.NoClientCert, fmt.Errorf("The %v client auth needs a client CA file.", mode)
+		}
+		if mode == ClientAuthOptional {
+			return tls.VerifyClientCertIfGiven, nil
+		}
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return tls.NoClientCert, fmt.Errorf("Invalid client auth %q, it must be none, optional or require.", c.ClientAuth)
+	}
+}
+
+//Method that checks the configuration without reading its files
+func (c Config) Validate() error {
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.New("The TLS certificate and key must be set together.")
+	}
+	if !c.Enabled() && c.ClientAuthMode() != ClientAuthNone {
+		return errors.New("The client certificates can only be verified when TLS is enabled.")
+	}
+	_, err := c.clientAuth()
+	return err
+}
+
+//A Reloader keeps the certificate and the client CAs of the server and replaces them on each reload
+type Reloader struct {
+	cfg        Config
+	clientAuth tls.ClientAuthType
+	mu         sync.RWMutex
+	cert       *tls.Certificate
+	clientCAs  *x509.CertPool
+}
+
+//Method that reads the files of the configuration, the server does not start when they are not valid
+func NewReloader(cfg Config) (*Reloader, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	clientAuth, _ := cfg.clientAuth()
+	r := &Reloader{cfg: cfg, clientAuth: clientAuth}
+	return r, r.Reload()
+}
+
+//Method that reads the files again, the current certificate and CAs are kept when the new ones are not valid
+func (r *Reloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("Failed to load the TLS certificate => %v", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		data, err := ioutil.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("Failed to read the client CA file => %v", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(data) {
+			return errors.New("Failed to load the client CA file, it has no PEM certificates.")
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	return nil
+}
+
+//Method that returns the TLS configuration of a listener, the protocols are the ALPN protocols of the listener
+//like "h2" for gRPC. Each connection gets the certificate and CAs of the last reload.
+func (r *Reloader) ServerConfig(protocols ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: protocols,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return r.cert, nil
+		},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   protocols,
+				Certificates: []tls.Certificate{*r.cert},
+				ClientAuth:   r.clientAuth,
+				ClientCAs:    r.clientCAs,
+			}, nil
+		},
+	}
+}
+
+//Method that returns the identity of a verified client certificate, that is its common name,
+//or its first URI or DNS name when it has no common name
+func Identity(state *tls.ConnectionState) (string, bool) {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	cert := state.VerifiedChains[0][0]
+	switch {
+	case cert.Subject.CommonName != "":
+		return cert.Subject.CommonName, true
+	case len(cert.URIs) > 0:
+		return cert.URIs[0].String(), true
+	case len(cert.DNSNames) > 0:
+		return cert.DNSNames[0], true
+	}
+	return "", false
+}
@@ -0,0 +1,217 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type issued struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+var serial int64
+
+//Issues a certificate signed by the parent, or a self signed CA when there is no parent
+func issue(t *testing.T, commonName string, parent *issued, server bool) *issued {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	serial++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	signer, signerKey := template, key
+	switch {
+	case parent == nil:
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	case server:
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		template.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+		signer, signerKey = parent.cert, parent.key
+	default:
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	assert.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	return &issued{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (i *issued) keyPEM(t *testing.T) []byte {
+	der, err := x509.MarshalECPrivateKey(i.key)
+	assert.Nil(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func (i *issued) tlsCertificate(t *testing.T) tls.Certificate {
+	cert, err := tls.X509KeyPair(i.pem, i.keyPEM(t))
+	assert.Nil(t, err)
+	return cert
+}
+
+//Writes the certificate of the server and the client CA on the files of the configuration
+func writeFiles(t *testing.T, cfg Config, server, clientCA *issued) {
+	assert.Nil(t, ioutil.WriteFile(cfg.CertFile, server.pem, 0600))
+	assert.Nil(t, ioutil.WriteFile(cfg.KeyFile, server.keyPEM(t), 0600))
+	if clientCA != nil {
+		assert.Nil(t, ioutil.WriteFile(cfg.ClientCAFile, clientCA.pem, 0600))
+	}
+}
+
+func newConfig(t *testing.T, clientAuth string) Config {
+	dir := t.TempDir()
+	return Config{
+		CertFile:     filepath.Join(dir, "server.pem"),
+		KeyFile:      filepath.Join(dir, "server-key.pem"),
+		ClientCAFile: filepath.Join(dir, "client-ca.pem"),
+		ClientAuth:   clientAuth,
+	}
+}
+
+//Starts a server that answers with the identity of the client certificate
+func startServer(reloader *Reloader) *httptest.Server {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, _ := Identity(r.TLS)
+		w.Write([]byte(identity))
+	}))
+	server.TLS = reloader.ServerConfig("http/1.1")
+	server.StartTLS()
+	return server
+}
+
+func client(t *testing.T, serverCA *issued, cert *issued) *http.Client {
+	roots := x509.NewCertPool()
+	roots.AddCert(serverCA.cert)
+	cfg := &tls.Config{RootCAs: roots}
+	if cert != nil {
+		cfg.Certificates = []tls.Certificate{cert.tlsCertificate(t)}
+	}
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+}
+
+func get(client *http.Client, url string) (string, error) {
+	response, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	return string(body), err
+}
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, Config{}.Validate())
+	assert.Nil(t, Config{CertFile: "cert.pem", KeyFile: "key.pem"}.Validate())
+	assert.Nil(t, Config{CertFile: "cert.pem", KeyFile: "key.pem", ClientCAFile: "ca.pem", ClientAuth: ClientAuthOptional}.Validate())
+
+	assert.NotNil(t, Config{CertFile: "cert.pem"}.Validate())
+	assert.NotNil(t, Config{ClientCAFile: "ca.pem"}.Validate())
+	assert.NotNil(t, Config{CertFile: "cert.pem", KeyFile: "key.pem", ClientAuth: ClientAuthRequire}.Validate())
+	assert.NotNil(t, Config{CertFile: "cert.pem", KeyFile: "key.pem", ClientCAFile: "ca.pem", ClientAuth: "always"}.Validate())
+
+	assert.Equal(t, ClientAuthNone, Config{}.ClientAuthMode())
+	assert.Equal(t, ClientAuthRequire, Config{ClientCAFile: "ca.pem"}.ClientAuthMode())
+}
+
+func TestNewReloader_InvalidFiles(t *testing.T) {
+	cfg := newConfig(t, "")
+	_, err := NewReloader(cfg)
+	assert.NotNil(t, err)
+
+	ca := issue(t, "server-ca", nil, false)
+	writeFiles(t, cfg, issue(t, "server", ca, true), nil)
+	assert.Nil(t, ioutil.WriteFile(cfg.ClientCAFile, []byte("not a certificate"), 0600))
+	_, err = NewReloader(cfg)
+	assert.NotNil(t, err)
+}
+
+func TestMutualTLS(t *testing.T) {
+	serverCA, clientCA := issue(t, "server-ca", nil, false), issue(t, "client-ca", nil, false)
+	cfg := newConfig(t, ClientAuthRequire)
+	writeFiles(t, cfg, issue(t, "server", serverCA, true), clientCA)
+	reloader, err := NewReloader(cfg)
+	assert.Nil(t, err)
+	server := startServer(reloader)
+	defer server.Close()
+
+	identity, err := get(client(t, serverCA, issue(t, "billing-service", clientCA, false)), server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "billing-service", identity)
+
+	//The clients without a certificate of the client CA are rejected
+	_, err = get(client(t, serverCA, nil), server.URL)
+	assert.NotNil(t, err)
+	_, err = get(client(t, serverCA, issue(t, "intruder", serverCA, false)), server.URL)
+	assert.NotNil(t, err)
+}
+
+func TestOptionalClientCertificate(t *testing.T) {
+	serverCA, clientCA := issue(t, "server-ca", nil, false), issue(t, "client-ca", nil, false)
+	cfg := newConfig(t, ClientAuthOptional)
+	writeFiles(t, cfg, issue(t, "server", serverCA, true), clientCA)
+	reloader, err := NewReloader(cfg)
+	assert.Nil(t, err)
+	server := startServer(reloader)
+	defer server.Close()
+
+	identity, err := get(client(t, serverCA, nil), server.URL)
+	assert.Nil(t, err)
+	assert.Empty(t, identity)
+
+	identity, err = get(client(t, serverCA, issue(t, "billing-service", clientCA, false)), server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "billing-service", identity)
+}
+
+func TestReload(t *testing.T) {
+	oldCA, newCA := issue(t, "old-ca", nil, false), issue(t, "new-ca", nil, false)
+	cfg := newConfig(t, ClientAuthNone)
+	cfg.ClientCAFile = ""
+	writeFiles(t, cfg, issue(t, "server", oldCA, true), nil)
+	reloader, err := NewReloader(cfg)
+	assert.Nil(t, err)
+	server := startServer(reloader)
+	defer server.Close()
+
+	_, err = get(client(t, oldCA, nil), server.URL)
+	assert.Nil(t, err)
+
+	//The new certificate is used once it is reloaded
+	writeFiles(t, cfg, issue(t, "server", newCA, true), nil)
+	_, err = get(client(t, newCA, nil), server.URL)
+	assert.NotNil(t, err)
+	assert.Nil(t, reloader.Reload())
+	_, err = get(client(t, newCA, nil), server.URL)
+	assert.Nil(t, err)
+
+	//A broken certificate is not loaded and the last one is kept
+	assert.Nil(t, ioutil.WriteFile(cfg.KeyFile, []byte("broken"), 0600))
+	assert.NotNil(t, reloader.Reload())
+	_, err = get(client(t, newCA, nil), server.URL)
+	assert.Nil(t, err)
+}
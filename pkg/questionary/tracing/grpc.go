@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+//Method that returns a gRPC interceptor that starts a span for each call,
+//the span continues the trace of the traceparent metadata of the call.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+		ctx, span := Tracer().Start(ctx, info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.RPCSystemKey.String("grpc")),
+		)
+		defer span.End()
+
+		response, err := handler(ctx, req)
+		code := status.Code(err)
+		span.SetAttributes(attribute.Int64("rpc.grpc.status_code", int64(code)))
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return response, err
+	}
+}
+
+//The incoming metadata as a carrier of the propagator
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key string, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+//Method that returns a command monitor for the MongoDB driver that starts a span for each command,
+//the spans of the commands are children of the span of the repository method that runs them.
+func NewCommandMonitor() *event.CommandMonitor {
+	var spans sync.Map
+	finish := func(requestID int64, err error) {
+		if span, ok := spans.LoadAndDelete(requestID); ok {
+			End(span.(trace.Span), err)
+		}
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			attrs := []attribute.KeyValue{
+				semconv.DBSystemMongoDB,
+				semconv.DBNameKey.String(evt.DatabaseName),
+				semconv.DBOperationKey.String(evt.CommandName),
+			}
+			if collection, ok := evt.Command.Lookup(evt.CommandName).StringValueOK(); ok {
+				attrs = append(attrs, semconv.DBMongoDBCollectionKey.String(collection))
+			}
+			_, span := Tracer().Start(ctx, "mongodb."+evt.CommandName,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+			)
+			spans.Store(evt.RequestID, span)
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			finish(evt.RequestID, nil)
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			finish(evt.RequestID, errors.New(evt.Failure))
+		},
+	}
+}
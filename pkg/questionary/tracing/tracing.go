@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+//This is the distributed tracing of the API
+//The spans follow the OpenTelemetry model and the trace context is propagated with the W3C traceparent header,
+//the incoming HTTP and gRPC requests, the service and repository methods and the MongoDB commands have its own spans.
+
+//Name of the tracer of the API
+const InstrumentationName = "github.com/ismaeljpv/qa-api"
+
+//Exporters of the spans
+const (
+	ExporterNone   = ""
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+//The exporter is "otlp", "stdout", "file" or empty to disable the export of the spans,
+//the OTLP exporter sends the spans over HTTP to the collector of the endpoint (like "localhost:4318")
+//and the file exporter writes them as JSON to the file.
+type Config struct {
+	ServiceName  string
+	Exporter     string
+	OTLPEndpoint string
+	File         string
+}
+
+//Method that sets the global tracer provider with the exporter of the configuration and the W3C trace context propagator,
+//the returned function flushes the pending spans and stops the exporter.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	exporter, closer, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(cfg.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			closer.Close()
+		}
+		return err
+	}, nil
+}
+
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.Exporter {
+	case ExporterNone:
+		return nil, nil, nil
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithInsecure()}
+		if cfg.OTLPEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.OTLPEndpoint))
+		}
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		return exporter, nil, err
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		return exporter, nil, err
+	case ExporterFile:
+		file, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to open the traces file %q: %v", cfg.File, err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		return exporter, file, err
+	default:
+		return nil, nil, fmt.Errorf("Invalid traces exporter %q, it must be otlp, stdout or file.", cfg.Exporter)
+	}
+}
+
+//Method that returns the tracer of the API from the global tracer provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+//Method that ends a span, recording the error when the traced operation failed
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,88 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+)
+
+func setupRecorder() *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return recorder
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	recorder := setupRecorder()
+	router := mux.NewRouter()
+	router.Use(HTTPMiddleware)
+	router.Methods("GET").Path("/question/{id}").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, span := Tracer().Start(r.Context(), "service.FindByID")
+		span.End()
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/question/1", nil)
+	r.Header.Set("traceparent", traceparent)
+	router.ServeHTTP(httptest.NewRecorder(), r)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "service.FindByID", spans[0].Name())
+	assert.Equal(t, "GET /question/{id}", spans[1].Name())
+	assert.Equal(t, traceID, spans[1].SpanContext().TraceID().String())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	recorder := setupRecorder()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", traceparent))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.New("failed")
+	}
+
+	_, err := UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/QuestionaryService/Create"}, handler)
+	assert.NotNil(t, err)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "/QuestionaryService/Create", spans[0].Name())
+	assert.Equal(t, traceID, spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestEnd(t *testing.T) {
+	recorder := setupRecorder()
+	_, span := Tracer().Start(context.Background(), "repository.FindByID")
+	End(span, errors.New("No Question Found"))
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "No Question Found", spans[0].Status().Description)
+	assert.Len(t, spans[0].Events(), 1)
+}
+
+func TestSetupRejectsUnknownExporter(t *testing.T) {
+	_, err := Setup(context.Background(), Config{Exporter: "zipkin"})
+	assert.NotNil(t, err)
+}
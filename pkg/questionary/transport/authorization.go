@@ -0,0 +1,63 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/auth"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/ratelimit"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/service"
+)
+
+//The roles of the authenticated users are read on every request, the repository or its role cache implement it
+type RoleReader interface {
+	FindRoles(ctx context.Context, userId string) (domain.UserRoles, error)
+}
+
+//Method that returns the middlewares shared by the HTTP and gRPC endpoints of a service method,
+//the rate limit goes first so the rejected requests do not reach the database.
+func Middleware(roles RoleReader, limiters *ratelimit.Limiters, method string) endpoint.Middleware {
+	return endpoint.Chain(ratelimit.Middleware(limiters, method), Authorize(roles, method))
+}
+
+//This is the role based authorization of the endpoints, the HTTP and gRPC endpoint sets wrap each endpoint with it.
+//The roles of the authenticated user are loaded into the context so the service can check them,
+//and the calls to a method of the policy table are rejected when the user has none of the roles of the method.
+//The API keys without the write scope can only call the read only methods.
+func Authorize(roles RoleReader, method string) endpoint.Middleware {
+	required := service.Policies[method]
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			subject, ok := auth.Subject(ctx)
+			if !ok {
+				if len(required) > 0 {
+					return nil, apperror.New(auth.ErrMissingToken, apperror.Unauthenticated, auth.ErrMissingToken.Error())
+				}
+				return next(ctx, request)
+			}
+
+			userRoles, err := roles.FindRoles(ctx, subject)
+			if err != nil {
+				return nil, err
+			}
+			ctx = auth.WithRoles(ctx, userRoles.Roles)
+
+			if !service.ReadOnlyMethods[method] && !auth.HasScope(ctx, domain.ScopeWrite) {
+				return nil, apperror.New(errors.New(fmt.Sprintf("The API key of user %v can not call %v", subject, method)),
+					apperror.Forbidden,
+					"The API Key Does Not Have The Write Scope")
+			}
+
+			if len(required) > 0 && !auth.HasRole(ctx, required...) {
+				return nil, apperror.New(errors.New(fmt.Sprintf("User %v is not allowed to call %v", subject, method)),
+					apperror.Forbidden,
+					"The User Does Not Have The Required Role")
+			}
+			return next(ctx, request)
+		}
+	}
+}
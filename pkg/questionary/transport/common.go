@@ -1,20 +1,47 @@
 package transport
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"regexp"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/auth"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
 )
 
 var validate *validator.Validate = validator.New()
 
+//A tag is a lowercase word of up to 25 letters, numbers or the characters "+", "#", "." and "-"
+var tagRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9+#.-]{0,24}$`)
+
+func init() {
+	validate.RegisterValidation("tag", func(fl validator.FieldLevel) bool {
+		return tagRegex.MatchString(fl.Field().String())
+	})
+}
+
 type (
 	GenericRequest struct{}
 
+	FindAllQuestionsRequest struct {
+		Filter domain.QuestionFilter `json:"filter"`
+		Page   domain.Page           `json:"page"`
+	}
+
 	FindQuestionsByUserRequest struct {
-		UserID string `json:"userId"`
+		UserID string      `json:"userId"`
+		Page   domain.Page `json:"page"`
+	}
+
+	SearchQuestionsRequest struct {
+		Query  string                `json:"query"`
+		Filter domain.QuestionFilter `json:"filter"`
+	}
+
+	FindQuestionsByTagRequest struct {
+		Tag string `json:"tag"`
 	}
 
 	UpdateQuestionRequest struct {
@@ -26,6 +53,56 @@ type (
 		ID string `json:"ID"`
 	}
 
+	DeleteQuestionRequest struct {
+		ID     string `json:"ID"`
+		UserID string `json:"userId" validate:"required"`
+	}
+
+	AnswerParamRequest struct {
+		QuestionID string `json:"questionId"`
+		AnswerID   string `json:"answerId"`
+	}
+
+	AcceptAnswerRequest struct {
+		QuestionID string `json:"questionId"`
+		AnswerID   string `json:"answerId"`
+		UserID     string `json:"userId" validate:"required"`
+	}
+
+	CommentParamRequest struct {
+		QuestionID string `json:"questionId"`
+		CommentID  string `json:"commentId"`
+	}
+
+	FindCommentsRequest struct {
+		ParentType string `json:"parentType"`
+		ParentID   string `json:"parentId"`
+	}
+
+	RollbackRevisionRequest struct {
+		QuestionID string `json:"questionId"`
+		Number     int64  `json:"number"`
+		UserID     string `json:"userId" validate:"required"`
+	}
+
+	VoteRequest struct {
+		TargetType string `json:"targetType"`
+		TargetID   string `json:"targetId"`
+		UserID     string `json:"userId" validate:"required"`
+		Value      int    `json:"value"`
+	}
+
+	RoleRequest struct {
+		UserID string `json:"userId" validate:"required"`
+		Role   string `json:"role"`
+	}
+
+	QuestionPageResponse struct {
+		Questions  []domain.QuestionInfo `json:"questions"`
+		NextCursor string                `json:"nextCursor,omitempty"`
+		Next       string                `json:"next,omitempty"`
+	}
+
 	GenericMessageResponse struct {
 		Message string `json:"message"`
 		Status  string `json:"status"`
@@ -33,6 +110,24 @@ type (
 	}
 )
 
+//The user of an authenticated request is the subject of its token, the user sent in the body
+//is only used when the request was not authenticated
+func AuthenticatedUser(ctx context.Context, userId string) string {
+	if subject, ok := auth.Subject(ctx); ok {
+		return subject
+	}
+	return userId
+}
+
+//The answer of a question update is optional, it is only validated when the update has one
+func ValidateQuestionUpdate(info *domain.QuestionInfo) error {
+	if !info.HasAnswerUpdate() {
+		info.Answer = domain.Answer{}
+		return ValidateStruct(&info.Question)
+	}
+	return ValidateStruct(info)
+}
+
 func ValidateStruct(s interface{}) error {
 	errs := validate.Struct(s)
 	if errs != nil {
@@ -2,13 +2,14 @@ package grpc
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/go-kit/kit/endpoint"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/ratelimit"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/service"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/transport"
-	httpError "github.com/ismaeljpv/qa-api/pkg/questionary/transport/http/error"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -19,29 +20,78 @@ type Endpoints struct {
 	FindAllQuestions    endpoint.Endpoint
 	FindQuestionById    endpoint.Endpoint
 	FindQuestionsByUser endpoint.Endpoint
+	FindQuestionsByTag  endpoint.Endpoint
+	FindTags            endpoint.Endpoint
+	SearchQuestions     endpoint.Endpoint
 	CreateQuestion      endpoint.Endpoint
 	AddAnswer           endpoint.Endpoint
 	UpdateQuestion      endpoint.Endpoint
 	DeleteQuestion      endpoint.Endpoint
+	RestoreQuestion     endpoint.Endpoint
+	FindAnswers         endpoint.Endpoint
+	UpdateAnswer        endpoint.Endpoint
+	DeleteAnswer        endpoint.Endpoint
+	AcceptAnswer        endpoint.Endpoint
+	UnacceptAnswer      endpoint.Endpoint
+	Vote                endpoint.Endpoint
+	RetractVote         endpoint.Endpoint
+	AddComment          endpoint.Endpoint
+	FindComments        endpoint.Endpoint
+	UpdateComment       endpoint.Endpoint
+	DeleteComment       endpoint.Endpoint
+	FindRevisions       endpoint.Endpoint
+	RollbackRevision    endpoint.Endpoint
+	FindRoles           endpoint.Endpoint
+	GrantRole           endpoint.Endpoint
+	RevokeRole          endpoint.Endpoint
+	CreateAPIKey        endpoint.Endpoint
+	FindAPIKeys         endpoint.Endpoint
+	RevokeAPIKey        endpoint.Endpoint
 }
 
-func MakeEndpoints(s service.Service) Endpoints {
+//The roles checked by the authorization of the endpoints are read with the role reader, not with the service,
+//so the requests do not log, trace and measure a call to FindRoles
+func MakeEndpoints(s service.Service, roles transport.RoleReader, limiters *ratelimit.Limiters) Endpoints {
 	return Endpoints{
-		FindAllQuestions:    makeFindAllQuestionsEndpoint(s),
-		FindQuestionById:    makeFindQuestionByIDEndpoint(s),
-		FindQuestionsByUser: makeFindQuestiosnByUserEndpoint(s),
-		CreateQuestion:      makeCreateQuestionEndpoint(s),
-		AddAnswer:           makeAddAnswerEndpoint(s),
-		UpdateQuestion:      makeUpdateQuestionEndPoint(s),
-		DeleteQuestion:      makeDeleteQuestionEndpoint(s),
+		FindAllQuestions:    middleware(roles, limiters, "FindAll")(makeFindAllQuestionsEndpoint(s)),
+		FindQuestionById:    middleware(roles, limiters, "FindByID")(makeFindQuestionByIDEndpoint(s)),
+		FindQuestionsByUser: middleware(roles, limiters, "FindByUser")(makeFindQuestiosnByUserEndpoint(s)),
+		FindQuestionsByTag:  middleware(roles, limiters, "FindByTag")(makeFindQuestionsByTagEndpoint(s)),
+		FindTags:            middleware(roles, limiters, "FindTags")(makeFindTagsEndpoint(s)),
+		SearchQuestions:     middleware(roles, limiters, "Search")(makeSearchQuestionsEndpoint(s)),
+		CreateQuestion:      middleware(roles, limiters, "Create")(makeCreateQuestionEndpoint(s)),
+		AddAnswer:           middleware(roles, limiters, "AddAnswer")(makeAddAnswerEndpoint(s)),
+		UpdateQuestion:      middleware(roles, limiters, "Update")(makeUpdateQuestionEndPoint(s)),
+		DeleteQuestion:      middleware(roles, limiters, "Delete")(makeDeleteQuestionEndpoint(s)),
+		RestoreQuestion:     middleware(roles, limiters, "Restore")(makeRestoreQuestionEndpoint(s)),
+		FindAnswers:         middleware(roles, limiters, "FindAnswers")(makeFindAnswersEndpoint(s)),
+		UpdateAnswer:        middleware(roles, limiters, "UpdateAnswer")(makeUpdateAnswerEndpoint(s)),
+		DeleteAnswer:        middleware(roles, limiters, "DeleteAnswer")(makeDeleteAnswerEndpoint(s)),
+		AcceptAnswer:        middleware(roles, limiters, "AcceptAnswer")(makeAcceptAnswerEndpoint(s)),
+		UnacceptAnswer:      middleware(roles, limiters, "UnacceptAnswer")(makeUnacceptAnswerEndpoint(s)),
+		Vote:                middleware(roles, limiters, "Vote")(makeVoteEndpoint(s)),
+		RetractVote:         middleware(roles, limiters, "RetractVote")(makeRetractVoteEndpoint(s)),
+		AddComment:          middleware(roles, limiters, "AddComment")(makeAddCommentEndpoint(s)),
+		FindComments:        middleware(roles, limiters, "FindComments")(makeFindCommentsEndpoint(s)),
+		UpdateComment:       middleware(roles, limiters, "UpdateComment")(makeUpdateCommentEndpoint(s)),
+		DeleteComment:       middleware(roles, limiters, "DeleteComment")(makeDeleteCommentEndpoint(s)),
+		FindRevisions:       middleware(roles, limiters, "FindRevisions")(makeFindRevisionsEndpoint(s)),
+		RollbackRevision:    middleware(roles, limiters, "RollbackRevision")(makeRollbackRevisionEndpoint(s)),
+		FindRoles:           middleware(roles, limiters, "FindRoles")(makeFindRolesEndpoint(s)),
+		GrantRole:           middleware(roles, limiters, "GrantRole")(makeGrantRoleEndpoint(s)),
+		RevokeRole:          middleware(roles, limiters, "RevokeRole")(makeRevokeRoleEndpoint(s)),
+		CreateAPIKey:        middleware(roles, limiters, "CreateAPIKey")(makeCreateAPIKeyEndpoint(s)),
+		FindAPIKeys:         middleware(roles, limiters, "FindAPIKeys")(makeFindAPIKeysEndpoint(s)),
+		RevokeAPIKey:        middleware(roles, limiters, "RevokeAPIKey")(makeRevokeAPIKeyEndpoint(s)),
 	}
 }
 
 func makeFindAllQuestionsEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		questions, err := s.FindAll(ctx)
+		req := request.(transport.FindAllQuestionsRequest)
+		questions, err := s.FindAll(ctx, req.Filter, req.Page)
 		if err != nil {
-			return []domain.QuestionInfo{}, gRPCErrorParser(err)
+			return domain.QuestionPage{}, gRPCErrorParser(err)
 		}
 		return questions, nil
 	}
@@ -61,7 +111,18 @@ func makeFindQuestionByIDEndpoint(s service.Service) endpoint.Endpoint {
 func makeFindQuestiosnByUserEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(transport.FindQuestionsByUserRequest)
-		questions, err := s.FindByUser(ctx, req.UserID)
+		questions, err := s.FindByUser(ctx, req.UserID, req.Page)
+		if err != nil {
+			return domain.QuestionPage{}, gRPCErrorParser(err)
+		}
+		return questions, nil
+	}
+}
+
+func makeFindQuestionsByTagEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.FindQuestionsByTagRequest)
+		questions, err := s.FindByTag(ctx, req.Tag)
 		if err != nil {
 			return []domain.QuestionInfo{}, gRPCErrorParser(err)
 		}
@@ -69,6 +130,27 @@ func makeFindQuestiosnByUserEndpoint(s service.Service) endpoint.Endpoint {
 	}
 }
 
+func makeFindTagsEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		tags, err := s.FindTags(ctx)
+		if err != nil {
+			return []domain.TagCount{}, gRPCErrorParser(err)
+		}
+		return tags, nil
+	}
+}
+
+func makeSearchQuestionsEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.SearchQuestionsRequest)
+		results, err := s.Search(ctx, req.Query, req.Filter)
+		if err != nil {
+			return []domain.SearchResult{}, gRPCErrorParser(err)
+		}
+		return results, nil
+	}
+}
+
 func makeCreateQuestionEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		question := request.(domain.Question)
@@ -103,9 +185,53 @@ func makeUpdateQuestionEndPoint(s service.Service) endpoint.Endpoint {
 }
 
 func makeDeleteQuestionEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.DeleteQuestionRequest)
+		msg, err := s.Delete(ctx, req.ID, req.UserID)
+		if err != nil {
+			return "", gRPCErrorParser(err)
+		}
+		return msg, nil
+	}
+}
+
+func makeRestoreQuestionEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(transport.IDParamRequest)
-		msg, err := s.Delete(ctx, req.ID)
+		questionInfo, err := s.Restore(ctx, req.ID)
+		if err != nil {
+			return domain.QuestionInfo{}, gRPCErrorParser(err)
+		}
+		return questionInfo, nil
+	}
+}
+
+func makeFindAnswersEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.IDParamRequest)
+		answers, err := s.FindAnswers(ctx, req.ID)
+		if err != nil {
+			return []domain.Answer{}, gRPCErrorParser(err)
+		}
+		return answers, nil
+	}
+}
+
+func makeUpdateAnswerEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		answer := request.(domain.Answer)
+		answer, err := s.UpdateAnswer(ctx, answer)
+		if err != nil {
+			return domain.Answer{}, gRPCErrorParser(err)
+		}
+		return answer, nil
+	}
+}
+
+func makeDeleteAnswerEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.AnswerParamRequest)
+		msg, err := s.DeleteAnswer(ctx, req.QuestionID, req.AnswerID)
 		if err != nil {
 			return "", gRPCErrorParser(err)
 		}
@@ -113,26 +239,236 @@ func makeDeleteQuestionEndpoint(s service.Service) endpoint.Endpoint {
 	}
 }
 
-func gRPCErrorParser(err error) error {
+func makeAcceptAnswerEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.AcceptAnswerRequest)
+		questionInfo, err := s.AcceptAnswer(ctx, req.QuestionID, req.AnswerID, req.UserID)
+		if err != nil {
+			return domain.QuestionInfo{}, gRPCErrorParser(err)
+		}
+		return questionInfo, nil
+	}
+}
+
+func makeUnacceptAnswerEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.AcceptAnswerRequest)
+		questionInfo, err := s.UnacceptAnswer(ctx, req.QuestionID, req.UserID)
+		if err != nil {
+			return domain.QuestionInfo{}, gRPCErrorParser(err)
+		}
+		return questionInfo, nil
+	}
+}
+
+func makeVoteEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.VoteRequest)
+		score, err := s.Vote(ctx, req.TargetType, req.TargetID, req.UserID, req.Value)
+		if err != nil {
+			return domain.Score{}, gRPCErrorParser(err)
+		}
+		return score, nil
+	}
+}
+
+func makeRetractVoteEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.VoteRequest)
+		score, err := s.RetractVote(ctx, req.TargetType, req.TargetID, req.UserID)
+		if err != nil {
+			return domain.Score{}, gRPCErrorParser(err)
+		}
+		return score, nil
+	}
+}
+
+func makeAddCommentEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(domain.Comment)
+		comment, err := s.AddComment(ctx, req)
+		if err != nil {
+			return domain.Comment{}, gRPCErrorParser(err)
+		}
+		return comment, nil
+	}
+}
+
+func makeFindCommentsEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.FindCommentsRequest)
+		comments, err := s.FindComments(ctx, req.ParentType, req.ParentID)
+		if err != nil {
+			return []domain.Comment{}, gRPCErrorParser(err)
+		}
+		return comments, nil
+	}
+}
 
-	switch er := err.(type) {
-	case httpError.ClientError:
-		var code codes.Code
-		statusCode, _ := er.ResponseHeaders()
+func makeUpdateCommentEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(domain.Comment)
+		comment, err := s.UpdateComment(ctx, req)
+		if err != nil {
+			return domain.Comment{}, gRPCErrorParser(err)
+		}
+		return comment, nil
+	}
+}
 
-		if statusCode == http.StatusNotFound {
-			code = codes.NotFound
-		} else if statusCode == http.StatusConflict {
-			code = codes.AlreadyExists
-		} else {
-			code = codes.FailedPrecondition
+func makeDeleteCommentEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.CommentParamRequest)
+		msg, err := s.DeleteComment(ctx, req.QuestionID, req.CommentID)
+		if err != nil {
+			return "", gRPCErrorParser(err)
 		}
-		return status.Error(code, er.Error())
+		return msg, nil
+	}
+}
 
-	case httpError.InternalServerError:
-	default:
-		return status.Error(codes.Internal, er.Error())
+func makeFindRevisionsEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.IDParamRequest)
+		revisions, err := s.FindRevisions(ctx, req.ID)
+		if err != nil {
+			return []domain.Revision{}, gRPCErrorParser(err)
+		}
+		return revisions, nil
 	}
+}
 
-	return status.Error(codes.Unknown, err.Error())
+func makeRollbackRevisionEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.RollbackRevisionRequest)
+		questionInfo, err := s.RollbackRevision(ctx, req.QuestionID, req.Number, req.UserID)
+		if err != nil {
+			return domain.QuestionInfo{}, gRPCErrorParser(err)
+		}
+		return questionInfo, nil
+	}
+}
+
+func makeFindRolesEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.RoleRequest)
+		roles, err := s.FindRoles(ctx, req.UserID)
+		if err != nil {
+			return domain.UserRoles{}, gRPCErrorParser(err)
+		}
+		return roles, nil
+	}
+}
+
+func makeGrantRoleEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.RoleRequest)
+		roles, err := s.GrantRole(ctx, req.UserID, req.Role)
+		if err != nil {
+			return domain.UserRoles{}, gRPCErrorParser(err)
+		}
+		return roles, nil
+	}
+}
+
+func makeRevokeRoleEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.RoleRequest)
+		roles, err := s.RevokeRole(ctx, req.UserID, req.Role)
+		if err != nil {
+			return domain.UserRoles{}, gRPCErrorParser(err)
+		}
+		return roles, nil
+	}
+}
+
+func makeCreateAPIKeyEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		apiKey := request.(domain.APIKey)
+		createdKey, err := s.CreateAPIKey(ctx, apiKey)
+		if err != nil {
+			return domain.APIKey{}, gRPCErrorParser(err)
+		}
+		return createdKey, nil
+	}
+}
+
+func makeFindAPIKeysEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		apiKeys, err := s.FindAPIKeys(ctx)
+		if err != nil {
+			return []domain.APIKey{}, gRPCErrorParser(err)
+		}
+		return apiKeys, nil
+	}
+}
+
+func makeRevokeAPIKeyEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.IDParamRequest)
+		msg, err := s.RevokeAPIKey(ctx, req.ID)
+		if err != nil {
+			return "", gRPCErrorParser(err)
+		}
+		return msg, nil
+	}
+}
+
+//The rate limit and role based authorization shared with the HTTP endpoints, its errors are parsed like the errors of the service
+func middleware(roles transport.RoleReader, limiters *ratelimit.Limiters, method string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		protected := transport.Middleware(roles, limiters, method)(next)
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			response, err := protected(ctx, request)
+			if err != nil {
+				return nil, gRPCErrorParser(err)
+			}
+			return response, nil
+		}
+	}
+}
+
+//The gRPC code of each kind of error of the service and the repositories
+var errorCodes = map[apperror.Kind]codes.Code{
+	apperror.Internal:        codes.Internal,
+	apperror.NotFound:        codes.NotFound,
+	apperror.Conflict:        codes.AlreadyExists,
+	apperror.Invalid:         codes.InvalidArgument,
+	apperror.Unauthenticated: codes.Unauthenticated,
+	apperror.Forbidden:       codes.PermissionDenied,
+	apperror.RateLimited:     codes.ResourceExhausted,
+	apperror.Unavailable:     codes.Unavailable,
+}
+
+//Method that returns the gRPC code of the kind of error
+func errorCode(kind apperror.Kind) codes.Code {
+	if code, ok := errorCodes[kind]; ok {
+		return code
+	}
+	return codes.Internal
+}
+
+//The errors of the decoders are returned by go-kit without passing through the endpoint middleware,
+//so the interceptor gives every error of the service the gRPC code of its kind
+func UnaryErrorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		response, err := handler(ctx, req)
+		if err != nil {
+			return response, gRPCErrorParser(err)
+		}
+		return response, nil
+	}
+}
+
+//The errors are returned with the gRPC code of its kind, the errors that already have a gRPC status are kept
+//and the unexpected errors do not show its detail
+func gRPCErrorParser(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	detail, ok := apperror.DetailOf(err)
+	if !ok {
+		detail = "There was an error procesing your request"
+	}
+	return status.Error(errorCode(apperror.KindOf(err)), detail)
 }
@@ -0,0 +1,71 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+	pb "github.com/ismaeljpv/qa-api/pkg/questionary/transport/grpc/protobuff"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorCode(t *testing.T) {
+	for _, kind := range apperror.Kinds {
+		_, ok := errorCodes[kind]
+		assert.True(t, ok, kind.String())
+	}
+	assert.Equal(t, codes.InvalidArgument, errorCode(apperror.Invalid))
+	assert.Equal(t, codes.Internal, errorCode(apperror.Kind(100)))
+}
+
+func TestGRPCErrorParser(t *testing.T) {
+	err := gRPCErrorParser(apperror.New(errors.New("no documents in result"), apperror.NotFound, "Question not found"))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "Question not found", status.Convert(err).Message())
+
+	//The unexpected errors do not show their detail
+	err = gRPCErrorParser(errors.New("connection refused"))
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.NotContains(t, status.Convert(err).Message(), "connection refused")
+
+	//The errors with a gRPC status are kept
+	err = gRPCErrorParser(status.Error(codes.Unauthenticated, "Invalid token"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+//The invalid requests are rejected by the decoders, the interceptor returns them as InvalidArgument like the service errors
+func TestDecoders_InvalidArgument(t *testing.T) {
+	invalid := map[string]func() (interface{}, error){
+		"MissingBody": func() (interface{}, error) { return DecodeCreateQuestionRequest(context.Background(), nil) },
+		"MissingTarget": func() (interface{}, error) {
+			return DecodeVoteRequest(context.Background(), &pb.VoteRequest{UserID: "1"})
+		},
+		"InvalidRevision": func() (interface{}, error) {
+			return DecodeRollbackRevisionRequest(context.Background(), &pb.RollbackRevisionRequest{QuestionID: "1"})
+		},
+		"ValidationFailed": func() (interface{}, error) {
+			return DecodeCreateQuestionRequest(context.Background(), &pb.Question{UserID: "1"})
+		},
+		"MissingAnswerID": func() (interface{}, error) {
+			return DecodeUpdateAnswerRequest(context.Background(), &pb.Answer{Answer: "Yes", QuestionID: "1", UserID: "1"})
+		},
+		"MissingCommentID": func() (interface{}, error) {
+			return DecodeUpdateCommentRequest(context.Background(), &pb.Comment{ParentType: domain.TargetQuestion, Comment: "Nice", UserID: "1"})
+		},
+	}
+
+	interceptor := UnaryErrorInterceptor()
+	for name, decode := range invalid {
+		_, err := decode()
+		assert.Equal(t, apperror.Invalid, apperror.KindOf(err), name)
+
+		_, err = interceptor(context.Background(), nil, nil, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return decode()
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/transport"
 	pb "github.com/ismaeljpv/qa-api/pkg/questionary/transport/grpc/protobuff"
@@ -17,7 +18,9 @@ import (
 func DecodeIDParamRequest(ctx context.Context, request interface{}) (interface{}, error) {
 	id, ok := request.(*wrapperspb.StringValue)
 	if !ok || id == nil {
-		return nil, errors.New("Question ID is required")
+		return nil, apperror.New(errors.New("Question ID is required"),
+			apperror.Invalid,
+			"Question ID is required")
 	}
 	return transport.IDParamRequest{ID: id.GetValue()}, nil
 }
@@ -27,27 +30,99 @@ func DecodeRequest(ctx context.Context, request interface{}) (interface{}, error
 	return req, nil
 }
 
+func DecodeFindAllQuestionsRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	var req transport.FindAllQuestionsRequest
+	find, ok := request.(*pb.FindAllRequest)
+	if !ok || find == nil {
+		return req, nil
+	}
+
+	req.Page = domain.Page{Limit: find.GetPageSize(), Cursor: find.GetPageToken(), Sort: find.GetSort()}
+	req.Filter = decodeQuestionFilter(find)
+	return req, nil
+}
+
+//The filters shared by the listings and the search of questions
+type questionFilterRequest interface {
+	GetUnresolved() bool
+	GetAnswered() *wrapperspb.BoolValue
+	GetAuthorID() string
+	GetAnswererID() string
+	GetCreatedFrom() int64
+	GetCreatedTo() int64
+}
+
+func decodeQuestionFilter(request questionFilterRequest) domain.QuestionFilter {
+	filter := domain.QuestionFilter{
+		Unresolved:  request.GetUnresolved(),
+		AuthorID:    request.GetAuthorID(),
+		AnswererID:  request.GetAnswererID(),
+		CreatedFrom: request.GetCreatedFrom(),
+		CreatedTo:   request.GetCreatedTo(),
+	}
+	if request.GetAnswered() != nil {
+		answered := request.GetAnswered().GetValue()
+		filter.Answered = &answered
+	}
+	return filter
+}
+
 func DecodeFindQuestionByUserRequest(ctx context.Context, request interface{}) (interface{}, error) {
-	userId, ok := request.(*wrapperspb.StringValue)
-	if !ok || userId == nil {
-		return nil, errors.New("UserID ID is required")
+	page, ok := request.(*pb.UserPageRequest)
+	if !ok || page == nil || page.GetUserID() == "" {
+		return nil, apperror.New(errors.New("UserID ID is required"),
+			apperror.Invalid,
+			"UserID ID is required")
+	}
+	return transport.FindQuestionsByUserRequest{
+		UserID: page.GetUserID(),
+		Page:   domain.Page{Limit: page.GetPageSize(), Cursor: page.GetPageToken(), Sort: page.GetSort()},
+	}, nil
+}
+
+func DecodeFindQuestionsByTagRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	tag, ok := request.(*wrapperspb.StringValue)
+	if !ok || tag == nil {
+		return nil, apperror.New(errors.New("Tag is required"),
+			apperror.Invalid,
+			"Tag is required")
 	}
-	return transport.FindQuestionsByUserRequest{UserID: userId.GetValue()}, nil
+	return transport.FindQuestionsByTagRequest{Tag: tag.GetValue()}, nil
+}
+
+func DecodeSearchQuestionsRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	search, ok := request.(*pb.SearchRequest)
+	if !ok || search == nil || search.GetQuery() == "" {
+		return nil, apperror.New(errors.New("Search query is required"),
+			apperror.Invalid,
+			"Search query is required")
+	}
+	return transport.SearchQuestionsRequest{
+		Query:  search.GetQuery(),
+		Filter: decodeQuestionFilter(search),
+	}, nil
 }
 
 func DecodeCreateQuestionRequest(ctx context.Context, request interface{}) (interface{}, error) {
 	var newQuestion domain.Question
 	body, ok := request.(*pb.Question)
 	if !ok || body == nil {
-		return nil, errors.New("No body found in the request")
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
 	}
 
 	newQuestion.Statement = body.GetStatement()
-	newQuestion.UserID = body.GetUserID()
+	newQuestion.UserID = transport.AuthenticatedUser(ctx, body.GetUserID())
+	newQuestion.Tags = body.GetTags()
+	newQuestion.NormalizeTags()
 
 	valErr := transport.ValidateStruct(&newQuestion)
 	if valErr != nil {
-		return nil, valErr
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
 	}
 
 	return newQuestion, nil
@@ -57,49 +132,389 @@ func DecodeAddAnswerRequest(ctx context.Context, request interface{}) (interface
 	var newAnswer domain.Answer
 	body, ok := request.(*pb.Answer)
 	if !ok || body == nil {
-		return nil, errors.New("No body found in the request")
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
 	}
 
 	newAnswer.Answer = body.GetAnswer()
-	newAnswer.UserID = body.GetUserID()
+	newAnswer.UserID = transport.AuthenticatedUser(ctx, body.GetUserID())
 	newAnswer.QuestionID = body.GetQuestionID()
 
 	valErr := transport.ValidateStruct(&newAnswer)
 	if valErr != nil {
-		return nil, valErr
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
 	}
 
 	return newAnswer, nil
 }
 
+func DecodeAnswerParamRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	params, ok := request.(*pb.AnswerID)
+	if !ok || params == nil {
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
+	}
+
+	if params.GetQuestionID() == "" || params.GetAnswerID() == "" {
+		return nil, apperror.New(errors.New("Question ID and Answer ID are required"),
+			apperror.Invalid,
+			"Question ID and Answer ID are required")
+	}
+	return transport.AnswerParamRequest{QuestionID: params.GetQuestionID(), AnswerID: params.GetAnswerID()}, nil
+}
+
+func DecodeUpdateAnswerRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	var answer domain.Answer
+	body, ok := request.(*pb.Answer)
+	if !ok || body == nil {
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
+	}
+
+	if body.GetID() == "" {
+		return nil, apperror.New(errors.New("No Answer ID passed"),
+			apperror.Invalid,
+			"No Answer ID passed")
+	}
+
+	answer.ID = body.GetID()
+	answer.Answer = body.GetAnswer()
+	answer.UserID = transport.AuthenticatedUser(ctx, body.GetUserID())
+	answer.QuestionID = body.GetQuestionID()
+
+	valErr := transport.ValidateStruct(&answer)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+
+	return answer, nil
+}
+
+func DecodeAcceptAnswerRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	var req transport.AcceptAnswerRequest
+	body, ok := request.(*pb.AcceptAnswerRequest)
+	if !ok || body == nil {
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
+	}
+
+	if body.GetQuestionID() == "" || body.GetAnswerID() == "" {
+		return nil, apperror.New(errors.New("Question ID and Answer ID are required"),
+			apperror.Invalid,
+			"Question ID and Answer ID are required")
+	}
+
+	req.QuestionID = body.GetQuestionID()
+	req.AnswerID = body.GetAnswerID()
+	req.UserID = transport.AuthenticatedUser(ctx, body.GetUserID())
+
+	valErr := transport.ValidateStruct(&req)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+	return req, nil
+}
+
+func DecodeDeleteQuestionRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	var req transport.DeleteQuestionRequest
+	body, ok := request.(*pb.DeleteRequest)
+	if !ok || body == nil {
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
+	}
+
+	if body.GetQuestionID() == "" {
+		return nil, apperror.New(errors.New("Question ID is required"),
+			apperror.Invalid,
+			"Question ID is required")
+	}
+
+	req.ID = body.GetQuestionID()
+	req.UserID = transport.AuthenticatedUser(ctx, body.GetUserID())
+	valErr := transport.ValidateStruct(&req)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+	return req, nil
+}
+
+func DecodeUnacceptAnswerRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	var req transport.AcceptAnswerRequest
+	body, ok := request.(*pb.AcceptAnswerRequest)
+	if !ok || body == nil {
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
+	}
+
+	if body.GetQuestionID() == "" {
+		return nil, apperror.New(errors.New("Question ID is required"),
+			apperror.Invalid,
+			"Question ID is required")
+	}
+
+	req.QuestionID = body.GetQuestionID()
+	req.UserID = transport.AuthenticatedUser(ctx, body.GetUserID())
+
+	valErr := transport.ValidateStruct(&req)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+	return req, nil
+}
+
+func DecodeVoteRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	var req transport.VoteRequest
+	body, ok := request.(*pb.VoteRequest)
+	if !ok || body == nil {
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
+	}
+
+	if body.GetTargetType() == "" || body.GetTargetID() == "" {
+		return nil, apperror.New(errors.New("Target Type and Target ID are required"),
+			apperror.Invalid,
+			"Target Type and Target ID are required")
+	}
+
+	req.TargetType = body.GetTargetType()
+	req.TargetID = body.GetTargetID()
+	req.UserID = transport.AuthenticatedUser(ctx, body.GetUserID())
+	req.Value = int(body.GetValue())
+
+	valErr := transport.ValidateStruct(&req)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+	return req, nil
+}
+
+func DecodeAddCommentRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	var newComment domain.Comment
+	body, ok := request.(*pb.Comment)
+	if !ok || body == nil {
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
+	}
+
+	newComment.ParentType = body.GetParentType()
+	newComment.ParentID = body.GetParentID()
+	newComment.QuestionID = body.GetQuestionID()
+	newComment.Comment = body.GetComment()
+	newComment.UserID = transport.AuthenticatedUser(ctx, body.GetUserID())
+
+	valErr := transport.ValidateStruct(&newComment)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+
+	return newComment, nil
+}
+
+func DecodeFindCommentsRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	body, ok := request.(*pb.CommentParent)
+	if !ok || body == nil {
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
+	}
+	return transport.FindCommentsRequest{ParentType: body.GetParentType(), ParentID: body.GetParentID()}, nil
+}
+
+func DecodeUpdateCommentRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	var comment domain.Comment
+	body, ok := request.(*pb.Comment)
+	if !ok || body == nil {
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
+	}
+
+	if body.GetID() == "" || body.GetQuestionID() == "" {
+		return nil, apperror.New(errors.New("Question ID and Comment ID are required"),
+			apperror.Invalid,
+			"Question ID and Comment ID are required")
+	}
+
+	comment.ID = body.GetID()
+	comment.QuestionID = body.GetQuestionID()
+	comment.Comment = body.GetComment()
+	comment.UserID = transport.AuthenticatedUser(ctx, body.GetUserID())
+
+	valErr := transport.ValidateStruct(&comment)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+
+	return comment, nil
+}
+
+func DecodeCommentParamRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	body, ok := request.(*pb.CommentID)
+	if !ok || body == nil {
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
+	}
+
+	if body.GetQuestionID() == "" || body.GetCommentID() == "" {
+		return nil, apperror.New(errors.New("Question ID and Comment ID are required"),
+			apperror.Invalid,
+			"Question ID and Comment ID are required")
+	}
+	return transport.CommentParamRequest{QuestionID: body.GetQuestionID(), CommentID: body.GetCommentID()}, nil
+}
+
+func DecodeRollbackRevisionRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	var req transport.RollbackRevisionRequest
+	body, ok := request.(*pb.RollbackRevisionRequest)
+	if !ok || body == nil {
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
+	}
+
+	if body.GetQuestionID() == "" || body.GetNumber() <= 0 {
+		return nil, apperror.New(errors.New("Question ID and a positive revision number are required"),
+			apperror.Invalid,
+			"Question ID and a positive revision number are required")
+	}
+
+	req.QuestionID = body.GetQuestionID()
+	req.Number = body.GetNumber()
+	req.UserID = transport.AuthenticatedUser(ctx, body.GetUserID())
+
+	valErr := transport.ValidateStruct(&req)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+	return req, nil
+}
+
+func DecodeFindRolesRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	userId, ok := request.(*wrapperspb.StringValue)
+	if !ok || userId == nil || userId.GetValue() == "" {
+		return nil, apperror.New(errors.New("User ID is required"),
+			apperror.Invalid,
+			"User ID is required")
+	}
+	return transport.RoleRequest{UserID: userId.GetValue()}, nil
+}
+
+func DecodeRoleRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	body, ok := request.(*pb.RoleRequest)
+	if !ok || body == nil {
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
+	}
+
+	req := transport.RoleRequest{UserID: body.GetUserID(), Role: body.GetRole()}
+	valErr := transport.ValidateStruct(&req)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+	return req, nil
+}
+
+func DecodeCreateAPIKeyRequest(ctx context.Context, request interface{}) (interface{}, error) {
+	var apiKey domain.APIKey
+	body, ok := request.(*pb.APIKey)
+	if !ok || body == nil {
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
+	}
+
+	apiKey.Name = body.GetName()
+	apiKey.UserID = body.GetUserID()
+	apiKey.Scopes = body.GetScopes()
+	apiKey.ExpiresOn = body.GetExpiresOn()
+
+	valErr := transport.ValidateStruct(&apiKey)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+	return apiKey, nil
+}
+
 func DecodeUpdateQuestionRequest(ctx context.Context, request interface{}) (interface{}, error) {
 	var req transport.UpdateQuestionRequest
 	var info domain.QuestionInfo
 	questionUpdate, ok := request.(*pb.QuestionUpdate)
 	if !ok || questionUpdate == nil {
-		return nil, errors.New("No body found in the request")
+		return nil, apperror.New(errors.New("No body found in the request"),
+			apperror.Invalid,
+			"No body found in the request")
 	}
 
 	if questionUpdate.QuestionID == "" {
-		return nil, errors.New("No Question ID passed")
+		return nil, apperror.New(errors.New("No Question ID passed"),
+			apperror.Invalid,
+			"No Question ID passed")
 	}
 
 	info.Question.ID = questionUpdate.GetQuestionInfo().GetQuestion().GetID()
 	info.Question.Statement = questionUpdate.GetQuestionInfo().GetQuestion().GetStatement()
 	info.Question.CreatedOn = questionUpdate.GetQuestionInfo().GetQuestion().GetCreatedOn()
-	info.Question.UserID = questionUpdate.GetQuestionInfo().GetQuestion().GetUserID()
+	info.Question.UserID = transport.AuthenticatedUser(ctx, questionUpdate.GetQuestionInfo().GetQuestion().GetUserID())
+	info.Question.Tags = questionUpdate.GetQuestionInfo().GetQuestion().GetTags()
+	info.Question.NormalizeTags()
 
 	info.Answer.ID = questionUpdate.GetQuestionInfo().GetAnswer().GetID()
 	info.Answer.Answer = questionUpdate.GetQuestionInfo().GetAnswer().GetAnswer()
 	info.Answer.QuestionID = questionUpdate.GetQuestionInfo().GetAnswer().GetQuestionID()
-	info.Answer.UserID = questionUpdate.GetQuestionInfo().GetAnswer().GetUserID()
+	info.Answer.UserID = transport.AuthenticatedUser(ctx, questionUpdate.GetQuestionInfo().GetAnswer().GetUserID())
 	info.Answer.CreatedOn = questionUpdate.GetQuestionInfo().GetAnswer().GetCreatedOn()
 
 	req.ID = questionUpdate.QuestionID
 	req.QuestionInfo = info
-	valErr := transport.ValidateStruct(&req.QuestionInfo)
+	valErr := transport.ValidateQuestionUpdate(&req.QuestionInfo)
 	if valErr != nil {
-		return nil, valErr
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
 	}
 
 	return req, nil
@@ -114,48 +529,188 @@ func EncodeGetQuestionsResponse(_ context.Context, response interface{}) (interf
 	}
 
 	for _, question := range questions {
-		var info pb.QuestionInfo
-		info.Question = &pb.Question{}
-		info.Answer = &pb.Answer{}
-
-		info.Question.ID = question.Question.ID
-		info.Question.Statement = question.Question.Statement
-		info.Question.UserID = question.Question.UserID
-		info.Question.CreatedOn = question.Question.CreatedOn
+		resQuestions = append(resQuestions, encodeQuestionInfo(question))
+	}
 
-		info.Answer.ID = question.Answer.ID
-		info.Answer.Answer = question.Answer.Answer
-		info.Answer.QuestionID = question.Answer.QuestionID
-		info.Answer.UserID = question.Answer.UserID
+	result.Questions = resQuestions
+	return &result, nil
+}
 
-		resQuestions = append(resQuestions, &info)
+func EncodeQuestionPageResponse(_ context.Context, response interface{}) (interface{}, error) {
+	var result pb.Questions
+	page, ok := response.(domain.QuestionPage)
+	if !ok {
+		return &pb.Questions{}, errors.New("Error parsing the response for gRPC Questions message")
 	}
 
-	result.Questions = resQuestions
+	result.Questions = make([]*pb.QuestionInfo, 0, len(page.Questions))
+	for _, question := range page.Questions {
+		result.Questions = append(result.Questions, encodeQuestionInfo(question))
+	}
+	result.NextPageToken = page.NextCursor
 	return &result, nil
 }
 
 func EncodeQuestionInfoResponse(_ context.Context, response interface{}) (interface{}, error) {
-	var info pb.QuestionInfo
 	question, ok := response.(domain.QuestionInfo)
 	if !ok {
 		return &pb.QuestionInfo{}, errors.New("Error parsing the response for gRPC QuestionInfo message")
 	}
+	return encodeQuestionInfo(question), nil
+}
 
-	info.Question = &pb.Question{}
-	info.Answer = &pb.Answer{}
+func EncodeAnswersResponse(_ context.Context, response interface{}) (interface{}, error) {
+	var result pb.Answers
+	answers, ok := response.([]domain.Answer)
+	if !ok {
+		return &pb.Answers{}, errors.New("Error parsing the response for gRPC Answers message")
+	}
 
-	info.Question.ID = question.Question.ID
-	info.Question.Statement = question.Question.Statement
-	info.Question.UserID = question.Question.UserID
-	info.Question.CreatedOn = question.Question.CreatedOn
+	result.Answers = make([]*pb.Answer, 0, len(answers))
+	for _, answer := range answers {
+		result.Answers = append(result.Answers, encodeAnswer(answer))
+	}
+	return &result, nil
+}
 
-	info.Answer.ID = question.Answer.ID
-	info.Answer.Answer = question.Answer.Answer
-	info.Answer.QuestionID = question.Answer.QuestionID
-	info.Answer.UserID = question.Answer.UserID
+func EncodeAnswerResponse(_ context.Context, response interface{}) (interface{}, error) {
+	answer, ok := response.(domain.Answer)
+	if !ok {
+		return &pb.Answer{}, errors.New("Error parsing the response for gRPC Answer message")
+	}
+	return encodeAnswer(answer), nil
+}
+
+func EncodeTagsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	var result pb.Tags
+	tags, ok := response.([]domain.TagCount)
+	if !ok {
+		return &pb.Tags{}, errors.New("Error parsing the response for gRPC Tags message")
+	}
 
-	return &info, nil
+	result.Tags = make([]*pb.Tag, 0, len(tags))
+	for _, tag := range tags {
+		result.Tags = append(result.Tags, &pb.Tag{Tag: tag.Tag, Count: tag.Count})
+	}
+	return &result, nil
+}
+
+func EncodeSearchResultsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	var result pb.SearchResults
+	results, ok := response.([]domain.SearchResult)
+	if !ok {
+		return &pb.SearchResults{}, errors.New("Error parsing the response for gRPC SearchResults message")
+	}
+
+	result.Results = make([]*pb.SearchResult, 0, len(results))
+	for _, searchResult := range results {
+		result.Results = append(result.Results, &pb.SearchResult{
+			QuestionInfo: encodeQuestionInfo(searchResult.QuestionInfo),
+			Relevance:    searchResult.Relevance,
+		})
+	}
+	return &result, nil
+}
+
+func EncodeUserRolesResponse(_ context.Context, response interface{}) (interface{}, error) {
+	roles, ok := response.(domain.UserRoles)
+	if !ok {
+		return &pb.UserRoles{}, errors.New("Error parsing the response for gRPC UserRoles message")
+	}
+	return &pb.UserRoles{
+		UserID: roles.UserID,
+		Roles:  roles.Roles,
+	}, nil
+}
+
+func EncodeAPIKeyResponse(_ context.Context, response interface{}) (interface{}, error) {
+	apiKey, ok := response.(domain.APIKey)
+	if !ok {
+		return &pb.APIKey{}, errors.New("Error parsing the response for gRPC APIKey message")
+	}
+	return encodeAPIKey(apiKey), nil
+}
+
+func EncodeAPIKeysResponse(_ context.Context, response interface{}) (interface{}, error) {
+	var result pb.APIKeys
+	apiKeys, ok := response.([]domain.APIKey)
+	if !ok {
+		return &pb.APIKeys{}, errors.New("Error parsing the response for gRPC APIKeys message")
+	}
+	for _, apiKey := range apiKeys {
+		result.APIKeys = append(result.APIKeys, encodeAPIKey(apiKey))
+	}
+	return &result, nil
+}
+
+func encodeAPIKey(apiKey domain.APIKey) *pb.APIKey {
+	return &pb.APIKey{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		UserID:     apiKey.UserID,
+		Scopes:     apiKey.Scopes,
+		Key:        apiKey.Key,
+		CreatedOn:  apiKey.CreatedOn,
+		ExpiresOn:  apiKey.ExpiresOn,
+		LastUsedOn: apiKey.LastUsedOn,
+		RevokedOn:  apiKey.RevokedOn,
+	}
+}
+
+func EncodeScoreResponse(_ context.Context, response interface{}) (interface{}, error) {
+	score, ok := response.(domain.Score)
+	if !ok {
+		return &pb.Score{}, errors.New("Error parsing the response for gRPC Score message")
+	}
+	return &pb.Score{
+		TargetType: score.TargetType,
+		TargetID:   score.TargetID,
+		Score:      score.Score,
+	}, nil
+}
+
+func EncodeCommentResponse(_ context.Context, response interface{}) (interface{}, error) {
+	comment, ok := response.(domain.Comment)
+	if !ok {
+		return &pb.Comment{}, errors.New("Error parsing the response for gRPC Comment message")
+	}
+	return encodeComment(comment), nil
+}
+
+func EncodeCommentsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	var result pb.Comments
+	comments, ok := response.([]domain.Comment)
+	if !ok {
+		return &pb.Comments{}, errors.New("Error parsing the response for gRPC Comments message")
+	}
+
+	result.Comments = make([]*pb.Comment, 0, len(comments))
+	for _, comment := range comments {
+		result.Comments = append(result.Comments, encodeComment(comment))
+	}
+	return &result, nil
+}
+
+func EncodeRevisionsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	var result pb.Revisions
+	revisions, ok := response.([]domain.Revision)
+	if !ok {
+		return &pb.Revisions{}, errors.New("Error parsing the response for gRPC Revisions message")
+	}
+
+	result.Revisions = make([]*pb.Revision, 0, len(revisions))
+	for _, revision := range revisions {
+		result.Revisions = append(result.Revisions, &pb.Revision{
+			QuestionID: revision.QuestionID,
+			Number:     revision.Number,
+			TargetType: revision.TargetType,
+			TargetID:   revision.TargetID,
+			Text:       revision.Text,
+			UserID:     revision.UserID,
+			CreatedOn:  revision.CreatedOn,
+		})
+	}
+	return &result, nil
 }
 
 func EncodeQuestionResponse(_ context.Context, response interface{}) (interface{}, error) {
@@ -169,6 +724,8 @@ func EncodeQuestionResponse(_ context.Context, response interface{}) (interface{
 	info.Statement = question.Statement
 	info.UserID = question.UserID
 	info.CreatedOn = question.CreatedOn
+	info.Score = question.Score
+	info.Tags = question.Tags
 	return info, nil
 }
 
@@ -182,3 +739,47 @@ func EncodeGenericMessageResponse(_ context.Context, response interface{}) (inte
 	resp.Message = message
 	return resp, nil
 }
+
+func encodeQuestionInfo(question domain.QuestionInfo) *pb.QuestionInfo {
+	var info pb.QuestionInfo
+	info.Question = &pb.Question{}
+
+	info.Question.ID = question.Question.ID
+	info.Question.Statement = question.Question.Statement
+	info.Question.UserID = question.Question.UserID
+	info.Question.CreatedOn = question.Question.CreatedOn
+	info.Question.Score = question.Question.Score
+	info.Question.Tags = question.Question.Tags
+
+	info.Answer = encodeAnswer(question.Answer)
+	info.AcceptedAnswerID = question.AcceptedAnswerID
+	info.RevisionCount = question.RevisionCount
+	info.Answers = make([]*pb.Answer, 0, len(question.Answers))
+	for _, answer := range question.Answers {
+		info.Answers = append(info.Answers, encodeAnswer(answer))
+	}
+	return &info
+}
+
+func encodeAnswer(answer domain.Answer) *pb.Answer {
+	return &pb.Answer{
+		ID:         answer.ID,
+		Answer:     answer.Answer,
+		QuestionID: answer.QuestionID,
+		UserID:     answer.UserID,
+		CreatedOn:  answer.CreatedOn,
+		Score:      answer.Score,
+	}
+}
+
+func encodeComment(comment domain.Comment) *pb.Comment {
+	return &pb.Comment{
+		ID:         comment.ID,
+		ParentType: comment.ParentType,
+		ParentID:   comment.ParentID,
+		QuestionID: comment.QuestionID,
+		Comment:    comment.Comment,
+		UserID:     comment.UserID,
+		CreatedOn:  comment.CreatedOn,
+	}
+}
@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ismaeljpv/qa-api/pkg/questionary/auth"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/transport"
+	pb "github.com/ismaeljpv/qa-api/pkg/questionary/transport/grpc/protobuff"
+	httpTransport "github.com/ismaeljpv/qa-api/pkg/questionary/transport/http"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+//The user of the decoded request, whatever the type of the request is
+func userOf(t *testing.T, request interface{}) string {
+	switch req := request.(type) {
+	case transport.AcceptAnswerRequest:
+		return req.UserID
+	case transport.VoteRequest:
+		return req.UserID
+	case transport.RollbackRevisionRequest:
+		return req.UserID
+	case domain.Comment:
+		return req.UserID
+	}
+	t.Fatalf("Unexpected request %T", request)
+	return ""
+}
+
+func TestDecoders_UseAuthenticatedUser(t *testing.T) {
+	decoders := map[string]struct {
+		decode  func(context.Context, interface{}) (interface{}, error)
+		request interface{}
+	}{
+		"AcceptAnswer":     {DecodeAcceptAnswerRequest, &pb.AcceptAnswerRequest{QuestionID: "q1", AnswerID: "a1", UserID: "author"}},
+		"UnacceptAnswer":   {DecodeUnacceptAnswerRequest, &pb.AcceptAnswerRequest{QuestionID: "q1", UserID: "author"}},
+		"Vote":             {DecodeVoteRequest, &pb.VoteRequest{TargetType: domain.TargetQuestion, TargetID: "q1", UserID: "author", Value: 1}},
+		"AddComment":       {DecodeAddCommentRequest, &pb.Comment{ParentType: domain.TargetQuestion, ParentID: "q1", QuestionID: "q1", Comment: "Nice", UserID: "author"}},
+		"UpdateComment":    {DecodeUpdateCommentRequest, &pb.Comment{ID: "c1", QuestionID: "q1", Comment: "Nice", UserID: "author"}},
+		"RollbackRevision": {DecodeRollbackRevisionRequest, &pb.RollbackRevisionRequest{QuestionID: "q1", Number: 1, UserID: "author"}},
+	}
+
+	for name, d := range decoders {
+		//The user sent on the body is replaced by the subject of the token
+		req, err := d.decode(auth.WithSubject(context.Background(), "intruder"), d.request)
+		assert.Nil(t, err, name)
+		assert.Equal(t, "intruder", userOf(t, req), name)
+
+		//The user of the body is only used when the request was not authenticated
+		req, err = d.decode(context.Background(), d.request)
+		assert.Nil(t, err, name)
+		assert.Equal(t, "author", userOf(t, req), name)
+	}
+}
+
+//The search of both transports reads the same filters
+func TestDecodeSearchQuestionsRequest_Filters(t *testing.T) {
+	decoded, err := DecodeSearchQuestionsRequest(context.Background(), &pb.SearchRequest{
+		Query:       "gophers",
+		Unresolved:  true,
+		Answered:    wrapperspb.Bool(false),
+		AuthorID:    "1",
+		AnswererID:  "2",
+		CreatedFrom: 100,
+		CreatedTo:   200,
+	})
+	assert.Nil(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/question/search?q=gophers&unresolved=true&answered=false&author=1&answerer=2&createdFrom=100&createdTo=200", nil)
+	expected, err := httpTransport.DecodeSearchQuestionsRequest(context.Background(), r)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, decoded)
+
+	answered := false
+	assert.Equal(t, transport.SearchQuestionsRequest{
+		Query:  "gophers",
+		Filter: domain.QuestionFilter{Unresolved: true, Answered: &answered, AuthorID: "1", AnswererID: "2", CreatedFrom: 100, CreatedTo: 200},
+	}, decoded)
+
+	//Without the answered filter every question is searched
+	decoded, err = DecodeSearchQuestionsRequest(context.Background(), &pb.SearchRequest{Query: "gophers"})
+	assert.Nil(t, err)
+	assert.Nil(t, decoded.(transport.SearchQuestionsRequest).Filter.Answered)
+}
@@ -26,10 +26,12 @@ type Question struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ID        string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	Statement string `protobuf:"bytes,2,opt,name=statement,proto3" json:"statement,omitempty"`
-	UserID    string `protobuf:"bytes,3,opt,name=UserID,proto3" json:"UserID,omitempty"`
-	CreatedOn int64  `protobuf:"varint,4,opt,name=CreatedOn,proto3" json:"CreatedOn,omitempty"`
+	ID        string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Statement string   `protobuf:"bytes,2,opt,name=statement,proto3" json:"statement,omitempty"`
+	UserID    string   `protobuf:"bytes,3,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	CreatedOn int64    `protobuf:"varint,4,opt,name=CreatedOn,proto3" json:"CreatedOn,omitempty"`
+	Score     int64    `protobuf:"varint,5,opt,name=Score,proto3" json:"Score,omitempty"`
+	Tags      []string `protobuf:"bytes,6,rep,name=Tags,proto3" json:"Tags,omitempty"`
 }
 
 func (x *Question) Reset() {
@@ -92,6 +94,20 @@ func (x *Question) GetCreatedOn() int64 {
 	return 0
 }
 
+func (x *Question) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Question) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type Answer struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -102,6 +118,7 @@ type Answer struct {
 	UserID     string `protobuf:"bytes,3,opt,name=UserID,proto3" json:"UserID,omitempty"`
 	QuestionID string `protobuf:"bytes,4,opt,name=QuestionID,proto3" json:"QuestionID,omitempty"`
 	CreatedOn  int64  `protobuf:"varint,5,opt,name=CreatedOn,proto3" json:"CreatedOn,omitempty"`
+	Score      int64  `protobuf:"varint,6,opt,name=Score,proto3" json:"Score,omitempty"`
 }
 
 func (x *Answer) Reset() {
@@ -171,13 +188,23 @@ func (x *Answer) GetCreatedOn() int64 {
 	return 0
 }
 
+func (x *Answer) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
 type QuestionInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Question *Question `protobuf:"bytes,1,opt,name=Question,proto3" json:"Question,omitempty"`
-	Answer   *Answer   `protobuf:"bytes,2,opt,name=Answer,proto3" json:"Answer,omitempty"`
+	Question         *Question `protobuf:"bytes,1,opt,name=Question,proto3" json:"Question,omitempty"`
+	Answer           *Answer   `protobuf:"bytes,2,opt,name=Answer,proto3" json:"Answer,omitempty"`
+	Answers          []*Answer `protobuf:"bytes,3,rep,name=Answers,proto3" json:"Answers,omitempty"`
+	AcceptedAnswerID string    `protobuf:"bytes,4,opt,name=AcceptedAnswerID,proto3" json:"AcceptedAnswerID,omitempty"`
+	RevisionCount    int64     `protobuf:"varint,5,opt,name=RevisionCount,proto3" json:"RevisionCount,omitempty"`
 }
 
 func (x *QuestionInfo) Reset() {
@@ -226,16 +253,37 @@ func (x *QuestionInfo) GetAnswer() *Answer {
 	return nil
 }
 
-type Questions struct {
+func (x *QuestionInfo) GetAnswers() []*Answer {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+func (x *QuestionInfo) GetAcceptedAnswerID() string {
+	if x != nil {
+		return x.AcceptedAnswerID
+	}
+	return ""
+}
+
+func (x *QuestionInfo) GetRevisionCount() int64 {
+	if x != nil {
+		return x.RevisionCount
+	}
+	return 0
+}
+
+type Answers struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Questions []*QuestionInfo `protobuf:"bytes,1,rep,name=Questions,proto3" json:"Questions,omitempty"`
+	Answers []*Answer `protobuf:"bytes,1,rep,name=Answers,proto3" json:"Answers,omitempty"`
 }
 
-func (x *Questions) Reset() {
-	*x = Questions{}
+func (x *Answers) Reset() {
+	*x = Answers{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -243,13 +291,13 @@ func (x *Questions) Reset() {
 	}
 }
 
-func (x *Questions) String() string {
+func (x *Answers) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Questions) ProtoMessage() {}
+func (*Answers) ProtoMessage() {}
 
-func (x *Questions) ProtoReflect() protoreflect.Message {
+func (x *Answers) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -261,28 +309,29 @@ func (x *Questions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Questions.ProtoReflect.Descriptor instead.
-func (*Questions) Descriptor() ([]byte, []int) {
+// Deprecated: Use Answers.ProtoReflect.Descriptor instead.
+func (*Answers) Descriptor() ([]byte, []int) {
 	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Questions) GetQuestions() []*QuestionInfo {
+func (x *Answers) GetAnswers() []*Answer {
 	if x != nil {
-		return x.Questions
+		return x.Answers
 	}
 	return nil
 }
 
-type GenericMessage struct {
+type AnswerID struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	QuestionID string `protobuf:"bytes,1,opt,name=QuestionID,proto3" json:"QuestionID,omitempty"`
+	AnswerID   string `protobuf:"bytes,2,opt,name=AnswerID,proto3" json:"AnswerID,omitempty"`
 }
 
-func (x *GenericMessage) Reset() {
-	*x = GenericMessage{}
+func (x *AnswerID) Reset() {
+	*x = AnswerID{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -290,13 +339,13 @@ func (x *GenericMessage) Reset() {
 	}
 }
 
-func (x *GenericMessage) String() string {
+func (x *AnswerID) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenericMessage) ProtoMessage() {}
+func (*AnswerID) ProtoMessage() {}
 
-func (x *GenericMessage) ProtoReflect() protoreflect.Message {
+func (x *AnswerID) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -308,29 +357,36 @@ func (x *GenericMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenericMessage.ProtoReflect.Descriptor instead.
-func (*GenericMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use AnswerID.ProtoReflect.Descriptor instead.
+func (*AnswerID) Descriptor() ([]byte, []int) {
 	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GenericMessage) GetMessage() string {
+func (x *AnswerID) GetQuestionID() string {
 	if x != nil {
-		return x.Message
+		return x.QuestionID
 	}
 	return ""
 }
 
-type QuestionUpdate struct {
+func (x *AnswerID) GetAnswerID() string {
+	if x != nil {
+		return x.AnswerID
+	}
+	return ""
+}
+
+type DeleteRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	QuestionInfo *QuestionInfo `protobuf:"bytes,1,opt,name=QuestionInfo,proto3" json:"QuestionInfo,omitempty"`
-	QuestionID   string        `protobuf:"bytes,2,opt,name=QuestionID,proto3" json:"QuestionID,omitempty"`
+	QuestionID string `protobuf:"bytes,1,opt,name=QuestionID,proto3" json:"QuestionID,omitempty"`
+	UserID     string `protobuf:"bytes,2,opt,name=UserID,proto3" json:"UserID,omitempty"`
 }
 
-func (x *QuestionUpdate) Reset() {
-	*x = QuestionUpdate{}
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -338,13 +394,13 @@ func (x *QuestionUpdate) Reset() {
 	}
 }
 
-func (x *QuestionUpdate) String() string {
+func (x *DeleteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QuestionUpdate) ProtoMessage() {}
+func (*DeleteRequest) ProtoMessage() {}
 
-func (x *QuestionUpdate) ProtoReflect() protoreflect.Message {
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -356,33 +412,37 @@ func (x *QuestionUpdate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QuestionUpdate.ProtoReflect.Descriptor instead.
-func (*QuestionUpdate) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *QuestionUpdate) GetQuestionInfo() *QuestionInfo {
+func (x *DeleteRequest) GetQuestionID() string {
 	if x != nil {
-		return x.QuestionInfo
+		return x.QuestionID
 	}
-	return nil
+	return ""
 }
 
-func (x *QuestionUpdate) GetQuestionID() string {
+func (x *DeleteRequest) GetUserID() string {
 	if x != nil {
-		return x.QuestionID
+		return x.UserID
 	}
 	return ""
 }
 
-type EmptyMessage struct {
+type AcceptAnswerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	QuestionID string `protobuf:"bytes,1,opt,name=QuestionID,proto3" json:"QuestionID,omitempty"`
+	AnswerID   string `protobuf:"bytes,2,opt,name=AnswerID,proto3" json:"AnswerID,omitempty"`
+	UserID     string `protobuf:"bytes,3,opt,name=UserID,proto3" json:"UserID,omitempty"`
 }
 
-func (x *EmptyMessage) Reset() {
-	*x = EmptyMessage{}
+func (x *AcceptAnswerRequest) Reset() {
+	*x = AcceptAnswerRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -390,13 +450,13 @@ func (x *EmptyMessage) Reset() {
 	}
 }
 
-func (x *EmptyMessage) String() string {
+func (x *AcceptAnswerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmptyMessage) ProtoMessage() {}
+func (*AcceptAnswerRequest) ProtoMessage() {}
 
-func (x *EmptyMessage) ProtoReflect() protoreflect.Message {
+func (x *AcceptAnswerRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -408,167 +468,2044 @@ func (x *EmptyMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmptyMessage.ProtoReflect.Descriptor instead.
-func (*EmptyMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use AcceptAnswerRequest.ProtoReflect.Descriptor instead.
+func (*AcceptAnswerRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{6}
 }
 
-var File_pkg_questionary_transport_grpc_protobuff_questionary_proto protoreflect.FileDescriptor
+func (x *AcceptAnswerRequest) GetQuestionID() string {
+	if x != nil {
+		return x.QuestionID
+	}
+	return ""
+}
 
-var file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDesc = []byte{
-	0x0a, 0x3a, 0x70, 0x6b, 0x67, 0x2f, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x72,
-	0x79, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x2f, 0x67, 0x72, 0x70, 0x63,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x66, 0x2f, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x69, 0x6f, 0x6e, 0x61, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72,
-	0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x6e, 0x0a, 0x08,
-	0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61,
-	0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1c,
-	0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x22, 0x86, 0x01, 0x0a,
-	0x06, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6e, 0x73, 0x77, 0x65,
-	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12,
-	0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x73, 0x74,
-	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x51, 0x75, 0x65,
-	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x64, 0x4f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x64, 0x4f, 0x6e, 0x22, 0x56, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
-	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x25, 0x0a, 0x08, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x08, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x06,
-	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x41,
-	0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x06, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x22, 0x38, 0x0a,
-	0x09, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x09, 0x51, 0x75,
-	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x51, 0x75,
-	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x2a, 0x0a, 0x0e, 0x47, 0x65, 0x6e, 0x65, 0x72,
-	0x69, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0x63, 0x0a, 0x0e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x31, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
-	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x51, 0x75,
-	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0c, 0x51, 0x75, 0x65, 0x73,
-	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1e, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x73,
-	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x51, 0x75,
-	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0x0e, 0x0a, 0x0c, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xd3, 0x02, 0x0a, 0x12, 0x51, 0x75, 0x65,
-	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x72, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x24, 0x0a, 0x07, 0x46, 0x69, 0x6e, 0x64, 0x41, 0x6c, 0x6c, 0x12, 0x0d, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x0a, 0x2e, 0x51, 0x75, 0x65, 0x73,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x36, 0x0a, 0x0a, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x55,
-	0x73, 0x65, 0x72, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x1a, 0x0a, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x37, 0x0a,
-	0x08, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x0d, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69,
-	0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1e, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x12, 0x09, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x09, 0x2e, 0x51, 0x75,
-	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x12, 0x0f, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x1a, 0x0d, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
-	0x12, 0x23, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x07, 0x2e,
-	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x1a, 0x0d, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
-	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x37, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12,
-	0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x0f, 0x2e,
-	0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x46,
-	0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x73, 0x6d,
-	0x61, 0x65, 0x6c, 0x6a, 0x70, 0x76, 0x2f, 0x71, 0x61, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x6b,
-	0x67, 0x2f, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x72, 0x79, 0x2f, 0x74, 0x72,
-	0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x66, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *AcceptAnswerRequest) GetAnswerID() string {
+	if x != nil {
+		return x.AnswerID
+	}
+	return ""
 }
 
-var (
-	file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescOnce sync.Once
-	file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescData = file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDesc
-)
+func (x *AcceptAnswerRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
 
-func file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP() []byte {
-	file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescOnce.Do(func() {
-		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescData)
-	})
-	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescData
+type VoteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetType string `protobuf:"bytes,1,opt,name=TargetType,proto3" json:"TargetType,omitempty"`
+	TargetID   string `protobuf:"bytes,2,opt,name=TargetID,proto3" json:"TargetID,omitempty"`
+	UserID     string `protobuf:"bytes,3,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	Value      int32  `protobuf:"varint,4,opt,name=Value,proto3" json:"Value,omitempty"`
 }
 
-var file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
-var file_pkg_questionary_transport_grpc_protobuff_questionary_proto_goTypes = []interface{}{
-	(*Question)(nil),               // 0: Question
-	(*Answer)(nil),                 // 1: Answer
-	(*QuestionInfo)(nil),           // 2: QuestionInfo
-	(*Questions)(nil),              // 3: Questions
-	(*GenericMessage)(nil),         // 4: GenericMessage
-	(*QuestionUpdate)(nil),         // 5: QuestionUpdate
-	(*EmptyMessage)(nil),           // 6: EmptyMessage
-	(*wrapperspb.StringValue)(nil), // 7: google.protobuf.StringValue
+func (x *VoteRequest) Reset() {
+	*x = VoteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-var file_pkg_questionary_transport_grpc_protobuff_questionary_proto_depIdxs = []int32{
-	0,  // 0: QuestionInfo.Question:type_name -> Question
-	1,  // 1: QuestionInfo.Answer:type_name -> Answer
-	2,  // 2: Questions.Questions:type_name -> QuestionInfo
-	2,  // 3: QuestionUpdate.QuestionInfo:type_name -> QuestionInfo
-	6,  // 4: QuestionaryService.FindAll:input_type -> EmptyMessage
-	7,  // 5: QuestionaryService.FindByUser:input_type -> google.protobuf.StringValue
-	7,  // 6: QuestionaryService.FindByID:input_type -> google.protobuf.StringValue
-	0,  // 7: QuestionaryService.Create:input_type -> Question
-	5,  // 8: QuestionaryService.Update:input_type -> QuestionUpdate
-	1,  // 9: QuestionaryService.AddAnswer:input_type -> Answer
-	7,  // 10: QuestionaryService.Delete:input_type -> google.protobuf.StringValue
-	3,  // 11: QuestionaryService.FindAll:output_type -> Questions
-	3,  // 12: QuestionaryService.FindByUser:output_type -> Questions
-	2,  // 13: QuestionaryService.FindByID:output_type -> QuestionInfo
-	0,  // 14: QuestionaryService.Create:output_type -> Question
-	2,  // 15: QuestionaryService.Update:output_type -> QuestionInfo
-	2,  // 16: QuestionaryService.AddAnswer:output_type -> QuestionInfo
-	4,  // 17: QuestionaryService.Delete:output_type -> GenericMessage
-	11, // [11:18] is the sub-list for method output_type
-	4,  // [4:11] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+
+func (x *VoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_pkg_questionary_transport_grpc_protobuff_questionary_proto_init() }
-func file_pkg_questionary_transport_grpc_protobuff_questionary_proto_init() {
-	if File_pkg_questionary_transport_grpc_protobuff_questionary_proto != nil {
-		return
+func (*VoteRequest) ProtoMessage() {}
+
+func (x *VoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Question); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteRequest.ProtoReflect.Descriptor instead.
+func (*VoteRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VoteRequest) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *VoteRequest) GetTargetID() string {
+	if x != nil {
+		return x.TargetID
+	}
+	return ""
+}
+
+func (x *VoteRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *VoteRequest) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type Score struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetType string `protobuf:"bytes,1,opt,name=TargetType,proto3" json:"TargetType,omitempty"`
+	TargetID   string `protobuf:"bytes,2,opt,name=TargetID,proto3" json:"TargetID,omitempty"`
+	Score      int64  `protobuf:"varint,3,opt,name=Score,proto3" json:"Score,omitempty"`
+}
+
+func (x *Score) Reset() {
+	*x = Score{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Score) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Score) ProtoMessage() {}
+
+func (x *Score) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Answer); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Score.ProtoReflect.Descriptor instead.
+func (*Score) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Score) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *Score) GetTargetID() string {
+	if x != nil {
+		return x.TargetID
+	}
+	return ""
+}
+
+func (x *Score) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type Tag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag   string `protobuf:"bytes,1,opt,name=Tag,proto3" json:"Tag,omitempty"`
+	Count int64  `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QuestionInfo); i {
-			case 0:
-				return &v.state
-			case 1:
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Tag) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Tag) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type Tags struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tags []*Tag `protobuf:"bytes,1,rep,name=Tags,proto3" json:"Tags,omitempty"`
+}
+
+func (x *Tags) Reset() {
+	*x = Tags{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tags) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tags) ProtoMessage() {}
+
+func (x *Tags) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tags.ProtoReflect.Descriptor instead.
+func (*Tags) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Tags) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type Comment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID         string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	ParentType string `protobuf:"bytes,2,opt,name=ParentType,proto3" json:"ParentType,omitempty"`
+	ParentID   string `protobuf:"bytes,3,opt,name=ParentID,proto3" json:"ParentID,omitempty"`
+	QuestionID string `protobuf:"bytes,4,opt,name=QuestionID,proto3" json:"QuestionID,omitempty"`
+	Comment    string `protobuf:"bytes,5,opt,name=Comment,proto3" json:"Comment,omitempty"`
+	UserID     string `protobuf:"bytes,6,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	CreatedOn  int64  `protobuf:"varint,7,opt,name=CreatedOn,proto3" json:"CreatedOn,omitempty"`
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Comment) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *Comment) GetParentType() string {
+	if x != nil {
+		return x.ParentType
+	}
+	return ""
+}
+
+func (x *Comment) GetParentID() string {
+	if x != nil {
+		return x.ParentID
+	}
+	return ""
+}
+
+func (x *Comment) GetQuestionID() string {
+	if x != nil {
+		return x.QuestionID
+	}
+	return ""
+}
+
+func (x *Comment) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Comment) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedOn() int64 {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return 0
+}
+
+type Comments struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Comments []*Comment `protobuf:"bytes,1,rep,name=Comments,proto3" json:"Comments,omitempty"`
+}
+
+func (x *Comments) Reset() {
+	*x = Comments{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Comments) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comments) ProtoMessage() {}
+
+func (x *Comments) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comments.ProtoReflect.Descriptor instead.
+func (*Comments) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Comments) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+type CommentID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuestionID string `protobuf:"bytes,1,opt,name=QuestionID,proto3" json:"QuestionID,omitempty"`
+	CommentID  string `protobuf:"bytes,2,opt,name=CommentID,proto3" json:"CommentID,omitempty"`
+}
+
+func (x *CommentID) Reset() {
+	*x = CommentID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommentID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentID) ProtoMessage() {}
+
+func (x *CommentID) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentID.ProtoReflect.Descriptor instead.
+func (*CommentID) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CommentID) GetQuestionID() string {
+	if x != nil {
+		return x.QuestionID
+	}
+	return ""
+}
+
+func (x *CommentID) GetCommentID() string {
+	if x != nil {
+		return x.CommentID
+	}
+	return ""
+}
+
+type CommentParent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ParentType string `protobuf:"bytes,1,opt,name=ParentType,proto3" json:"ParentType,omitempty"`
+	ParentID   string `protobuf:"bytes,2,opt,name=ParentID,proto3" json:"ParentID,omitempty"`
+}
+
+func (x *CommentParent) Reset() {
+	*x = CommentParent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommentParent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentParent) ProtoMessage() {}
+
+func (x *CommentParent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentParent.ProtoReflect.Descriptor instead.
+func (*CommentParent) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CommentParent) GetParentType() string {
+	if x != nil {
+		return x.ParentType
+	}
+	return ""
+}
+
+func (x *CommentParent) GetParentID() string {
+	if x != nil {
+		return x.ParentID
+	}
+	return ""
+}
+
+type Revision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuestionID string `protobuf:"bytes,1,opt,name=QuestionID,proto3" json:"QuestionID,omitempty"`
+	Number     int64  `protobuf:"varint,2,opt,name=Number,proto3" json:"Number,omitempty"`
+	TargetType string `protobuf:"bytes,3,opt,name=TargetType,proto3" json:"TargetType,omitempty"`
+	TargetID   string `protobuf:"bytes,4,opt,name=TargetID,proto3" json:"TargetID,omitempty"`
+	Text       string `protobuf:"bytes,5,opt,name=Text,proto3" json:"Text,omitempty"`
+	UserID     string `protobuf:"bytes,6,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	CreatedOn  int64  `protobuf:"varint,7,opt,name=CreatedOn,proto3" json:"CreatedOn,omitempty"`
+}
+
+func (x *Revision) Reset() {
+	*x = Revision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Revision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revision) ProtoMessage() {}
+
+func (x *Revision) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revision.ProtoReflect.Descriptor instead.
+func (*Revision) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Revision) GetQuestionID() string {
+	if x != nil {
+		return x.QuestionID
+	}
+	return ""
+}
+
+func (x *Revision) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Revision) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *Revision) GetTargetID() string {
+	if x != nil {
+		return x.TargetID
+	}
+	return ""
+}
+
+func (x *Revision) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Revision) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *Revision) GetCreatedOn() int64 {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return 0
+}
+
+type Revisions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Revisions []*Revision `protobuf:"bytes,1,rep,name=Revisions,proto3" json:"Revisions,omitempty"`
+}
+
+func (x *Revisions) Reset() {
+	*x = Revisions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Revisions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revisions) ProtoMessage() {}
+
+func (x *Revisions) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revisions.ProtoReflect.Descriptor instead.
+func (*Revisions) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Revisions) GetRevisions() []*Revision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+type RollbackRevisionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuestionID string `protobuf:"bytes,1,opt,name=QuestionID,proto3" json:"QuestionID,omitempty"`
+	Number     int64  `protobuf:"varint,2,opt,name=Number,proto3" json:"Number,omitempty"`
+	UserID     string `protobuf:"bytes,3,opt,name=UserID,proto3" json:"UserID,omitempty"`
+}
+
+func (x *RollbackRevisionRequest) Reset() {
+	*x = RollbackRevisionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RollbackRevisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackRevisionRequest) ProtoMessage() {}
+
+func (x *RollbackRevisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackRevisionRequest.ProtoReflect.Descriptor instead.
+func (*RollbackRevisionRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RollbackRevisionRequest) GetQuestionID() string {
+	if x != nil {
+		return x.QuestionID
+	}
+	return ""
+}
+
+func (x *RollbackRevisionRequest) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *RollbackRevisionRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+type RoleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID string `protobuf:"bytes,1,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	Role   string `protobuf:"bytes,2,opt,name=Role,proto3" json:"Role,omitempty"`
+}
+
+func (x *RoleRequest) Reset() {
+	*x = RoleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleRequest) ProtoMessage() {}
+
+func (x *RoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleRequest.ProtoReflect.Descriptor instead.
+func (*RoleRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RoleRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *RoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type UserRoles struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID string   `protobuf:"bytes,1,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	Roles  []string `protobuf:"bytes,2,rep,name=Roles,proto3" json:"Roles,omitempty"`
+}
+
+func (x *UserRoles) Reset() {
+	*x = UserRoles{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserRoles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserRoles) ProtoMessage() {}
+
+func (x *UserRoles) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserRoles.ProtoReflect.Descriptor instead.
+func (*UserRoles) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UserRoles) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *UserRoles) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type APIKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID         string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Name       string   `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	UserID     string   `protobuf:"bytes,3,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	Scopes     []string `protobuf:"bytes,4,rep,name=Scopes,proto3" json:"Scopes,omitempty"`
+	Key        string   `protobuf:"bytes,5,opt,name=Key,proto3" json:"Key,omitempty"`
+	CreatedOn  int64    `protobuf:"varint,6,opt,name=CreatedOn,proto3" json:"CreatedOn,omitempty"`
+	ExpiresOn  int64    `protobuf:"varint,7,opt,name=ExpiresOn,proto3" json:"ExpiresOn,omitempty"`
+	LastUsedOn int64    `protobuf:"varint,8,opt,name=LastUsedOn,proto3" json:"LastUsedOn,omitempty"`
+	RevokedOn  int64    `protobuf:"varint,9,opt,name=RevokedOn,proto3" json:"RevokedOn,omitempty"`
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *APIKey) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *APIKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIKey) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *APIKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *APIKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *APIKey) GetCreatedOn() int64 {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return 0
+}
+
+func (x *APIKey) GetExpiresOn() int64 {
+	if x != nil {
+		return x.ExpiresOn
+	}
+	return 0
+}
+
+func (x *APIKey) GetLastUsedOn() int64 {
+	if x != nil {
+		return x.LastUsedOn
+	}
+	return 0
+}
+
+func (x *APIKey) GetRevokedOn() int64 {
+	if x != nil {
+		return x.RevokedOn
+	}
+	return 0
+}
+
+type APIKeys struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	APIKeys []*APIKey `protobuf:"bytes,1,rep,name=APIKeys,proto3" json:"APIKeys,omitempty"`
+}
+
+func (x *APIKeys) Reset() {
+	*x = APIKeys{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *APIKeys) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKeys) ProtoMessage() {}
+
+func (x *APIKeys) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKeys.ProtoReflect.Descriptor instead.
+func (*APIKeys) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *APIKeys) GetAPIKeys() []*APIKey {
+	if x != nil {
+		return x.APIKeys
+	}
+	return nil
+}
+
+type Questions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Questions     []*QuestionInfo `protobuf:"bytes,1,rep,name=Questions,proto3" json:"Questions,omitempty"`
+	NextPageToken string          `protobuf:"bytes,2,opt,name=NextPageToken,proto3" json:"NextPageToken,omitempty"`
+}
+
+func (x *Questions) Reset() {
+	*x = Questions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Questions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Questions) ProtoMessage() {}
+
+func (x *Questions) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Questions.ProtoReflect.Descriptor instead.
+func (*Questions) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Questions) GetQuestions() []*QuestionInfo {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+func (x *Questions) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type FindAllRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageSize    int64                 `protobuf:"varint,1,opt,name=PageSize,proto3" json:"PageSize,omitempty"`
+	PageToken   string                `protobuf:"bytes,2,opt,name=PageToken,proto3" json:"PageToken,omitempty"`
+	Sort        string                `protobuf:"bytes,3,opt,name=Sort,proto3" json:"Sort,omitempty"`
+	Unresolved  bool                  `protobuf:"varint,4,opt,name=Unresolved,proto3" json:"Unresolved,omitempty"`
+	Answered    *wrapperspb.BoolValue `protobuf:"bytes,5,opt,name=Answered,proto3" json:"Answered,omitempty"`
+	AuthorID    string                `protobuf:"bytes,6,opt,name=AuthorID,proto3" json:"AuthorID,omitempty"`
+	AnswererID  string                `protobuf:"bytes,7,opt,name=AnswererID,proto3" json:"AnswererID,omitempty"`
+	CreatedFrom int64                 `protobuf:"varint,8,opt,name=CreatedFrom,proto3" json:"CreatedFrom,omitempty"`
+	CreatedTo   int64                 `protobuf:"varint,9,opt,name=CreatedTo,proto3" json:"CreatedTo,omitempty"`
+}
+
+func (x *FindAllRequest) Reset() {
+	*x = FindAllRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindAllRequest) ProtoMessage() {}
+
+func (x *FindAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindAllRequest.ProtoReflect.Descriptor instead.
+func (*FindAllRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *FindAllRequest) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *FindAllRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *FindAllRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *FindAllRequest) GetUnresolved() bool {
+	if x != nil {
+		return x.Unresolved
+	}
+	return false
+}
+
+func (x *FindAllRequest) GetAnswered() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.Answered
+	}
+	return nil
+}
+
+func (x *FindAllRequest) GetAuthorID() string {
+	if x != nil {
+		return x.AuthorID
+	}
+	return ""
+}
+
+func (x *FindAllRequest) GetAnswererID() string {
+	if x != nil {
+		return x.AnswererID
+	}
+	return ""
+}
+
+func (x *FindAllRequest) GetCreatedFrom() int64 {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return 0
+}
+
+func (x *FindAllRequest) GetCreatedTo() int64 {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return 0
+}
+
+type UserPageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID    string `protobuf:"bytes,1,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	PageSize  int64  `protobuf:"varint,2,opt,name=PageSize,proto3" json:"PageSize,omitempty"`
+	PageToken string `protobuf:"bytes,3,opt,name=PageToken,proto3" json:"PageToken,omitempty"`
+	Sort      string `protobuf:"bytes,4,opt,name=Sort,proto3" json:"Sort,omitempty"`
+}
+
+func (x *UserPageRequest) Reset() {
+	*x = UserPageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserPageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserPageRequest) ProtoMessage() {}
+
+func (x *UserPageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserPageRequest.ProtoReflect.Descriptor instead.
+func (*UserPageRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UserPageRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *UserPageRequest) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *UserPageRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *UserPageRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query       string                `protobuf:"bytes,1,opt,name=Query,proto3" json:"Query,omitempty"`
+	Unresolved  bool                  `protobuf:"varint,2,opt,name=Unresolved,proto3" json:"Unresolved,omitempty"`
+	Answered    *wrapperspb.BoolValue `protobuf:"bytes,3,opt,name=Answered,proto3" json:"Answered,omitempty"`
+	AuthorID    string                `protobuf:"bytes,4,opt,name=AuthorID,proto3" json:"AuthorID,omitempty"`
+	AnswererID  string                `protobuf:"bytes,5,opt,name=AnswererID,proto3" json:"AnswererID,omitempty"`
+	CreatedFrom int64                 `protobuf:"varint,6,opt,name=CreatedFrom,proto3" json:"CreatedFrom,omitempty"`
+	CreatedTo   int64                 `protobuf:"varint,7,opt,name=CreatedTo,proto3" json:"CreatedTo,omitempty"`
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetUnresolved() bool {
+	if x != nil {
+		return x.Unresolved
+	}
+	return false
+}
+
+func (x *SearchRequest) GetAnswered() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.Answered
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetAuthorID() string {
+	if x != nil {
+		return x.AuthorID
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetAnswererID() string {
+	if x != nil {
+		return x.AnswererID
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetCreatedFrom() int64 {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetCreatedTo() int64 {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return 0
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuestionInfo *QuestionInfo `protobuf:"bytes,1,opt,name=QuestionInfo,proto3" json:"QuestionInfo,omitempty"`
+	Relevance    float64       `protobuf:"fixed64,2,opt,name=Relevance,proto3" json:"Relevance,omitempty"`
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SearchResult) GetQuestionInfo() *QuestionInfo {
+	if x != nil {
+		return x.QuestionInfo
+	}
+	return nil
+}
+
+func (x *SearchResult) GetRelevance() float64 {
+	if x != nil {
+		return x.Relevance
+	}
+	return 0
+}
+
+type SearchResults struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*SearchResult `protobuf:"bytes,1,rep,name=Results,proto3" json:"Results,omitempty"`
+}
+
+func (x *SearchResults) Reset() {
+	*x = SearchResults{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchResults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResults) ProtoMessage() {}
+
+func (x *SearchResults) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResults.ProtoReflect.Descriptor instead.
+func (*SearchResults) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SearchResults) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type GenericMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *GenericMessage) Reset() {
+	*x = GenericMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenericMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenericMessage) ProtoMessage() {}
+
+func (x *GenericMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenericMessage.ProtoReflect.Descriptor instead.
+func (*GenericMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GenericMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type QuestionUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuestionInfo *QuestionInfo `protobuf:"bytes,1,opt,name=QuestionInfo,proto3" json:"QuestionInfo,omitempty"`
+	QuestionID   string        `protobuf:"bytes,2,opt,name=QuestionID,proto3" json:"QuestionID,omitempty"`
+}
+
+func (x *QuestionUpdate) Reset() {
+	*x = QuestionUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuestionUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuestionUpdate) ProtoMessage() {}
+
+func (x *QuestionUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuestionUpdate.ProtoReflect.Descriptor instead.
+func (*QuestionUpdate) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *QuestionUpdate) GetQuestionInfo() *QuestionInfo {
+	if x != nil {
+		return x.QuestionInfo
+	}
+	return nil
+}
+
+func (x *QuestionUpdate) GetQuestionID() string {
+	if x != nil {
+		return x.QuestionID
+	}
+	return ""
+}
+
+type EmptyMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EmptyMessage) Reset() {
+	*x = EmptyMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmptyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyMessage) ProtoMessage() {}
+
+func (x *EmptyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmptyMessage.ProtoReflect.Descriptor instead.
+func (*EmptyMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP(), []int{30}
+}
+
+var File_pkg_questionary_transport_grpc_protobuff_questionary_proto protoreflect.FileDescriptor
+
+var file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDesc = []byte{
+	0x0a, 0x3a, 0x70, 0x6b, 0x67, 0x2f, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x72,
+	0x79, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x2f, 0x67, 0x72, 0x70, 0x63,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x66, 0x2f, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x61, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72,
+	0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x98, 0x01, 0x0a,
+	0x08, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12,
+	0x1c, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x04, 0x54, 0x61, 0x67, 0x73, 0x22, 0x9c, 0x01, 0x0a, 0x06, 0x41, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73,
+	0x65, 0x72, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72,
+	0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x6e,
+	0x12, 0x14, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x22, 0xcb, 0x01, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x25, 0x0a, 0x08, 0x51, 0x75, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x51, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f,
+	0x0a, 0x06, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07,
+	0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x06, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12,
+	0x21, 0x0a, 0x07, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x07, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x07, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x41, 0x63,
+	0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x49, 0x44, 0x12, 0x24,
+	0x0a, 0x0d, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x2c, 0x0a, 0x07, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x12,
+	0x21, 0x0a, 0x07, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x07, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x07, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x73, 0x22, 0x46, 0x0a, 0x08, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1e,
+	0x0a, 0x0a, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a,
+	0x0a, 0x08, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x49, 0x44, 0x22, 0x47, 0x0a, 0x0d, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x51,
+	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65,
+	0x72, 0x49, 0x44, 0x22, 0x69, 0x0a, 0x13, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x41, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x51, 0x75,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x22, 0x77,
+	0x0a, 0x0b, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65,
+	0x72, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x59, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x22, 0x2d, 0x0a, 0x03, 0x54, 0x61, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x54, 0x61, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x54, 0x61, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x20, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x18, 0x0a, 0x04, 0x54, 0x61, 0x67,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x04, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x04, 0x54,
+	0x61, 0x67, 0x73, 0x22, 0xc5, 0x01, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12,
+	0x1e, 0x0a, 0x0a, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x51,
+	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1c, 0x0a,
+	0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x22, 0x30, 0x0a, 0x08, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x08, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x08, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x49, 0x0a,
+	0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x51, 0x75,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x22, 0x4b, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x50,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x50, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x49, 0x44, 0x22, 0xc8, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x54, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x54, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x65, 0x78, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x65, 0x78, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73,
+	0x65, 0x72, 0x49, 0x44, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72,
+	0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x6e,
+	0x22, 0x34, 0x0a, 0x09, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x27, 0x0a,
+	0x09, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x09, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x52, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x69, 0x0a, 0x17, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61,
+	0x63, 0x6b, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x12, 0x16, 0x0a, 0x06, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65,
+	0x72, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x22, 0x39, 0x0a, 0x0b, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x52, 0x6f, 0x6c, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x52, 0x6f, 0x6c, 0x65, 0x22, 0x39, 0x0a, 0x09,
+	0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65,
+	0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x12, 0x14, 0x0a, 0x05, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x05, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x22, 0xe8, 0x01, 0x0a, 0x06, 0x41, 0x50, 0x49, 0x4b,
+	0x65, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x16,
+	0x0a, 0x06, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
+	0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x4f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x4f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x45, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x73, 0x4f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x4c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x64,
+	0x4f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x4c, 0x61, 0x73, 0x74, 0x55, 0x73,
+	0x65, 0x64, 0x4f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x4f,
+	0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64,
+	0x4f, 0x6e, 0x22, 0x2c, 0x0a, 0x07, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x21, 0x0a,
+	0x07, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x07,
+	0x2e, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x52, 0x07, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x73,
+	0x22, 0x5e, 0x0a, 0x09, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a,
+	0x09, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x09, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x4e, 0x65,
+	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x22, 0xb2, 0x02, 0x0a, 0x0e, 0x46, 0x69, 0x6e, 0x64, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x53, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x53, 0x6f, 0x72,
+	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x55, 0x6e, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x55, 0x6e, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x64, 0x12, 0x36, 0x0a, 0x08, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x08, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x41, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x49, 0x44, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x41, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65,
+	0x72, 0x49, 0x44, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x65, 0x72, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x46, 0x72, 0x6f, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x54, 0x6f, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x54, 0x6f, 0x22, 0x77, 0x0a, 0x0f, 0x55, 0x73, 0x65, 0x72, 0x50, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44,
+	0x12, 0x1a, 0x0a, 0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x6f,
+	0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x53, 0x6f, 0x72, 0x74, 0x22, 0xf9,
+	0x01, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x55, 0x6e, 0x72, 0x65, 0x73, 0x6f,
+	0x6c, 0x76, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x55, 0x6e, 0x72, 0x65,
+	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x65, 0x72, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x72, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x1c, 0x0a, 0x09,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x6f, 0x22, 0x5f, 0x0a, 0x0c, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x31, 0x0a, 0x0c, 0x51, 0x75,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x0c, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a,
+	0x09, 0x52, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x09, 0x52, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x38, 0x0a, 0x0d, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x07,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x2a, 0x0a, 0x0e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x63, 0x0a, 0x0e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x31, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x51, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0c, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1e, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x51, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0x0e, 0x0a, 0x0c, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xd1, 0x0a, 0x0a, 0x12, 0x51, 0x75, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x61, 0x72, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x26, 0x0a,
+	0x07, 0x46, 0x69, 0x6e, 0x64, 0x41, 0x6c, 0x6c, 0x12, 0x0f, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x41,
+	0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x51, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2a, 0x0a, 0x0a, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x55,
+	0x73, 0x65, 0x72, 0x12, 0x10, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x50, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x35, 0x0a, 0x09, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x54, 0x61, 0x67, 0x12, 0x1c,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x0a, 0x2e, 0x51,
+	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x20, 0x0a, 0x08, 0x46, 0x69, 0x6e, 0x64,
+	0x54, 0x61, 0x67, 0x73, 0x12, 0x0d, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x1a, 0x05, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x12, 0x28, 0x0a, 0x06, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x12, 0x0e, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x08, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44,
+	0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x0d,
+	0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1e, 0x0a,
+	0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x09, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x1a, 0x09, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a,
+	0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x0f, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x1a, 0x0d, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x23, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x12, 0x07, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x1a, 0x0d, 0x2e,
+	0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x29, 0x0a, 0x06,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x0e, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x1a, 0x0d, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x35, 0x0a, 0x0b, 0x46, 0x69, 0x6e, 0x64, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x12, 0x1c,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x08, 0x2e, 0x41,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x12, 0x20, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x07, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x1a,
+	0x07, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x09, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x49, 0x44, 0x1a, 0x0f, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x0c, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x12, 0x14, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x41, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x51, 0x75, 0x65,
+	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x35, 0x0a, 0x0e, 0x55, 0x6e, 0x61,
+	0x63, 0x63, 0x65, 0x70, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x14, 0x2e, 0x41, 0x63,
+	0x63, 0x65, 0x70, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x0d, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x1c, 0x0a, 0x04, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x0c, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x06, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x23,
+	0x0a, 0x0b, 0x52, 0x65, 0x74, 0x72, 0x61, 0x63, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x0c, 0x2e,
+	0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x06, 0x2e, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x12, 0x20, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x08, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x08, 0x2e, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x0c, 0x46, 0x69, 0x6e, 0x64, 0x43, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x0e, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x50,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x1a, 0x09, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x08, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x08, 0x2e, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0a, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x49, 0x44, 0x1a, 0x0f, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x39, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x1a, 0x0a, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3b,
+	0x0a, 0x10, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x18, 0x2e, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x51,
+	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x35, 0x0a, 0x09, 0x46,
+	0x69, 0x6e, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x0a, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c,
+	0x65, 0x73, 0x12, 0x25, 0x0a, 0x09, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x12,
+	0x0c, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0a, 0x52, 0x65, 0x76,
+	0x6f, 0x6b, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x0c, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65,
+	0x73, 0x12, 0x20, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x50, 0x49, 0x4b, 0x65,
+	0x79, 0x12, 0x07, 0x2e, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x1a, 0x07, 0x2e, 0x41, 0x50, 0x49,
+	0x4b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x50, 0x49, 0x4b, 0x65,
+	0x79, 0x73, 0x12, 0x0d, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x1a, 0x08, 0x2e, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x3d, 0x0a, 0x0c, 0x52,
+	0x65, 0x76, 0x6f, 0x6b, 0x65, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x0f, 0x2e, 0x47, 0x65, 0x6e, 0x65,
+	0x72, 0x69, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x46, 0x5a, 0x44, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x73, 0x6d, 0x61, 0x65, 0x6c, 0x6a,
+	0x70, 0x76, 0x2f, 0x71, 0x61, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x72, 0x79, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70,
+	0x6f, 0x72, 0x74, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x66, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescOnce sync.Once
+	file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescData = file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDesc
+)
+
+func file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescGZIP() []byte {
+	file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescOnce.Do(func() {
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescData)
+	})
+	return file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDescData
+}
+
+var file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_pkg_questionary_transport_grpc_protobuff_questionary_proto_goTypes = []interface{}{
+	(*Question)(nil),                // 0: Question
+	(*Answer)(nil),                  // 1: Answer
+	(*QuestionInfo)(nil),            // 2: QuestionInfo
+	(*Answers)(nil),                 // 3: Answers
+	(*AnswerID)(nil),                // 4: AnswerID
+	(*DeleteRequest)(nil),           // 5: DeleteRequest
+	(*AcceptAnswerRequest)(nil),     // 6: AcceptAnswerRequest
+	(*VoteRequest)(nil),             // 7: VoteRequest
+	(*Score)(nil),                   // 8: Score
+	(*Tag)(nil),                     // 9: Tag
+	(*Tags)(nil),                    // 10: Tags
+	(*Comment)(nil),                 // 11: Comment
+	(*Comments)(nil),                // 12: Comments
+	(*CommentID)(nil),               // 13: CommentID
+	(*CommentParent)(nil),           // 14: CommentParent
+	(*Revision)(nil),                // 15: Revision
+	(*Revisions)(nil),               // 16: Revisions
+	(*RollbackRevisionRequest)(nil), // 17: RollbackRevisionRequest
+	(*RoleRequest)(nil),             // 18: RoleRequest
+	(*UserRoles)(nil),               // 19: UserRoles
+	(*APIKey)(nil),                  // 20: APIKey
+	(*APIKeys)(nil),                 // 21: APIKeys
+	(*Questions)(nil),               // 22: Questions
+	(*FindAllRequest)(nil),          // 23: FindAllRequest
+	(*UserPageRequest)(nil),         // 24: UserPageRequest
+	(*SearchRequest)(nil),           // 25: SearchRequest
+	(*SearchResult)(nil),            // 26: SearchResult
+	(*SearchResults)(nil),           // 27: SearchResults
+	(*GenericMessage)(nil),          // 28: GenericMessage
+	(*QuestionUpdate)(nil),          // 29: QuestionUpdate
+	(*EmptyMessage)(nil),            // 30: EmptyMessage
+	(*wrapperspb.BoolValue)(nil),    // 31: google.protobuf.BoolValue
+	(*wrapperspb.StringValue)(nil),  // 32: google.protobuf.StringValue
+}
+var file_pkg_questionary_transport_grpc_protobuff_questionary_proto_depIdxs = []int32{
+	0,  // 0: QuestionInfo.Question:type_name -> Question
+	1,  // 1: QuestionInfo.Answer:type_name -> Answer
+	1,  // 2: QuestionInfo.Answers:type_name -> Answer
+	1,  // 3: Answers.Answers:type_name -> Answer
+	9,  // 4: Tags.Tags:type_name -> Tag
+	11, // 5: Comments.Comments:type_name -> Comment
+	15, // 6: Revisions.Revisions:type_name -> Revision
+	20, // 7: APIKeys.APIKeys:type_name -> APIKey
+	2,  // 8: Questions.Questions:type_name -> QuestionInfo
+	31, // 9: FindAllRequest.Answered:type_name -> google.protobuf.BoolValue
+	31, // 10: SearchRequest.Answered:type_name -> google.protobuf.BoolValue
+	2,  // 11: SearchResult.QuestionInfo:type_name -> QuestionInfo
+	26, // 12: SearchResults.Results:type_name -> SearchResult
+	2,  // 13: QuestionUpdate.QuestionInfo:type_name -> QuestionInfo
+	23, // 14: QuestionaryService.FindAll:input_type -> FindAllRequest
+	24, // 15: QuestionaryService.FindByUser:input_type -> UserPageRequest
+	32, // 16: QuestionaryService.FindByTag:input_type -> google.protobuf.StringValue
+	30, // 17: QuestionaryService.FindTags:input_type -> EmptyMessage
+	25, // 18: QuestionaryService.Search:input_type -> SearchRequest
+	32, // 19: QuestionaryService.FindByID:input_type -> google.protobuf.StringValue
+	0,  // 20: QuestionaryService.Create:input_type -> Question
+	29, // 21: QuestionaryService.Update:input_type -> QuestionUpdate
+	1,  // 22: QuestionaryService.AddAnswer:input_type -> Answer
+	5,  // 23: QuestionaryService.Delete:input_type -> DeleteRequest
+	32, // 24: QuestionaryService.Restore:input_type -> google.protobuf.StringValue
+	32, // 25: QuestionaryService.FindAnswers:input_type -> google.protobuf.StringValue
+	1,  // 26: QuestionaryService.UpdateAnswer:input_type -> Answer
+	4,  // 27: QuestionaryService.DeleteAnswer:input_type -> AnswerID
+	6,  // 28: QuestionaryService.AcceptAnswer:input_type -> AcceptAnswerRequest
+	6,  // 29: QuestionaryService.UnacceptAnswer:input_type -> AcceptAnswerRequest
+	7,  // 30: QuestionaryService.Vote:input_type -> VoteRequest
+	7,  // 31: QuestionaryService.RetractVote:input_type -> VoteRequest
+	11, // 32: QuestionaryService.AddComment:input_type -> Comment
+	14, // 33: QuestionaryService.FindComments:input_type -> CommentParent
+	11, // 34: QuestionaryService.UpdateComment:input_type -> Comment
+	13, // 35: QuestionaryService.DeleteComment:input_type -> CommentID
+	32, // 36: QuestionaryService.ListRevisions:input_type -> google.protobuf.StringValue
+	17, // 37: QuestionaryService.RollbackRevision:input_type -> RollbackRevisionRequest
+	32, // 38: QuestionaryService.FindRoles:input_type -> google.protobuf.StringValue
+	18, // 39: QuestionaryService.GrantRole:input_type -> RoleRequest
+	18, // 40: QuestionaryService.RevokeRole:input_type -> RoleRequest
+	20, // 41: QuestionaryService.CreateAPIKey:input_type -> APIKey
+	30, // 42: QuestionaryService.ListAPIKeys:input_type -> EmptyMessage
+	32, // 43: QuestionaryService.RevokeAPIKey:input_type -> google.protobuf.StringValue
+	22, // 44: QuestionaryService.FindAll:output_type -> Questions
+	22, // 45: QuestionaryService.FindByUser:output_type -> Questions
+	22, // 46: QuestionaryService.FindByTag:output_type -> Questions
+	10, // 47: QuestionaryService.FindTags:output_type -> Tags
+	27, // 48: QuestionaryService.Search:output_type -> SearchResults
+	2,  // 49: QuestionaryService.FindByID:output_type -> QuestionInfo
+	0,  // 50: QuestionaryService.Create:output_type -> Question
+	2,  // 51: QuestionaryService.Update:output_type -> QuestionInfo
+	2,  // 52: QuestionaryService.AddAnswer:output_type -> QuestionInfo
+	28, // 53: QuestionaryService.Delete:output_type -> GenericMessage
+	2,  // 54: QuestionaryService.Restore:output_type -> QuestionInfo
+	3,  // 55: QuestionaryService.FindAnswers:output_type -> Answers
+	1,  // 56: QuestionaryService.UpdateAnswer:output_type -> Answer
+	28, // 57: QuestionaryService.DeleteAnswer:output_type -> GenericMessage
+	2,  // 58: QuestionaryService.AcceptAnswer:output_type -> QuestionInfo
+	2,  // 59: QuestionaryService.UnacceptAnswer:output_type -> QuestionInfo
+	8,  // 60: QuestionaryService.Vote:output_type -> Score
+	8,  // 61: QuestionaryService.RetractVote:output_type -> Score
+	11, // 62: QuestionaryService.AddComment:output_type -> Comment
+	12, // 63: QuestionaryService.FindComments:output_type -> Comments
+	11, // 64: QuestionaryService.UpdateComment:output_type -> Comment
+	28, // 65: QuestionaryService.DeleteComment:output_type -> GenericMessage
+	16, // 66: QuestionaryService.ListRevisions:output_type -> Revisions
+	2,  // 67: QuestionaryService.RollbackRevision:output_type -> QuestionInfo
+	19, // 68: QuestionaryService.FindRoles:output_type -> UserRoles
+	19, // 69: QuestionaryService.GrantRole:output_type -> UserRoles
+	19, // 70: QuestionaryService.RevokeRole:output_type -> UserRoles
+	20, // 71: QuestionaryService.CreateAPIKey:output_type -> APIKey
+	21, // 72: QuestionaryService.ListAPIKeys:output_type -> APIKeys
+	28, // 73: QuestionaryService.RevokeAPIKey:output_type -> GenericMessage
+	44, // [44:74] is the sub-list for method output_type
+	14, // [14:44] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_pkg_questionary_transport_grpc_protobuff_questionary_proto_init() }
+func file_pkg_questionary_transport_grpc_protobuff_questionary_proto_init() {
+	if File_pkg_questionary_transport_grpc_protobuff_questionary_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Question); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Answer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuestionInfo); i {
+			case 0:
+				return &v.state
+			case 1:
 				return &v.sizeCache
 			case 2:
 				return &v.unknownFields
@@ -577,7 +2514,7 @@ func file_pkg_questionary_transport_grpc_protobuff_questionary_proto_init() {
 			}
 		}
 		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Questions); i {
+			switch v := v.(*Answers); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -589,7 +2526,7 @@ func file_pkg_questionary_transport_grpc_protobuff_questionary_proto_init() {
 			}
 		}
 		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GenericMessage); i {
+			switch v := v.(*AnswerID); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -601,7 +2538,7 @@ func file_pkg_questionary_transport_grpc_protobuff_questionary_proto_init() {
 			}
 		}
 		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QuestionUpdate); i {
+			switch v := v.(*DeleteRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -613,6 +2550,294 @@ func file_pkg_questionary_transport_grpc_protobuff_questionary_proto_init() {
 			}
 		}
 		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AcceptAnswerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Score); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tag); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tags); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Comment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Comments); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommentID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommentParent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Revision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Revisions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RollbackRevisionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserRoles); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*APIKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*APIKeys); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Questions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindAllRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserPageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchResults); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenericMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuestionUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_questionary_transport_grpc_protobuff_questionary_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EmptyMessage); i {
 			case 0:
 				return &v.state
@@ -631,7 +2856,7 @@ func file_pkg_questionary_transport_grpc_protobuff_questionary_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_questionary_transport_grpc_protobuff_questionary_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,13 +19,36 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type QuestionaryServiceClient interface {
-	FindAll(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*Questions, error)
-	FindByUser(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*Questions, error)
+	FindAll(ctx context.Context, in *FindAllRequest, opts ...grpc.CallOption) (*Questions, error)
+	FindByUser(ctx context.Context, in *UserPageRequest, opts ...grpc.CallOption) (*Questions, error)
+	FindByTag(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*Questions, error)
+	FindTags(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*Tags, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResults, error)
 	FindByID(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*QuestionInfo, error)
 	Create(ctx context.Context, in *Question, opts ...grpc.CallOption) (*Question, error)
 	Update(ctx context.Context, in *QuestionUpdate, opts ...grpc.CallOption) (*QuestionInfo, error)
 	AddAnswer(ctx context.Context, in *Answer, opts ...grpc.CallOption) (*QuestionInfo, error)
-	Delete(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*GenericMessage, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*GenericMessage, error)
+	Restore(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*QuestionInfo, error)
+	FindAnswers(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*Answers, error)
+	UpdateAnswer(ctx context.Context, in *Answer, opts ...grpc.CallOption) (*Answer, error)
+	DeleteAnswer(ctx context.Context, in *AnswerID, opts ...grpc.CallOption) (*GenericMessage, error)
+	AcceptAnswer(ctx context.Context, in *AcceptAnswerRequest, opts ...grpc.CallOption) (*QuestionInfo, error)
+	UnacceptAnswer(ctx context.Context, in *AcceptAnswerRequest, opts ...grpc.CallOption) (*QuestionInfo, error)
+	Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*Score, error)
+	RetractVote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*Score, error)
+	AddComment(ctx context.Context, in *Comment, opts ...grpc.CallOption) (*Comment, error)
+	FindComments(ctx context.Context, in *CommentParent, opts ...grpc.CallOption) (*Comments, error)
+	UpdateComment(ctx context.Context, in *Comment, opts ...grpc.CallOption) (*Comment, error)
+	DeleteComment(ctx context.Context, in *CommentID, opts ...grpc.CallOption) (*GenericMessage, error)
+	ListRevisions(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*Revisions, error)
+	RollbackRevision(ctx context.Context, in *RollbackRevisionRequest, opts ...grpc.CallOption) (*QuestionInfo, error)
+	FindRoles(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*UserRoles, error)
+	GrantRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*UserRoles, error)
+	RevokeRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*UserRoles, error)
+	CreateAPIKey(ctx context.Context, in *APIKey, opts ...grpc.CallOption) (*APIKey, error)
+	ListAPIKeys(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*APIKeys, error)
+	RevokeAPIKey(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*GenericMessage, error)
 }
 
 type questionaryServiceClient struct {
@@ -36,7 +59,7 @@ func NewQuestionaryServiceClient(cc grpc.ClientConnInterface) QuestionaryService
 	return &questionaryServiceClient{cc}
 }
 
-func (c *questionaryServiceClient) FindAll(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*Questions, error) {
+func (c *questionaryServiceClient) FindAll(ctx context.Context, in *FindAllRequest, opts ...grpc.CallOption) (*Questions, error) {
 	out := new(Questions)
 	err := c.cc.Invoke(ctx, "/QuestionaryService/FindAll", in, out, opts...)
 	if err != nil {
@@ -45,7 +68,7 @@ func (c *questionaryServiceClient) FindAll(ctx context.Context, in *EmptyMessage
 	return out, nil
 }
 
-func (c *questionaryServiceClient) FindByUser(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*Questions, error) {
+func (c *questionaryServiceClient) FindByUser(ctx context.Context, in *UserPageRequest, opts ...grpc.CallOption) (*Questions, error) {
 	out := new(Questions)
 	err := c.cc.Invoke(ctx, "/QuestionaryService/FindByUser", in, out, opts...)
 	if err != nil {
@@ -54,6 +77,33 @@ func (c *questionaryServiceClient) FindByUser(ctx context.Context, in *wrappersp
 	return out, nil
 }
 
+func (c *questionaryServiceClient) FindByTag(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*Questions, error) {
+	out := new(Questions)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/FindByTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) FindTags(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*Tags, error) {
+	out := new(Tags)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/FindTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResults, error) {
+	out := new(SearchResults)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *questionaryServiceClient) FindByID(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*QuestionInfo, error) {
 	out := new(QuestionInfo)
 	err := c.cc.Invoke(ctx, "/QuestionaryService/FindByID", in, out, opts...)
@@ -90,7 +140,7 @@ func (c *questionaryServiceClient) AddAnswer(ctx context.Context, in *Answer, op
 	return out, nil
 }
 
-func (c *questionaryServiceClient) Delete(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*GenericMessage, error) {
+func (c *questionaryServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*GenericMessage, error) {
 	out := new(GenericMessage)
 	err := c.cc.Invoke(ctx, "/QuestionaryService/Delete", in, out, opts...)
 	if err != nil {
@@ -99,17 +149,220 @@ func (c *questionaryServiceClient) Delete(ctx context.Context, in *wrapperspb.St
 	return out, nil
 }
 
+func (c *questionaryServiceClient) Restore(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*QuestionInfo, error) {
+	out := new(QuestionInfo)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) FindAnswers(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*Answers, error) {
+	out := new(Answers)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/FindAnswers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) UpdateAnswer(ctx context.Context, in *Answer, opts ...grpc.CallOption) (*Answer, error) {
+	out := new(Answer)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/UpdateAnswer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) DeleteAnswer(ctx context.Context, in *AnswerID, opts ...grpc.CallOption) (*GenericMessage, error) {
+	out := new(GenericMessage)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/DeleteAnswer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) AcceptAnswer(ctx context.Context, in *AcceptAnswerRequest, opts ...grpc.CallOption) (*QuestionInfo, error) {
+	out := new(QuestionInfo)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/AcceptAnswer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) UnacceptAnswer(ctx context.Context, in *AcceptAnswerRequest, opts ...grpc.CallOption) (*QuestionInfo, error) {
+	out := new(QuestionInfo)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/UnacceptAnswer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*Score, error) {
+	out := new(Score)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/Vote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) RetractVote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*Score, error) {
+	out := new(Score)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/RetractVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) AddComment(ctx context.Context, in *Comment, opts ...grpc.CallOption) (*Comment, error) {
+	out := new(Comment)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/AddComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) FindComments(ctx context.Context, in *CommentParent, opts ...grpc.CallOption) (*Comments, error) {
+	out := new(Comments)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/FindComments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) UpdateComment(ctx context.Context, in *Comment, opts ...grpc.CallOption) (*Comment, error) {
+	out := new(Comment)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/UpdateComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) DeleteComment(ctx context.Context, in *CommentID, opts ...grpc.CallOption) (*GenericMessage, error) {
+	out := new(GenericMessage)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/DeleteComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) ListRevisions(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*Revisions, error) {
+	out := new(Revisions)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/ListRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) RollbackRevision(ctx context.Context, in *RollbackRevisionRequest, opts ...grpc.CallOption) (*QuestionInfo, error) {
+	out := new(QuestionInfo)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/RollbackRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) FindRoles(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*UserRoles, error) {
+	out := new(UserRoles)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/FindRoles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) GrantRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*UserRoles, error) {
+	out := new(UserRoles)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/GrantRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) RevokeRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*UserRoles, error) {
+	out := new(UserRoles)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/RevokeRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) CreateAPIKey(ctx context.Context, in *APIKey, opts ...grpc.CallOption) (*APIKey, error) {
+	out := new(APIKey)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/CreateAPIKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) ListAPIKeys(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*APIKeys, error) {
+	out := new(APIKeys)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/ListAPIKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionaryServiceClient) RevokeAPIKey(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*GenericMessage, error) {
+	out := new(GenericMessage)
+	err := c.cc.Invoke(ctx, "/QuestionaryService/RevokeAPIKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QuestionaryServiceServer is the server API for QuestionaryService service.
 // All implementations must embed UnimplementedQuestionaryServiceServer
 // for forward compatibility
 type QuestionaryServiceServer interface {
-	FindAll(context.Context, *EmptyMessage) (*Questions, error)
-	FindByUser(context.Context, *wrapperspb.StringValue) (*Questions, error)
+	FindAll(context.Context, *FindAllRequest) (*Questions, error)
+	FindByUser(context.Context, *UserPageRequest) (*Questions, error)
+	FindByTag(context.Context, *wrapperspb.StringValue) (*Questions, error)
+	FindTags(context.Context, *EmptyMessage) (*Tags, error)
+	Search(context.Context, *SearchRequest) (*SearchResults, error)
 	FindByID(context.Context, *wrapperspb.StringValue) (*QuestionInfo, error)
 	Create(context.Context, *Question) (*Question, error)
 	Update(context.Context, *QuestionUpdate) (*QuestionInfo, error)
 	AddAnswer(context.Context, *Answer) (*QuestionInfo, error)
-	Delete(context.Context, *wrapperspb.StringValue) (*GenericMessage, error)
+	Delete(context.Context, *DeleteRequest) (*GenericMessage, error)
+	Restore(context.Context, *wrapperspb.StringValue) (*QuestionInfo, error)
+	FindAnswers(context.Context, *wrapperspb.StringValue) (*Answers, error)
+	UpdateAnswer(context.Context, *Answer) (*Answer, error)
+	DeleteAnswer(context.Context, *AnswerID) (*GenericMessage, error)
+	AcceptAnswer(context.Context, *AcceptAnswerRequest) (*QuestionInfo, error)
+	UnacceptAnswer(context.Context, *AcceptAnswerRequest) (*QuestionInfo, error)
+	Vote(context.Context, *VoteRequest) (*Score, error)
+	RetractVote(context.Context, *VoteRequest) (*Score, error)
+	AddComment(context.Context, *Comment) (*Comment, error)
+	FindComments(context.Context, *CommentParent) (*Comments, error)
+	UpdateComment(context.Context, *Comment) (*Comment, error)
+	DeleteComment(context.Context, *CommentID) (*GenericMessage, error)
+	ListRevisions(context.Context, *wrapperspb.StringValue) (*Revisions, error)
+	RollbackRevision(context.Context, *RollbackRevisionRequest) (*QuestionInfo, error)
+	FindRoles(context.Context, *wrapperspb.StringValue) (*UserRoles, error)
+	GrantRole(context.Context, *RoleRequest) (*UserRoles, error)
+	RevokeRole(context.Context, *RoleRequest) (*UserRoles, error)
+	CreateAPIKey(context.Context, *APIKey) (*APIKey, error)
+	ListAPIKeys(context.Context, *EmptyMessage) (*APIKeys, error)
+	RevokeAPIKey(context.Context, *wrapperspb.StringValue) (*GenericMessage, error)
 	mustEmbedUnimplementedQuestionaryServiceServer()
 }
 
@@ -117,12 +370,21 @@ type QuestionaryServiceServer interface {
 type UnimplementedQuestionaryServiceServer struct {
 }
 
-func (UnimplementedQuestionaryServiceServer) FindAll(context.Context, *EmptyMessage) (*Questions, error) {
+func (UnimplementedQuestionaryServiceServer) FindAll(context.Context, *FindAllRequest) (*Questions, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindAll not implemented")
 }
-func (UnimplementedQuestionaryServiceServer) FindByUser(context.Context, *wrapperspb.StringValue) (*Questions, error) {
+func (UnimplementedQuestionaryServiceServer) FindByUser(context.Context, *UserPageRequest) (*Questions, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindByUser not implemented")
 }
+func (UnimplementedQuestionaryServiceServer) FindByTag(context.Context, *wrapperspb.StringValue) (*Questions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindByTag not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) FindTags(context.Context, *EmptyMessage) (*Tags, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindTags not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) Search(context.Context, *SearchRequest) (*SearchResults, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
 func (UnimplementedQuestionaryServiceServer) FindByID(context.Context, *wrapperspb.StringValue) (*QuestionInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindByID not implemented")
 }
@@ -135,9 +397,69 @@ func (UnimplementedQuestionaryServiceServer) Update(context.Context, *QuestionUp
 func (UnimplementedQuestionaryServiceServer) AddAnswer(context.Context, *Answer) (*QuestionInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddAnswer not implemented")
 }
-func (UnimplementedQuestionaryServiceServer) Delete(context.Context, *wrapperspb.StringValue) (*GenericMessage, error) {
+func (UnimplementedQuestionaryServiceServer) Delete(context.Context, *DeleteRequest) (*GenericMessage, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedQuestionaryServiceServer) Restore(context.Context, *wrapperspb.StringValue) (*QuestionInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) FindAnswers(context.Context, *wrapperspb.StringValue) (*Answers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindAnswers not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) UpdateAnswer(context.Context, *Answer) (*Answer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAnswer not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) DeleteAnswer(context.Context, *AnswerID) (*GenericMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAnswer not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) AcceptAnswer(context.Context, *AcceptAnswerRequest) (*QuestionInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptAnswer not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) UnacceptAnswer(context.Context, *AcceptAnswerRequest) (*QuestionInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnacceptAnswer not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) Vote(context.Context, *VoteRequest) (*Score, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Vote not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) RetractVote(context.Context, *VoteRequest) (*Score, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetractVote not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) AddComment(context.Context, *Comment) (*Comment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddComment not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) FindComments(context.Context, *CommentParent) (*Comments, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindComments not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) UpdateComment(context.Context, *Comment) (*Comment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComment not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) DeleteComment(context.Context, *CommentID) (*GenericMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) ListRevisions(context.Context, *wrapperspb.StringValue) (*Revisions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRevisions not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) RollbackRevision(context.Context, *RollbackRevisionRequest) (*QuestionInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackRevision not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) FindRoles(context.Context, *wrapperspb.StringValue) (*UserRoles, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindRoles not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) GrantRole(context.Context, *RoleRequest) (*UserRoles, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantRole not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) RevokeRole(context.Context, *RoleRequest) (*UserRoles, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeRole not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) CreateAPIKey(context.Context, *APIKey) (*APIKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIKey not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) ListAPIKeys(context.Context, *EmptyMessage) (*APIKeys, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPIKeys not implemented")
+}
+func (UnimplementedQuestionaryServiceServer) RevokeAPIKey(context.Context, *wrapperspb.StringValue) (*GenericMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIKey not implemented")
+}
 func (UnimplementedQuestionaryServiceServer) mustEmbedUnimplementedQuestionaryServiceServer() {}
 
 // UnsafeQuestionaryServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -152,7 +474,7 @@ func RegisterQuestionaryServiceServer(s grpc.ServiceRegistrar, srv QuestionarySe
 }
 
 func _QuestionaryService_FindAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmptyMessage)
+	in := new(FindAllRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -164,13 +486,13 @@ func _QuestionaryService_FindAll_Handler(srv interface{}, ctx context.Context, d
 		FullMethod: "/QuestionaryService/FindAll",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QuestionaryServiceServer).FindAll(ctx, req.(*EmptyMessage))
+		return srv.(QuestionaryServiceServer).FindAll(ctx, req.(*FindAllRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _QuestionaryService_FindByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(wrapperspb.StringValue)
+	in := new(UserPageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -182,7 +504,61 @@ func _QuestionaryService_FindByUser_Handler(srv interface{}, ctx context.Context
 		FullMethod: "/QuestionaryService/FindByUser",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QuestionaryServiceServer).FindByUser(ctx, req.(*wrapperspb.StringValue))
+		return srv.(QuestionaryServiceServer).FindByUser(ctx, req.(*UserPageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_FindByTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).FindByTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/FindByTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).FindByTag(ctx, req.(*wrapperspb.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_FindTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).FindTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/FindTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).FindTags(ctx, req.(*EmptyMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).Search(ctx, req.(*SearchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -260,7 +636,7 @@ func _QuestionaryService_AddAnswer_Handler(srv interface{}, ctx context.Context,
 }
 
 func _QuestionaryService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(wrapperspb.StringValue)
+	in := new(DeleteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -272,45 +648,497 @@ func _QuestionaryService_Delete_Handler(srv interface{}, ctx context.Context, de
 		FullMethod: "/QuestionaryService/Delete",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QuestionaryServiceServer).Delete(ctx, req.(*wrapperspb.StringValue))
+		return srv.(QuestionaryServiceServer).Delete(ctx, req.(*DeleteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// QuestionaryService_ServiceDesc is the grpc.ServiceDesc for QuestionaryService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var QuestionaryService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "QuestionaryService",
-	HandlerType: (*QuestionaryServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "FindAll",
-			Handler:    _QuestionaryService_FindAll_Handler,
-		},
-		{
-			MethodName: "FindByUser",
-			Handler:    _QuestionaryService_FindByUser_Handler,
-		},
-		{
-			MethodName: "FindByID",
-			Handler:    _QuestionaryService_FindByID_Handler,
-		},
-		{
-			MethodName: "Create",
-			Handler:    _QuestionaryService_Create_Handler,
-		},
-		{
-			MethodName: "Update",
-			Handler:    _QuestionaryService_Update_Handler,
-		},
-		{
-			MethodName: "AddAnswer",
-			Handler:    _QuestionaryService_AddAnswer_Handler,
-		},
-		{
-			MethodName: "Delete",
-			Handler:    _QuestionaryService_Delete_Handler,
+func _QuestionaryService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).Restore(ctx, req.(*wrapperspb.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_FindAnswers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).FindAnswers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/FindAnswers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).FindAnswers(ctx, req.(*wrapperspb.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_UpdateAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Answer)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).UpdateAnswer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/UpdateAnswer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).UpdateAnswer(ctx, req.(*Answer))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_DeleteAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnswerID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).DeleteAnswer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/DeleteAnswer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).DeleteAnswer(ctx, req.(*AnswerID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_AcceptAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).AcceptAnswer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/AcceptAnswer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).AcceptAnswer(ctx, req.(*AcceptAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_UnacceptAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).UnacceptAnswer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/UnacceptAnswer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).UnacceptAnswer(ctx, req.(*AcceptAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_Vote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).Vote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/Vote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).Vote(ctx, req.(*VoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_RetractVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).RetractVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/RetractVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).RetractVote(ctx, req.(*VoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_AddComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Comment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).AddComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/AddComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).AddComment(ctx, req.(*Comment))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_FindComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommentParent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).FindComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/FindComments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).FindComments(ctx, req.(*CommentParent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_UpdateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Comment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).UpdateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/UpdateComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).UpdateComment(ctx, req.(*Comment))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommentID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/DeleteComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).DeleteComment(ctx, req.(*CommentID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_ListRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).ListRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/ListRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).ListRevisions(ctx, req.(*wrapperspb.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_RollbackRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).RollbackRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/RollbackRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).RollbackRevision(ctx, req.(*RollbackRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_FindRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).FindRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/FindRoles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).FindRoles(ctx, req.(*wrapperspb.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_GrantRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).GrantRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/GrantRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).GrantRole(ctx, req.(*RoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_RevokeRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).RevokeRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/RevokeRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).RevokeRole(ctx, req.(*RoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/CreateAPIKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).CreateAPIKey(ctx, req.(*APIKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_ListAPIKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).ListAPIKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/ListAPIKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).ListAPIKeys(ctx, req.(*EmptyMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionaryService_RevokeAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionaryServiceServer).RevokeAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/QuestionaryService/RevokeAPIKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionaryServiceServer).RevokeAPIKey(ctx, req.(*wrapperspb.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// QuestionaryService_ServiceDesc is the grpc.ServiceDesc for QuestionaryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var QuestionaryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "QuestionaryService",
+	HandlerType: (*QuestionaryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FindAll",
+			Handler:    _QuestionaryService_FindAll_Handler,
+		},
+		{
+			MethodName: "FindByUser",
+			Handler:    _QuestionaryService_FindByUser_Handler,
+		},
+		{
+			MethodName: "FindByTag",
+			Handler:    _QuestionaryService_FindByTag_Handler,
+		},
+		{
+			MethodName: "FindTags",
+			Handler:    _QuestionaryService_FindTags_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _QuestionaryService_Search_Handler,
+		},
+		{
+			MethodName: "FindByID",
+			Handler:    _QuestionaryService_FindByID_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _QuestionaryService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _QuestionaryService_Update_Handler,
+		},
+		{
+			MethodName: "AddAnswer",
+			Handler:    _QuestionaryService_AddAnswer_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _QuestionaryService_Delete_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _QuestionaryService_Restore_Handler,
+		},
+		{
+			MethodName: "FindAnswers",
+			Handler:    _QuestionaryService_FindAnswers_Handler,
+		},
+		{
+			MethodName: "UpdateAnswer",
+			Handler:    _QuestionaryService_UpdateAnswer_Handler,
+		},
+		{
+			MethodName: "DeleteAnswer",
+			Handler:    _QuestionaryService_DeleteAnswer_Handler,
+		},
+		{
+			MethodName: "AcceptAnswer",
+			Handler:    _QuestionaryService_AcceptAnswer_Handler,
+		},
+		{
+			MethodName: "UnacceptAnswer",
+			Handler:    _QuestionaryService_UnacceptAnswer_Handler,
+		},
+		{
+			MethodName: "Vote",
+			Handler:    _QuestionaryService_Vote_Handler,
+		},
+		{
+			MethodName: "RetractVote",
+			Handler:    _QuestionaryService_RetractVote_Handler,
+		},
+		{
+			MethodName: "AddComment",
+			Handler:    _QuestionaryService_AddComment_Handler,
+		},
+		{
+			MethodName: "FindComments",
+			Handler:    _QuestionaryService_FindComments_Handler,
+		},
+		{
+			MethodName: "UpdateComment",
+			Handler:    _QuestionaryService_UpdateComment_Handler,
+		},
+		{
+			MethodName: "DeleteComment",
+			Handler:    _QuestionaryService_DeleteComment_Handler,
+		},
+		{
+			MethodName: "ListRevisions",
+			Handler:    _QuestionaryService_ListRevisions_Handler,
+		},
+		{
+			MethodName: "RollbackRevision",
+			Handler:    _QuestionaryService_RollbackRevision_Handler,
+		},
+		{
+			MethodName: "FindRoles",
+			Handler:    _QuestionaryService_FindRoles_Handler,
+		},
+		{
+			MethodName: "GrantRole",
+			Handler:    _QuestionaryService_GrantRole_Handler,
+		},
+		{
+			MethodName: "RevokeRole",
+			Handler:    _QuestionaryService_RevokeRole_Handler,
+		},
+		{
+			MethodName: "CreateAPIKey",
+			Handler:    _QuestionaryService_CreateAPIKey_Handler,
+		},
+		{
+			MethodName: "ListAPIKeys",
+			Handler:    _QuestionaryService_ListAPIKeys_Handler,
+		},
+		{
+			MethodName: "RevokeAPIKey",
+			Handler:    _QuestionaryService_RevokeAPIKey_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
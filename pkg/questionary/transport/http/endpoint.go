@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/ratelimit"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/service"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/transport"
 )
@@ -16,27 +17,76 @@ type Endpoints struct {
 	FindAllQuestions    endpoint.Endpoint
 	FindQuestionById    endpoint.Endpoint
 	FindQuestionsByUser endpoint.Endpoint
+	FindQuestionsByTag  endpoint.Endpoint
+	FindTags            endpoint.Endpoint
+	SearchQuestions     endpoint.Endpoint
 	CreateQuestion      endpoint.Endpoint
 	AddAnswer           endpoint.Endpoint
 	UpdateQuestion      endpoint.Endpoint
 	DeleteQuestion      endpoint.Endpoint
+	RestoreQuestion     endpoint.Endpoint
+	FindAnswers         endpoint.Endpoint
+	UpdateAnswer        endpoint.Endpoint
+	DeleteAnswer        endpoint.Endpoint
+	AcceptAnswer        endpoint.Endpoint
+	UnacceptAnswer      endpoint.Endpoint
+	Vote                endpoint.Endpoint
+	RetractVote         endpoint.Endpoint
+	AddComment          endpoint.Endpoint
+	FindComments        endpoint.Endpoint
+	UpdateComment       endpoint.Endpoint
+	DeleteComment       endpoint.Endpoint
+	FindRevisions       endpoint.Endpoint
+	RollbackRevision    endpoint.Endpoint
+	FindRoles           endpoint.Endpoint
+	GrantRole           endpoint.Endpoint
+	RevokeRole          endpoint.Endpoint
+	CreateAPIKey        endpoint.Endpoint
+	FindAPIKeys         endpoint.Endpoint
+	RevokeAPIKey        endpoint.Endpoint
 }
 
-func MakeEndpoints(s service.Service) Endpoints {
+//The roles checked by the authorization of the endpoints are read with the role reader, not with the service,
+//so the requests do not log, trace and measure a call to FindRoles
+func MakeEndpoints(s service.Service, roles transport.RoleReader, limiters *ratelimit.Limiters) Endpoints {
 	return Endpoints{
-		FindAllQuestions:    makeFindAllQuestionsEndpoint(s),
-		FindQuestionById:    makeFindQuestionByIDEndpoint(s),
-		FindQuestionsByUser: makeFindQuestiosnByUserEndpoint(s),
-		CreateQuestion:      makeCreateQuestionEndpoint(s),
-		AddAnswer:           makeAddAnswerEndpoint(s),
-		UpdateQuestion:      makeUpdateQuestionEndPoint(s),
-		DeleteQuestion:      makeDeleteQuestionEndpoint(s),
+		FindAllQuestions:    transport.Middleware(roles, limiters, "FindAll")(makeFindAllQuestionsEndpoint(s)),
+		FindQuestionById:    transport.Middleware(roles, limiters, "FindByID")(makeFindQuestionByIDEndpoint(s)),
+		FindQuestionsByUser: transport.Middleware(roles, limiters, "FindByUser")(makeFindQuestiosnByUserEndpoint(s)),
+		FindQuestionsByTag:  transport.Middleware(roles, limiters, "FindByTag")(makeFindQuestionsByTagEndpoint(s)),
+		FindTags:            transport.Middleware(roles, limiters, "FindTags")(makeFindTagsEndpoint(s)),
+		SearchQuestions:     transport.Middleware(roles, limiters, "Search")(makeSearchQuestionsEndpoint(s)),
+		CreateQuestion:      transport.Middleware(roles, limiters, "Create")(makeCreateQuestionEndpoint(s)),
+		AddAnswer:           transport.Middleware(roles, limiters, "AddAnswer")(makeAddAnswerEndpoint(s)),
+		UpdateQuestion:      transport.Middleware(roles, limiters, "Update")(makeUpdateQuestionEndPoint(s)),
+		DeleteQuestion:      transport.Middleware(roles, limiters, "Delete")(makeDeleteQuestionEndpoint(s)),
+		RestoreQuestion:     transport.Middleware(roles, limiters, "Restore")(makeRestoreQuestionEndpoint(s)),
+		FindAnswers:         transport.Middleware(roles, limiters, "FindAnswers")(makeFindAnswersEndpoint(s)),
+		UpdateAnswer:        transport.Middleware(roles, limiters, "UpdateAnswer")(makeUpdateAnswerEndpoint(s)),
+		DeleteAnswer:        transport.Middleware(roles, limiters, "DeleteAnswer")(makeDeleteAnswerEndpoint(s)),
+		AcceptAnswer:        transport.Middleware(roles, limiters, "AcceptAnswer")(makeAcceptAnswerEndpoint(s)),
+		UnacceptAnswer:      transport.Middleware(roles, limiters, "UnacceptAnswer")(makeUnacceptAnswerEndpoint(s)),
+		Vote:                transport.Middleware(roles, limiters, "Vote")(makeVoteEndpoint(s)),
+		RetractVote:         transport.Middleware(roles, limiters, "RetractVote")(makeRetractVoteEndpoint(s)),
+		AddComment:          transport.Middleware(roles, limiters, "AddComment")(makeAddCommentEndpoint(s)),
+		FindComments:        transport.Middleware(roles, limiters, "FindComments")(makeFindCommentsEndpoint(s)),
+		UpdateComment:       transport.Middleware(roles, limiters, "UpdateComment")(makeUpdateCommentEndpoint(s)),
+		DeleteComment:       transport.Middleware(roles, limiters, "DeleteComment")(makeDeleteCommentEndpoint(s)),
+		FindRevisions:       transport.Middleware(roles, limiters, "FindRevisions")(makeFindRevisionsEndpoint(s)),
+		RollbackRevision:    transport.Middleware(roles, limiters, "RollbackRevision")(makeRollbackRevisionEndpoint(s)),
+		FindRoles:           transport.Middleware(roles, limiters, "FindRoles")(makeFindRolesEndpoint(s)),
+		GrantRole:           transport.Middleware(roles, limiters, "GrantRole")(makeGrantRoleEndpoint(s)),
+		RevokeRole:          transport.Middleware(roles, limiters, "RevokeRole")(makeRevokeRoleEndpoint(s)),
+		CreateAPIKey:        transport.Middleware(roles, limiters, "CreateAPIKey")(makeCreateAPIKeyEndpoint(s)),
+		FindAPIKeys:         transport.Middleware(roles, limiters, "FindAPIKeys")(makeFindAPIKeysEndpoint(s)),
+		RevokeAPIKey:        transport.Middleware(roles, limiters, "RevokeAPIKey")(makeRevokeAPIKeyEndpoint(s)),
 	}
 }
 
 func makeFindAllQuestionsEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		questions, err := s.FindAll(ctx)
+		req := request.(transport.FindAllQuestionsRequest)
+		questions, err := s.FindAll(ctx, req.Filter, req.Page)
 		return questions, err
 	}
 }
@@ -52,11 +102,34 @@ func makeFindQuestionByIDEndpoint(s service.Service) endpoint.Endpoint {
 func makeFindQuestiosnByUserEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(transport.FindQuestionsByUserRequest)
-		questions, err := s.FindByUser(ctx, req.UserID)
+		questions, err := s.FindByUser(ctx, req.UserID, req.Page)
 		return questions, err
 	}
 }
 
+func makeFindQuestionsByTagEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.FindQuestionsByTagRequest)
+		questions, err := s.FindByTag(ctx, req.Tag)
+		return questions, err
+	}
+}
+
+func makeFindTagsEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		tags, err := s.FindTags(ctx)
+		return tags, err
+	}
+}
+
+func makeSearchQuestionsEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.SearchQuestionsRequest)
+		results, err := s.Search(ctx, req.Query, req.Filter)
+		return results, err
+	}
+}
+
 func makeCreateQuestionEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		question := request.(domain.Question)
@@ -82,9 +155,183 @@ func makeUpdateQuestionEndPoint(s service.Service) endpoint.Endpoint {
 }
 
 func makeDeleteQuestionEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.DeleteQuestionRequest)
+		msg, err := s.Delete(ctx, req.ID, req.UserID)
+
+		return transport.GenericMessageResponse{
+			Message: msg,
+			Status:  http.StatusText(http.StatusOK),
+			Code:    http.StatusOK,
+		}, err
+	}
+}
+
+func makeRestoreQuestionEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.IDParamRequest)
+		questionInfo, err := s.Restore(ctx, req.ID)
+		return questionInfo, err
+	}
+}
+
+func makeFindAnswersEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.IDParamRequest)
+		answers, err := s.FindAnswers(ctx, req.ID)
+		return answers, err
+	}
+}
+
+func makeUpdateAnswerEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		answer := request.(domain.Answer)
+		answer, err := s.UpdateAnswer(ctx, answer)
+		return answer, err
+	}
+}
+
+func makeDeleteAnswerEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.AnswerParamRequest)
+		msg, err := s.DeleteAnswer(ctx, req.QuestionID, req.AnswerID)
+
+		return transport.GenericMessageResponse{
+			Message: msg,
+			Status:  http.StatusText(http.StatusOK),
+			Code:    http.StatusOK,
+		}, err
+	}
+}
+
+func makeAcceptAnswerEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.AcceptAnswerRequest)
+		questionInfo, err := s.AcceptAnswer(ctx, req.QuestionID, req.AnswerID, req.UserID)
+		return questionInfo, err
+	}
+}
+
+func makeUnacceptAnswerEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.AcceptAnswerRequest)
+		questionInfo, err := s.UnacceptAnswer(ctx, req.QuestionID, req.UserID)
+		return questionInfo, err
+	}
+}
+
+func makeVoteEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.VoteRequest)
+		score, err := s.Vote(ctx, req.TargetType, req.TargetID, req.UserID, req.Value)
+		return score, err
+	}
+}
+
+func makeRetractVoteEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.VoteRequest)
+		score, err := s.RetractVote(ctx, req.TargetType, req.TargetID, req.UserID)
+		return score, err
+	}
+}
+
+func makeAddCommentEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(domain.Comment)
+		comment, err := s.AddComment(ctx, req)
+		return comment, err
+	}
+}
+
+func makeFindCommentsEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.FindCommentsRequest)
+		comments, err := s.FindComments(ctx, req.ParentType, req.ParentID)
+		return comments, err
+	}
+}
+
+func makeUpdateCommentEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(domain.Comment)
+		comment, err := s.UpdateComment(ctx, req)
+		return comment, err
+	}
+}
+
+func makeDeleteCommentEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.CommentParamRequest)
+		msg, err := s.DeleteComment(ctx, req.QuestionID, req.CommentID)
+
+		return transport.GenericMessageResponse{
+			Message: msg,
+			Status:  http.StatusText(http.StatusOK),
+			Code:    http.StatusOK,
+		}, err
+	}
+}
+
+func makeFindRevisionsEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.IDParamRequest)
+		revisions, err := s.FindRevisions(ctx, req.ID)
+		return revisions, err
+	}
+}
+
+func makeRollbackRevisionEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.RollbackRevisionRequest)
+		questionInfo, err := s.RollbackRevision(ctx, req.QuestionID, req.Number, req.UserID)
+		return questionInfo, err
+	}
+}
+
+func makeFindRolesEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.RoleRequest)
+		roles, err := s.FindRoles(ctx, req.UserID)
+		return roles, err
+	}
+}
+
+func makeGrantRoleEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.RoleRequest)
+		roles, err := s.GrantRole(ctx, req.UserID, req.Role)
+		return roles, err
+	}
+}
+
+func makeRevokeRoleEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transport.RoleRequest)
+		roles, err := s.RevokeRole(ctx, req.UserID, req.Role)
+		return roles, err
+	}
+}
+
+func makeCreateAPIKeyEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		apiKey := request.(domain.APIKey)
+		createdKey, err := s.CreateAPIKey(ctx, apiKey)
+		return createdKey, err
+	}
+}
+
+func makeFindAPIKeysEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		apiKeys, err := s.FindAPIKeys(ctx)
+		return apiKeys, err
+	}
+}
+
+func makeRevokeAPIKeyEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(transport.IDParamRequest)
-		msg, err := s.Delete(ctx, req.ID)
+		msg, err := s.RevokeAPIKey(ctx, req.ID)
 
 		return transport.GenericMessageResponse{
 			Message: msg,
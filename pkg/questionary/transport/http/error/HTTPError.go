@@ -8,9 +8,10 @@ import (
 
 // HTTPError implements ClientError interface.
 type HTTPError struct {
-	Cause  error  `json:"-"`
-	Detail string `json:"detail"`
-	Status int    `json:"status"`
+	Cause     error  `json:"-"`
+	Detail    string `json:"detail"`
+	Status    int    `json:"status"`
+	RequestID string `json:"requestId,omitempty"`
 }
 
 func NewClientError(err error, status int, detail string) ClientError {
@@ -29,6 +30,18 @@ func NewServerError(err error, detail string) InternalServerError {
 	}
 }
 
+//Returns a copy of the error with the ID of the request that produced it,
+//the errors of other types are returned unchanged.
+func WithRequestID(err ClientError, requestID string) ClientError {
+	httpErr, ok := err.(*HTTPError)
+	if !ok || requestID == "" {
+		return err
+	}
+	withID := *httpErr
+	withID.RequestID = requestID
+	return &withID
+}
+
 func (e *HTTPError) Error() string {
 	return e.Detail
 }
@@ -0,0 +1,44 @@
+package error
+
+import (
+	"net/http"
+
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
+)
+
+//The HTTP status of each kind of error of the service and the repositories
+var statusCodes = map[apperror.Kind]int{
+	apperror.Internal:        http.StatusInternalServerError,
+	apperror.NotFound:        http.StatusNotFound,
+	apperror.Conflict:        http.StatusConflict,
+	apperror.Invalid:         http.StatusBadRequest,
+	apperror.Unauthenticated: http.StatusUnauthorized,
+	apperror.Forbidden:       http.StatusForbidden,
+	apperror.RateLimited:     http.StatusTooManyRequests,
+	apperror.Unavailable:     http.StatusServiceUnavailable,
+}
+
+//Method that returns the HTTP status of the kind of error
+func StatusCode(kind apperror.Kind) int {
+	if status, ok := statusCodes[kind]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+//Method that converts an error of the service or the repositories to the HTTP error of its kind,
+//the errors that are neither of those nor HTTP errors can not be shown to the client.
+func FromError(err error) (*HTTPError, bool) {
+	if httpErr, ok := err.(*HTTPError); ok {
+		return httpErr, true
+	}
+	detail, ok := apperror.DetailOf(err)
+	if !ok {
+		return nil, false
+	}
+	return &HTTPError{
+		Cause:  err,
+		Detail: detail,
+		Status: StatusCode(apperror.KindOf(err)),
+	}, true
+}
@@ -0,0 +1,29 @@
+package error
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusCode(t *testing.T) {
+	for _, kind := range apperror.Kinds {
+		_, ok := statusCodes[kind]
+		assert.True(t, ok, kind.String())
+	}
+	assert.Equal(t, http.StatusNotFound, StatusCode(apperror.NotFound))
+	assert.Equal(t, http.StatusServiceUnavailable, StatusCode(apperror.Unavailable))
+}
+
+func TestFromError(t *testing.T) {
+	httpErr, ok := FromError(apperror.New(errors.New("duplicated key"), apperror.Conflict, "The question already exists"))
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusConflict, httpErr.Status)
+	assert.Equal(t, "The question already exists", httpErr.Detail)
+
+	_, ok = FromError(errors.New("connection refused"))
+	assert.False(t, ok)
+}
@@ -4,10 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 
+	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/gorilla/mux"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/requestid"
 	"github.com/ismaeljpv/qa-api/pkg/questionary/transport"
 	httpError "github.com/ismaeljpv/qa-api/pkg/questionary/transport/http/error"
 )
@@ -19,40 +26,191 @@ import (
 func DecodeIDParamRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	id, ok := mux.Vars(r)["id"]
 	if !ok {
-		return nil, httpError.NewClientError(errors.New("Question ID is required"),
-			http.StatusBadRequest,
+		return nil, apperror.New(errors.New("Question ID is required"),
+			apperror.Invalid,
 			"Question ID is required")
 	}
 	return transport.IDParamRequest{ID: id}, nil
 }
 
+func DecodeRoleRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	req := transport.RoleRequest{UserID: vars["userId"], Role: vars["role"]}
+
+	valErr := transport.ValidateStruct(&req)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+	return req, nil
+}
+
+func DecodeCreateAPIKeyRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var body domain.APIKey
+	err := decodeBody(r, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	valErr := transport.ValidateStruct(&body)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+	return body, nil
+}
+
 func DecodeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	var req transport.GenericRequest
 	return req, nil
 }
 
+func DecodeFindAllQuestionsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req transport.FindAllQuestionsRequest
+	page, err := decodePage(r)
+	if err != nil {
+		return nil, err
+	}
+	req.Page = page
+
+	filter, err := decodeQuestionFilter(r)
+	if err != nil {
+		return nil, err
+	}
+	req.Filter = filter
+	return req, nil
+}
+
+func DecodeSearchQuestionsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req transport.SearchQuestionsRequest
+	req.Query = r.URL.Query().Get("q")
+	if req.Query == "" {
+		return nil, apperror.New(errors.New("Search query is required"),
+			apperror.Invalid,
+			"The search query is required")
+	}
+
+	filter, err := decodeQuestionFilter(r)
+	if err != nil {
+		return nil, err
+	}
+	req.Filter = filter
+	return req, nil
+}
+
+//The filters of a listing are read from the query params
+func decodeQuestionFilter(r *http.Request) (domain.QuestionFilter, error) {
+	var filter domain.QuestionFilter
+	query := r.URL.Query()
+
+	if unresolved := query.Get("unresolved"); unresolved != "" {
+		value, err := strconv.ParseBool(unresolved)
+		if err != nil {
+			return filter, apperror.New(err,
+				apperror.Invalid,
+				"The unresolved filter must be true or false")
+		}
+		filter.Unresolved = value
+	}
+
+	if answered := query.Get("answered"); answered != "" {
+		value, err := strconv.ParseBool(answered)
+		if err != nil {
+			return filter, apperror.New(err,
+				apperror.Invalid,
+				"The answered filter must be true or false")
+		}
+		filter.Answered = &value
+	}
+
+	if createdFrom := query.Get("createdFrom"); createdFrom != "" {
+		value, err := strconv.ParseInt(createdFrom, 10, 64)
+		if err != nil || value <= 0 {
+			return filter, apperror.New(errors.New("Invalid createdFrom"),
+				apperror.Invalid,
+				"The createdFrom filter must be a unix time")
+		}
+		filter.CreatedFrom = value
+	}
+
+	if createdTo := query.Get("createdTo"); createdTo != "" {
+		value, err := strconv.ParseInt(createdTo, 10, 64)
+		if err != nil || value <= 0 {
+			return filter, apperror.New(errors.New("Invalid createdTo"),
+				apperror.Invalid,
+				"The createdTo filter must be a unix time")
+		}
+		filter.CreatedTo = value
+	}
+
+	filter.AuthorID = query.Get("author")
+	filter.AnswererID = query.Get("answerer")
+	return filter, nil
+}
+
 func DecodeFindQuestionByUserRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	userId, ok := mux.Vars(r)["userId"]
 
 	if !ok {
-		return nil, httpError.NewClientError(errors.New("User ID is required"),
-			http.StatusBadRequest,
+		return nil, apperror.New(errors.New("User ID is required"),
+			apperror.Invalid,
 			"User ID is required")
 	}
-	return transport.FindQuestionsByUserRequest{UserID: userId}, nil
+
+	page, err := decodePage(r)
+	if err != nil {
+		return nil, err
+	}
+	return transport.FindQuestionsByUserRequest{UserID: userId, Page: page}, nil
+}
+
+//The page of a listing is read from the limit, cursor and sort query params
+func decodePage(r *http.Request) (domain.Page, error) {
+	var page domain.Page
+	query := r.URL.Query()
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.ParseInt(limit, 10, 64)
+		if err != nil || value <= 0 {
+			return page, apperror.New(errors.New("Invalid limit"),
+				apperror.Invalid,
+				"The limit must be a positive number")
+		}
+		page.Limit = value
+	}
+	page.Cursor = query.Get("cursor")
+	page.Sort = query.Get("sort")
+	return page, nil
+}
+
+func DecodeFindQuestionsByTagRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	tag, ok := mux.Vars(r)["tag"]
+
+	if !ok {
+		return nil, apperror.New(errors.New("Tag is required"),
+			apperror.Invalid,
+			"Tag is required")
+	}
+	return transport.FindQuestionsByTagRequest{Tag: tag}, nil
 }
 
 func DecodeCreateQuestionRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	var body domain.Question
-	err := json.NewDecoder(r.Body).Decode(&body)
+	err := decodeBody(r, &body)
 	if err != nil {
 		return nil, err
 	}
+	body.UserID = transport.AuthenticatedUser(ctx, body.UserID)
+	body.NormalizeTags()
 
 	valErr := transport.ValidateStruct(&body)
 	if valErr != nil {
-		return nil, httpError.NewClientError(valErr,
-			http.StatusBadRequest,
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
 			valErr.Error(),
 		)
 	}
@@ -62,15 +220,16 @@ func DecodeCreateQuestionRequest(ctx context.Context, r *http.Request) (interfac
 
 func DecodeAddAnswerRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	var body domain.Answer
-	err := json.NewDecoder(r.Body).Decode(&body)
+	err := decodeBody(r, &body)
 	if err != nil {
 		return nil, err
 	}
+	body.UserID = transport.AuthenticatedUser(ctx, body.UserID)
 
 	valErr := transport.ValidateStruct(&body)
 	if valErr != nil {
-		return nil, httpError.NewClientError(valErr,
-			http.StatusBadRequest,
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
 			valErr.Error(),
 		)
 	}
@@ -78,28 +237,193 @@ func DecodeAddAnswerRequest(ctx context.Context, r *http.Request) (interface{},
 	return body, nil
 }
 
+func DecodeAnswerParamRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	questionId, ok := vars["id"]
+	if !ok {
+		return nil, apperror.New(errors.New("Question ID is required"),
+			apperror.Invalid,
+			"Question ID is required")
+	}
+
+	answerId, ok := vars["answerId"]
+	if !ok {
+		return nil, apperror.New(errors.New("Answer ID is required"),
+			apperror.Invalid,
+			"Answer ID is required")
+	}
+	return transport.AnswerParamRequest{QuestionID: questionId, AnswerID: answerId}, nil
+}
+
+func DecodeAddQuestionAnswerRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var body domain.Answer
+	questionId, ok := mux.Vars(r)["id"]
+	if !ok {
+		return nil, apperror.New(errors.New("Question ID is required"),
+			apperror.Invalid,
+			"Question ID is required")
+	}
+
+	err := decodeBody(r, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	if body.QuestionID != "" && body.QuestionID != questionId {
+		return nil, apperror.New(errors.New("Invalid Request"),
+			apperror.Invalid,
+			"There is a inconsistency with the information of the request")
+	}
+	body.QuestionID = questionId
+	body.UserID = transport.AuthenticatedUser(ctx, body.UserID)
+
+	valErr := transport.ValidateStruct(&body)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+
+	return body, nil
+}
+
+func DecodeUpdateAnswerRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var body domain.Answer
+	params, err := DecodeAnswerParamRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req := params.(transport.AnswerParamRequest)
+
+	err = decodeBody(r, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	if (body.ID != "" && body.ID != req.AnswerID) || (body.QuestionID != "" && body.QuestionID != req.QuestionID) {
+		return nil, apperror.New(errors.New("Invalid Request"),
+			apperror.Invalid,
+			"There is a inconsistency with the information of the request")
+	}
+	body.ID = req.AnswerID
+	body.QuestionID = req.QuestionID
+	body.UserID = transport.AuthenticatedUser(ctx, body.UserID)
+
+	valErr := transport.ValidateStruct(&body)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+
+	return body, nil
+}
+
+func DecodeAcceptAnswerRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req transport.AcceptAnswerRequest
+	params, err := DecodeAnswerParamRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = decodeOptionalBody(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	req.QuestionID = params.(transport.AnswerParamRequest).QuestionID
+	req.AnswerID = params.(transport.AnswerParamRequest).AnswerID
+	req.UserID = transport.AuthenticatedUser(ctx, req.UserID)
+
+	valErr := transport.ValidateStruct(&req)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+	return req, nil
+}
+
+func DecodeDeleteQuestionRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req transport.DeleteQuestionRequest
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		return nil, apperror.New(errors.New("Question ID is required"),
+			apperror.Invalid,
+			"Question ID is required")
+	}
+
+	err := decodeOptionalBody(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	req.ID = id
+	req.UserID = transport.AuthenticatedUser(ctx, req.UserID)
+
+	valErr := transport.ValidateStruct(&req)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+	return req, nil
+}
+
+func DecodeUnacceptAnswerRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req transport.AcceptAnswerRequest
+	questionId, ok := mux.Vars(r)["id"]
+	if !ok {
+		return nil, apperror.New(errors.New("Question ID is required"),
+			apperror.Invalid,
+			"Question ID is required")
+	}
+
+	err := decodeOptionalBody(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	req.QuestionID = questionId
+	req.AnswerID = ""
+	req.UserID = transport.AuthenticatedUser(ctx, req.UserID)
+
+	valErr := transport.ValidateStruct(&req)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+	return req, nil
+}
+
 func DecodeUpdateQuestionRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	var req transport.UpdateQuestionRequest
 	var info domain.QuestionInfo
 
 	quetionId, ok := mux.Vars(r)["id"]
 	if !ok {
-		return nil, httpError.NewClientError(errors.New("Question ID is required"),
-			http.StatusBadRequest,
+		return nil, apperror.New(errors.New("Question ID is required"),
+			apperror.Invalid,
 			"Question ID is required")
 	}
 
-	err := json.NewDecoder(r.Body).Decode(&info)
+	err := decodeBody(r, &info)
 	if err != nil {
 		return nil, err
 	}
 
+	info.Question.NormalizeTags()
+	info.Question.UserID = transport.AuthenticatedUser(ctx, info.Question.UserID)
+	info.Answer.UserID = transport.AuthenticatedUser(ctx, info.Answer.UserID)
 	req.ID = quetionId
 	req.QuestionInfo = info
-	valErr := transport.ValidateStruct(&req.QuestionInfo)
+	valErr := transport.ValidateQuestionUpdate(&req.QuestionInfo)
 	if valErr != nil {
-		return nil, httpError.NewClientError(valErr,
-			http.StatusBadRequest,
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
 			valErr.Error(),
 		)
 	}
@@ -107,42 +431,253 @@ func DecodeUpdateQuestionRequest(ctx context.Context, r *http.Request) (interfac
 	return req, nil
 }
 
+func DecodeCommentParamRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	questionId, ok := vars["id"]
+	if !ok {
+		return nil, apperror.New(errors.New("Question ID is required"),
+			apperror.Invalid,
+			"Question ID is required")
+	}
+
+	commentId, ok := vars["commentId"]
+	if !ok {
+		return nil, apperror.New(errors.New("Comment ID is required"),
+			apperror.Invalid,
+			"Comment ID is required")
+	}
+	return transport.CommentParamRequest{QuestionID: questionId, CommentID: commentId}, nil
+}
+
+//The comments belong to a question unless the route has the ID of one of its answers
+func DecodeFindCommentsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	questionId, ok := vars["id"]
+	if !ok {
+		return nil, apperror.New(errors.New("Question ID is required"),
+			apperror.Invalid,
+			"Question ID is required")
+	}
+
+	if answerId, ok := vars["answerId"]; ok {
+		return transport.FindCommentsRequest{ParentType: domain.TargetAnswer, ParentID: answerId}, nil
+	}
+	return transport.FindCommentsRequest{ParentType: domain.TargetQuestion, ParentID: questionId}, nil
+}
+
+func DecodeAddCommentRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var body domain.Comment
+	params, err := DecodeFindCommentsRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	parent := params.(transport.FindCommentsRequest)
+
+	err = decodeBody(r, &body)
+	if err != nil {
+		return nil, err
+	}
+	body.ParentType = parent.ParentType
+	body.ParentID = parent.ParentID
+	body.QuestionID = mux.Vars(r)["id"]
+	body.UserID = transport.AuthenticatedUser(ctx, body.UserID)
+
+	valErr := transport.ValidateStruct(&body)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+
+	return body, nil
+}
+
+func DecodeUpdateCommentRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var body domain.Comment
+	params, err := DecodeCommentParamRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req := params.(transport.CommentParamRequest)
+
+	err = decodeBody(r, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	if (body.ID != "" && body.ID != req.CommentID) || (body.QuestionID != "" && body.QuestionID != req.QuestionID) {
+		return nil, apperror.New(errors.New("Invalid Request"),
+			apperror.Invalid,
+			"There is a inconsistency with the information of the request")
+	}
+	body.ID = req.CommentID
+	body.QuestionID = req.QuestionID
+	body.UserID = transport.AuthenticatedUser(ctx, body.UserID)
+
+	valErr := transport.ValidateStruct(&body)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+
+	return body, nil
+}
+
+func DecodeRollbackRevisionRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req transport.RollbackRevisionRequest
+	vars := mux.Vars(r)
+	questionId, ok := vars["id"]
+	if !ok {
+		return nil, apperror.New(errors.New("Question ID is required"),
+			apperror.Invalid,
+			"Question ID is required")
+	}
+
+	number, err := strconv.ParseInt(vars["number"], 10, 64)
+	if err != nil || number <= 0 {
+		return nil, apperror.New(errors.New("Invalid revision number"),
+			apperror.Invalid,
+			"The revision number must be a positive number")
+	}
+
+	err = decodeOptionalBody(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	req.QuestionID = questionId
+	req.Number = number
+	req.UserID = transport.AuthenticatedUser(ctx, req.UserID)
+
+	valErr := transport.ValidateStruct(&req)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+	return req, nil
+}
+
+//The body of the request is decoded as JSON, a missing or malformed body is an invalid request
+func decodeBody(r *http.Request, v interface{}) error {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err == io.EOF {
+		return apperror.New(err,
+			apperror.Invalid,
+			"The body of the request is required")
+	}
+	if err != nil {
+		return apperror.New(err,
+			apperror.Invalid,
+			"The body of the request is not a valid JSON")
+	}
+	return nil
+}
+
+//The body is optional on the requests that only need the user, which is taken from the token,
+//so an empty body is decoded as an empty request
+func decodeOptionalBody(r *http.Request, v interface{}) error {
+	err := decodeBody(r, v)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
+
 func EncodeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
 	w.WriteHeader(http.StatusOK)
 	return json.NewEncoder(w).Encode(response)
 }
 
+//The page is returned with a link to the next page that keeps the query params of the request,
+//the request URI is read from the context populated by httptransport.PopulateRequestContext
+func EncodeQuestionPageResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	page, ok := response.(domain.QuestionPage)
+	if !ok {
+		return EncodeResponse(ctx, w, response)
+	}
+
+	resp := transport.QuestionPageResponse{Questions: page.Questions, NextCursor: page.NextCursor}
+	requestURI, _ := ctx.Value(httptransport.ContextKeyRequestURI).(string)
+	if next, err := url.ParseRequestURI(requestURI); page.NextCursor != "" && err == nil {
+		query := next.Query()
+		query.Set("cursor", page.NextCursor)
+		next.RawQuery = query.Encode()
+		resp.Next = next.String()
+	}
+	return EncodeResponse(ctx, w, resp)
+}
+
+//The errors are written with the HTTP status of its kind, the unexpected errors do not show its detail
 func HTTPErrorHandler(ctx context.Context, err error, w http.ResponseWriter) {
+	requestID := requestid.FromContext(ctx)
 
-	switch er := err.(type) {
-	case httpError.ClientError:
-		body, err := er.ResponseBody()
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte("There was an error procesing your request"))
-			return
-		}
-		status, headers := er.ResponseHeaders()
-		for k, v := range headers {
-			w.Header().Set(k, v)
-		}
-		w.WriteHeader(status)
-		w.Write(body)
-	case httpError.InternalServerError:
-		body, err := er.ResponseBody()
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte("There was an error procesing your request"))
-			return
-		}
-		_, headers := er.ResponseHeaders()
-		for k, v := range headers {
-			w.Header().Set(k, v)
-		}
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write(body)
-	default:
-		w.WriteHeader(http.StatusInternalServerError)
+	httpErr, ok := httpError.FromError(err)
+	if !ok {
+		writeUnexpectedError(w, requestID)
+		return
+	}
+	body, err := httpError.WithRequestID(httpErr, requestID).ResponseBody()
+	if err != nil {
+		writeUnexpectedError(w, requestID)
+		return
+	}
+	status, headers := httpErr.ResponseHeaders()
+	for k, v := range headers {
+		w.Header().Set(k, v)
+	}
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+//The unexpected errors have the request ID so the client can report it
+func writeUnexpectedError(w http.ResponseWriter, requestID string) {
+	w.WriteHeader(http.StatusInternalServerError)
+	if requestID == "" {
 		w.Write([]byte("There was an error procesing your request"))
+		return
 	}
+	w.Write([]byte(fmt.Sprintf("There was an error procesing your request, request ID %v", requestID)))
+}
+
+//The vote target is a question unless the route has the ID of one of its answers,
+//the value is only required to vote, a vote is retracted without a body
+func DecodeVoteRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req transport.VoteRequest
+	vars := mux.Vars(r)
+	questionId, ok := vars["id"]
+	if !ok {
+		return nil, apperror.New(errors.New("Question ID is required"),
+			apperror.Invalid,
+			"Question ID is required")
+	}
+
+	err := decodeOptionalBody(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	if r.Method == http.MethodPost && req.Value == 0 {
+		return nil, apperror.New(errors.New("Vote value is required"),
+			apperror.Invalid,
+			"The value of the vote is required")
+	}
+	req.TargetType = domain.TargetQuestion
+	req.TargetID = questionId
+	if answerId, ok := vars["answerId"]; ok {
+		req.TargetType = domain.TargetAnswer
+		req.TargetID = answerId
+	}
+	req.UserID = transport.AuthenticatedUser(ctx, req.UserID)
+
+	valErr := transport.ValidateStruct(&req)
+	if valErr != nil {
+		return nil, apperror.New(valErr,
+			apperror.Invalid,
+			valErr.Error(),
+		)
+	}
+	return req, nil
 }
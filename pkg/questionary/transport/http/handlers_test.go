@@ -0,0 +1,151 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/apperror"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/auth"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/domain"
+	"github.com/ismaeljpv/qa-api/pkg/questionary/transport"
+	"github.com/stretchr/testify/assert"
+)
+
+//The user of the decoded request, whatever the type of the request is
+func userOf(t *testing.T, request interface{}) string {
+	switch req := request.(type) {
+	case transport.DeleteQuestionRequest:
+		return req.UserID
+	case transport.AcceptAnswerRequest:
+		return req.UserID
+	case transport.VoteRequest:
+		return req.UserID
+	case transport.RollbackRevisionRequest:
+		return req.UserID
+	case domain.Comment:
+		return req.UserID
+	}
+	t.Fatalf("Unexpected request %T", request)
+	return ""
+}
+
+func TestDecodeUpdateQuestionRequest_WithoutAnswer(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPut, "/question/2", strings.NewReader(`{"question": {"id": "2", "statement": "Where are all the gophers now?"}}`))
+	decoded, err := DecodeUpdateQuestionRequest(auth.WithSubject(context.Background(), "1"), mux.SetURLVars(r, map[string]string{"id": "2"}))
+	assert.Nil(t, err)
+	req := decoded.(transport.UpdateQuestionRequest)
+	assert.Equal(t, "1", req.QuestionInfo.Question.UserID)
+	assert.False(t, req.QuestionInfo.HasAnswerUpdate())
+
+	//An answer without its text is still rejected
+	r = httptest.NewRequest(http.MethodPut, "/question/2", strings.NewReader(`{"question": {"id": "2", "statement": "Where?"}, "answer": {"id": "1"}}`))
+	_, err = DecodeUpdateQuestionRequest(context.Background(), mux.SetURLVars(r, map[string]string{"id": "2"}))
+	assert.NotNil(t, err)
+}
+
+func TestDecoders_UseAuthenticatedUser(t *testing.T) {
+	decoders := map[string]struct {
+		decode func(context.Context, *http.Request) (interface{}, error)
+		vars   map[string]string
+		body   string
+	}{
+		"AcceptAnswer":     {DecodeAcceptAnswerRequest, map[string]string{"id": "q1", "answerId": "a1"}, `{"userId": "author"}`},
+		"UnacceptAnswer":   {DecodeUnacceptAnswerRequest, map[string]string{"id": "q1"}, `{"userId": "author"}`},
+		"Vote":             {DecodeVoteRequest, map[string]string{"id": "q1"}, `{"userId": "author", "value": 1}`},
+		"AddComment":       {DecodeAddCommentRequest, map[string]string{"id": "q1"}, `{"userId": "author", "comment": "Nice"}`},
+		"UpdateComment":    {DecodeUpdateCommentRequest, map[string]string{"id": "q1", "commentId": "c1"}, `{"userId": "author", "comment": "Nice"}`},
+		"RollbackRevision": {DecodeRollbackRevisionRequest, map[string]string{"id": "q1", "number": "1"}, `{"userId": "author"}`},
+	}
+
+	request := func(vars map[string]string, body string) *http.Request {
+		return mux.SetURLVars(httptest.NewRequest(http.MethodPost, "/question", strings.NewReader(body)), vars)
+	}
+
+	for name, d := range decoders {
+		//The user sent on the body is replaced by the subject of the token
+		req, err := d.decode(auth.WithSubject(context.Background(), "intruder"), request(d.vars, d.body))
+		assert.Nil(t, err, name)
+		assert.Equal(t, "intruder", userOf(t, req), name)
+
+		//The user of the body is only used when the request was not authenticated
+		req, err = d.decode(context.Background(), request(d.vars, d.body))
+		assert.Nil(t, err, name)
+		assert.Equal(t, "author", userOf(t, req), name)
+	}
+}
+
+func TestDecoders_OptionalBody(t *testing.T) {
+	decoders := map[string]struct {
+		decode func(context.Context, *http.Request) (interface{}, error)
+		method string
+		vars   map[string]string
+	}{
+		"DeleteQuestion":    {DecodeDeleteQuestionRequest, http.MethodDelete, map[string]string{"id": "q1"}},
+		"AcceptAnswer":      {DecodeAcceptAnswerRequest, http.MethodPost, map[string]string{"id": "q1", "answerId": "a1"}},
+		"UnacceptAnswer":    {DecodeUnacceptAnswerRequest, http.MethodPost, map[string]string{"id": "q1"}},
+		"RollbackRevision":  {DecodeRollbackRevisionRequest, http.MethodPost, map[string]string{"id": "q1", "number": "1"}},
+		"RetractVote":       {DecodeVoteRequest, http.MethodDelete, map[string]string{"id": "q1"}},
+		"RetractAnswerVote": {DecodeVoteRequest, http.MethodDelete, map[string]string{"id": "q1", "answerId": "a1"}},
+	}
+
+	request := func(method string, vars map[string]string, body string) *http.Request {
+		return mux.SetURLVars(httptest.NewRequest(method, "/question", strings.NewReader(body)), vars)
+	}
+
+	for name, d := range decoders {
+		//Without a body the user is the subject of the token
+		req, err := d.decode(auth.WithSubject(context.Background(), "author"), request(d.method, d.vars, ""))
+		assert.Nil(t, err, name)
+		assert.Equal(t, "author", userOf(t, req), name)
+
+		//A body that is not a valid JSON is rejected
+		_, err = d.decode(auth.WithSubject(context.Background(), "author"), request(d.method, d.vars, `{"userId": `))
+		assert.Equal(t, apperror.Invalid, apperror.KindOf(err), name)
+
+		//Without a token nor a body there is no user
+		_, err = d.decode(context.Background(), request(d.method, d.vars, ""))
+		assert.Equal(t, apperror.Invalid, apperror.KindOf(err), name)
+	}
+}
+
+func TestDecodeVoteRequest_ValueRequired(t *testing.T) {
+	ctx := auth.WithSubject(context.Background(), "author")
+	vote := func(body string) *http.Request {
+		return mux.SetURLVars(httptest.NewRequest(http.MethodPost, "/question/q1/vote", strings.NewReader(body)), map[string]string{"id": "q1"})
+	}
+
+	_, err := DecodeVoteRequest(ctx, vote(""))
+	assert.Equal(t, apperror.Invalid, apperror.KindOf(err))
+
+	_, err = DecodeVoteRequest(ctx, vote(`{"userId": "author"}`))
+	assert.Equal(t, apperror.Invalid, apperror.KindOf(err))
+
+	decoded, err := DecodeVoteRequest(ctx, vote(`{"value": -1}`))
+	assert.Nil(t, err)
+	assert.Equal(t, -1, decoded.(transport.VoteRequest).Value)
+	assert.Equal(t, "author", decoded.(transport.VoteRequest).UserID)
+}
+
+//An empty or malformed body is a bad request on every decoder that requires a body
+func TestHTTPErrorHandler_InvalidBody(t *testing.T) {
+	endpoint := func(ctx context.Context, request interface{}) (interface{}, error) {
+		return request, nil
+	}
+	server := httptransport.NewServer(endpoint,
+		DecodeCreateQuestionRequest,
+		EncodeResponse,
+		httptransport.ServerErrorEncoder(HTTPErrorHandler),
+	)
+
+	for name, body := range map[string]string{"Empty": "", "Broken": `{"statement": "Where are`, "WrongType": `{"statement": 1}`} {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/question", strings.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+		assert.Contains(t, w.Body.String(), "The body of the request", name)
+	}
+}